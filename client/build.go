@@ -8,6 +8,7 @@ import (
 	"github.com/moby/buildkit/frontend/gateway/grpcclient"
 	gatewayapi "github.com/moby/buildkit/frontend/gateway/pb"
 	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/apicaps"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -53,6 +54,13 @@ func (c *Client) Build(ctx context.Context, opt SolveOpt, product string, buildF
 
 		gwClient.caps = g.BuildOpts().Caps
 
+		if len(opt.Exports) > 1 {
+			llbCaps := g.BuildOpts().LLBCaps
+			if err := llbCaps.Supports(pb.CapMultipleExporters); err != nil {
+				return errors.Wrap(err, "multiple exporters are not supported")
+			}
+		}
+
 		if err := g.Run(ctx, buildFunc); err != nil {
 			return errors.Wrap(err, "failed to run Build function")
 		}
@@ -148,3 +156,35 @@ func (g *gatewayClientForBuild) ExecProcess(ctx context.Context, opts ...grpc.Ca
 	ctx = buildid.AppendToOutgoingContext(ctx, g.buildID)
 	return g.gateway.ExecProcess(ctx, opts...)
 }
+
+func (g *gatewayClientForBuild) SnapshotMount(ctx context.Context, in *gatewayapi.SnapshotMountRequest, opts ...grpc.CallOption) (*gatewayapi.SnapshotMountResponse, error) {
+	if err := g.caps.Supports(gatewayapi.CapGatewayMountSnapshot); err != nil {
+		return nil, err
+	}
+	ctx = buildid.AppendToOutgoingContext(ctx, g.buildID)
+	return g.gateway.SnapshotMount(ctx, in, opts...)
+}
+
+func (g *gatewayClientForBuild) Warn(ctx context.Context, in *gatewayapi.WarnRequest, opts ...grpc.CallOption) (*gatewayapi.WarnResponse, error) {
+	if err := g.caps.Supports(gatewayapi.CapGatewayWarnings); err != nil {
+		return nil, err
+	}
+	ctx = buildid.AppendToOutgoingContext(ctx, g.buildID)
+	return g.gateway.Warn(ctx, in, opts...)
+}
+
+func (g *gatewayClientForBuild) ResolveSourceMetadata(ctx context.Context, in *gatewayapi.ResolveSourceMetadataRequest, opts ...grpc.CallOption) (*gatewayapi.ResolveSourceMetadataResponse, error) {
+	if err := g.caps.Supports(gatewayapi.CapGatewayResolveSourceMetadata); err != nil {
+		return nil, err
+	}
+	ctx = buildid.AppendToOutgoingContext(ctx, g.buildID)
+	return g.gateway.ResolveSourceMetadata(ctx, in, opts...)
+}
+
+func (g *gatewayClientForBuild) Progress(ctx context.Context, in *gatewayapi.ProgressRequest, opts ...grpc.CallOption) (*gatewayapi.ProgressResponse, error) {
+	if err := g.caps.Supports(gatewayapi.CapGatewayProgress); err != nil {
+		return nil, err
+	}
+	ctx = buildid.AppendToOutgoingContext(ctx, g.buildID)
+	return g.gateway.Progress(ctx, in, opts...)
+}
@@ -1,14 +1,19 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -22,7 +27,9 @@ import (
 	"github.com/moby/buildkit/solver/errdefs"
 	"github.com/moby/buildkit/solver/pb"
 	utilsystem "github.com/moby/buildkit/util/system"
+	"github.com/moby/buildkit/util/testutil/httpserver"
 	"github.com/moby/buildkit/util/testutil/integration"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ssh/agent"
@@ -33,18 +40,26 @@ func TestClientGatewayIntegration(t *testing.T) {
 		testClientGatewaySolve,
 		testClientGatewayFailedSolve,
 		testClientGatewayEmptySolve,
+		testClientGatewayWarnAndProgress,
+		testClientGatewayLocalPaths,
+		testClientGatewayGitPaths,
+		testClientGatewayResolveSourceMetadata,
 		testNoBuildID,
 		testUnknownBuildID,
 		testClientGatewayContainerExecPipe,
 		testClientGatewayContainerCancelOnRelease,
 		testClientGatewayContainerPID1Fail,
 		testClientGatewayContainerPID1Exit,
+		testClientGatewayContainerSignal,
+		testClientGatewayContainerSnapshotMount,
+		testClientGatewayContainerSnapshotMountTwice,
 		testClientGatewayContainerMounts,
 		testClientGatewayContainerPID1Tty,
 		testClientGatewayContainerExecTty,
 		testClientSlowCacheRootfsRef,
 		testClientGatewayContainerPlatformPATH,
 		testClientGatewayExecError,
+		testClientGatewayFrontendExecError,
 		testClientGatewaySlowCacheExecError,
 		testClientGatewayExecFileActionError,
 	}, integration.WithMirroredImages(integration.OfficialImages("busybox:latest")))
@@ -170,6 +185,249 @@ func testClientGatewayEmptySolve(t *testing.T, sb integration.Sandbox) {
 	require.NoError(t, err)
 }
 
+func testClientGatewayLocalPaths(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+
+	ctx := context.TODO()
+
+	c, err := New(ctx, sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	tmpdir, err := ioutil.TempDir("", "buildkit-localpaths")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	err = ioutil.WriteFile(filepath.Join(tmpdir, "package.json"), []byte(`{"name":"app"}`), 0600)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(tmpdir, "main.js"), []byte("console.log(1)"), 0600)
+	require.NoError(t, err)
+
+	b := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		ref, err := client.LocalPaths(ctx, c, "mylocal", []string{"package.json"})
+		if err != nil {
+			return nil, err
+		}
+		dt, err := ref.ReadFile(ctx, client.ReadRequest{Filename: "package.json"})
+		require.NoError(t, err)
+		require.Equal(t, `{"name":"app"}`, string(dt))
+
+		_, err = ref.StatFile(ctx, client.StatRequest{Path: "main.js"})
+		require.Error(t, err)
+
+		return &client.Result{}, nil
+	}
+
+	_, err = c.Build(ctx, SolveOpt{
+		LocalDirs: map[string]string{
+			"mylocal": tmpdir,
+		},
+	}, "buildkit_test", b, nil)
+	require.NoError(t, err)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
+func testClientGatewayGitPaths(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+
+	ctx := context.TODO()
+
+	c, err := New(ctx, sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	gitDir, err := ioutil.TempDir("", "buildkit-gitpaths")
+	require.NoError(t, err)
+	defer os.RemoveAll(gitDir)
+
+	err = ioutil.WriteFile(filepath.Join(gitDir, "package.json"), []byte(`{"name":"app"}`), 0600)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(gitDir, "main.js"), []byte("console.log(1)"), 0600)
+	require.NoError(t, err)
+
+	for _, args := range []string{
+		"git init",
+		"git config --local user.email test",
+		"git config --local user.name test",
+		"git add package.json main.js",
+		"git commit -m initial",
+		"git update-server-info",
+	} {
+		cmd := exec.Command("sh", "-c", args)
+		cmd.Dir = gitDir
+		require.NoError(t, cmd.Run(), args)
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir(gitDir)))
+	defer server.Close()
+
+	b := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		ref, err := client.GitPaths(ctx, c, server.URL+"/.git", "", []string{"package.json", "missing"})
+		if err != nil {
+			return nil, err
+		}
+		dt, err := ref.ReadFile(ctx, client.ReadRequest{Filename: "package.json"})
+		require.NoError(t, err)
+		require.Equal(t, `{"name":"app"}`, string(dt))
+
+		_, err = ref.StatFile(ctx, client.StatRequest{Path: "main.js"})
+		require.Error(t, err)
+
+		return &client.Result{}, nil
+	}
+
+	_, err = c.Build(ctx, SolveOpt{}, "buildkit_test", b, nil)
+	require.NoError(t, err)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
+func testClientGatewayResolveSourceMetadata(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+
+	ctx := context.TODO()
+
+	c, err := New(ctx, sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	etag := identity.NewID()
+	server := httpserver.NewTestServer(map[string]httpserver.Response{
+		"/foo": {
+			Etag:    etag,
+			Content: []byte("content1"),
+		},
+	})
+	defer server.Close()
+
+	b := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		md, err := c.ResolveSourceMetadata(ctx, &pb.SourceOp{
+			Identifier: "docker-image://docker.io/library/busybox:latest",
+		}, llb.ResolveSourceMetadataOpt{})
+		if err != nil {
+			return nil, err
+		}
+		require.NotNil(t, md.Image)
+		require.NoError(t, md.Image.Digest.Validate())
+		require.NotEmpty(t, md.Image.Config)
+		require.Nil(t, md.Git)
+		require.Nil(t, md.HTTP)
+
+		md, err = c.ResolveSourceMetadata(ctx, &pb.SourceOp{
+			Identifier: server.URL + "/foo",
+		}, llb.ResolveSourceMetadataOpt{})
+		if err != nil {
+			return nil, err
+		}
+		require.NotNil(t, md.HTTP)
+		require.Equal(t, digest.FromBytes([]byte("content1")), md.HTTP.Checksum)
+		require.Equal(t, etag, md.HTTP.ETag)
+		require.Nil(t, md.Image)
+
+		_, err = c.ResolveSourceMetadata(ctx, &pb.SourceOp{
+			Identifier: "local://mylocal",
+		}, llb.ResolveSourceMetadataOpt{})
+		require.Error(t, err)
+
+		return &client.Result{}, nil
+	}
+
+	_, err = c.Build(ctx, SolveOpt{}, "buildkit_test", b, nil)
+	require.NoError(t, err)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
+// testClientGatewayWarnAndProgress is testing that warnings and custom
+// progress steps sent by a frontend show up in the status stream.
+func testClientGatewayWarnAndProgress(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+
+	ctx := context.TODO()
+
+	c, err := New(ctx, sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	vtx := digest.FromBytes([]byte("frontend-vertex"))
+
+	b := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		err := c.Progress(ctx, client.ProgressRequest{
+			ID:   "lockfile",
+			Name: "resolving lockfile",
+		})
+		require.NoError(t, err)
+		err = c.Progress(ctx, client.ProgressRequest{
+			ID:     "lockfile",
+			Stream: 1,
+			Data:   []byte("resolved 3 packages\n"),
+		})
+		require.NoError(t, err)
+		err = c.Progress(ctx, client.ProgressRequest{
+			ID:        "lockfile",
+			Completed: true,
+		})
+		require.NoError(t, err)
+
+		// the first request for a step needs a name
+		err = c.Progress(ctx, client.ProgressRequest{
+			ID: "unnamed",
+		})
+		require.Error(t, err)
+
+		err = c.Warn(ctx, vtx, "base image is deprecated", client.WarnOpts{
+			Level:  1,
+			URL:    "https://example.com/deprecated",
+			Detail: [][]byte{[]byte("use a newer image")},
+		})
+		require.NoError(t, err)
+
+		return &client.Result{}, nil
+	}
+
+	statusCh := make(chan *SolveStatus)
+	var statuses []*SolveStatus
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for s := range statusCh {
+			statuses = append(statuses, s)
+		}
+	}()
+
+	_, err = c.Build(ctx, SolveOpt{}, "buildkit_test", b, statusCh)
+	require.NoError(t, err)
+	<-done
+
+	var (
+		completed bool
+		logs      []byte
+		warnings  []*VertexWarning
+	)
+	for _, s := range statuses {
+		for _, v := range s.Vertexes {
+			if v.Name == "resolving lockfile" && v.Completed != nil {
+				require.Empty(t, v.Error)
+				completed = true
+			}
+		}
+		for _, l := range s.Logs {
+			logs = append(logs, l.Data...)
+		}
+		warnings = append(warnings, s.Warnings...)
+	}
+	require.True(t, completed)
+	require.Contains(t, string(logs), "resolved 3 packages")
+	require.Equal(t, 1, len(warnings))
+	require.Equal(t, vtx, warnings[0].Vertex)
+	require.Equal(t, "base image is deprecated", string(warnings[0].Short))
+	require.Equal(t, "https://example.com/deprecated", warnings[0].URL)
+	require.Equal(t, [][]byte{[]byte("use a newer image")}, warnings[0].Detail)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
 func testNoBuildID(t *testing.T, sb integration.Sandbox) {
 	requiresLinux(t)
 
@@ -536,6 +794,244 @@ func testClientGatewayContainerPID1Exit(t *testing.T, sb integration.Sandbox) {
 	checkAllReleasable(t, c, sb, true)
 }
 
+// testClientGatewayContainerSignal is testing that signals sent through the
+// gateway are delivered to pid1 and to processes started via `Exec`
+func testClientGatewayContainerSignal(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+
+	ctx := context.TODO()
+
+	c, err := New(ctx, sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	product := "buildkit_test"
+
+	b := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		st := llb.Image("busybox:latest")
+
+		def, err := st.Marshal(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal state")
+		}
+
+		r, err := c.Solve(ctx, client.SolveRequest{
+			Definition: def.ToPB(),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to solve")
+		}
+
+		ctr, err := c.NewContainer(ctx, client.NewContainerRequest{
+			Mounts: []client.Mount{{
+				Dest:      "/",
+				MountType: pb.MountType_BIND,
+				Ref:       r.Ref,
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer ctr.Release(ctx)
+
+		start := func(code int) client.ContainerProcess {
+			outputR, outputW := io.Pipe()
+			proc, err := ctr.Start(ctx, client.StartRequest{
+				Args:   []string{"sh", "-c", fmt.Sprintf(`trap "exit %d" TERM; echo ready; while true; do sleep 0.1; done`, code)},
+				Stdout: outputW,
+			})
+			require.NoError(t, err)
+			// wait for the trap to be set up before sending the signal
+			line, err := bufio.NewReader(outputR).ReadString('\n')
+			require.NoError(t, err)
+			require.Equal(t, "ready\n", line)
+			go io.Copy(ioutil.Discard, outputR)
+			return proc
+		}
+
+		pid1 := start(42)
+		pid2 := start(43)
+
+		err = pid2.Signal(ctx, syscall.SIGTERM)
+		require.NoError(t, err)
+		err = pid2.Wait()
+		var exitErr *errdefs.ExitError
+		require.True(t, errors.As(err, &exitErr), "%+v", err)
+		require.Equal(t, uint32(43), exitErr.ExitCode)
+
+		err = pid1.Signal(ctx, syscall.SIGTERM)
+		require.NoError(t, err)
+		err = pid1.Wait()
+		require.True(t, errors.As(err, &exitErr), "%+v", err)
+		require.Equal(t, uint32(42), exitErr.ExitCode)
+
+		return &client.Result{}, nil
+	}
+
+	_, err = c.Build(ctx, SolveOpt{}, product, b, nil)
+	require.NoError(t, err)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
+// testClientGatewayContainerSnapshotMount is testing that the changes made
+// by a container process to a writable mount can be returned as a reference.
+func testClientGatewayContainerSnapshotMount(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+
+	ctx := context.TODO()
+
+	c, err := New(ctx, sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	product := "buildkit_test"
+	otherBuild := c
+
+	b := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		st := llb.Image("busybox:latest")
+
+		def, err := st.Marshal(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal state")
+		}
+
+		r, err := c.Solve(ctx, client.SolveRequest{
+			Definition: def.ToPB(),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to solve")
+		}
+
+		ctr, err := c.NewContainer(ctx, client.NewContainerRequest{
+			Mounts: []client.Mount{{
+				Dest:      "/",
+				MountType: pb.MountType_BIND,
+				Ref:       r.Ref,
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer ctr.Release(ctx)
+
+		pid1, err := ctr.Start(ctx, client.StartRequest{
+			Args: []string{"sh", "-c", "echo -n snapshot > /foo"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, pid1.Wait())
+
+		ref, err := ctr.SnapshotMount(ctx, "/")
+		require.NoError(t, err)
+
+		dt, err := ref.ReadFile(ctx, client.ReadRequest{
+			Filename: "/foo",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "snapshot", string(dt))
+
+		_, err = ctr.Start(ctx, client.StartRequest{
+			Args: []string{"true"},
+		})
+		require.Error(t, err)
+
+		// the snapshot can be used as the input of further solves
+		snapshotSt, err := ref.ToState()
+		require.NoError(t, err)
+
+		// but not by other builds
+		snapshotDef, err := snapshotSt.Marshal(ctx)
+		require.NoError(t, err)
+		_, err = otherBuild.Solve(ctx, snapshotDef, SolveOpt{}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not a mount snapshot of this build")
+
+		def, err = snapshotSt.Run(llb.Shlex(`sh -c "cp /foo /bar"`)).Marshal(ctx)
+		require.NoError(t, err)
+		res, err := c.Solve(ctx, client.SolveRequest{
+			Definition: def.ToPB(),
+		})
+		require.NoError(t, err)
+
+		dt, err = res.Ref.ReadFile(ctx, client.ReadRequest{
+			Filename: "/bar",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "snapshot", string(dt))
+
+		return res, nil
+	}
+
+	_, err = c.Build(ctx, SolveOpt{}, product, b, nil)
+	require.NoError(t, err)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
+// testClientGatewayContainerSnapshotMountTwice is testing that snapshotting
+// the same mount twice doesn't leak the snapshot.
+func testClientGatewayContainerSnapshotMountTwice(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+
+	ctx := context.TODO()
+
+	c, err := New(ctx, sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	product := "buildkit_test"
+
+	b := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		def, err := llb.Image("busybox:latest").Marshal(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal state")
+		}
+
+		r, err := c.Solve(ctx, client.SolveRequest{
+			Definition: def.ToPB(),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to solve")
+		}
+
+		ctr, err := c.NewContainer(ctx, client.NewContainerRequest{
+			Mounts: []client.Mount{{
+				Dest:      "/",
+				MountType: pb.MountType_BIND,
+				Ref:       r.Ref,
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer ctr.Release(ctx)
+
+		pid1, err := ctr.Start(ctx, client.StartRequest{
+			Args: []string{"sh", "-c", "echo -n snapshot > /foo"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, pid1.Wait())
+
+		for i := 0; i < 2; i++ {
+			ref, err := ctr.SnapshotMount(ctx, "/")
+			require.NoError(t, err)
+
+			dt, err := ref.ReadFile(ctx, client.ReadRequest{
+				Filename: "/foo",
+			})
+			require.NoError(t, err)
+			require.Equal(t, "snapshot", string(dt))
+		}
+
+		return client.NewResult(), nil
+	}
+
+	_, err = c.Build(ctx, SolveOpt{}, product, b, nil)
+	require.NoError(t, err)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
 // testClientGatewayContainerMounts is testing mounts derived from various
 // llb.States
 func testClientGatewayContainerMounts(t *testing.T, sb integration.Sandbox) {
@@ -1230,6 +1726,78 @@ func testClientGatewayExecError(t *testing.T, sb integration.Sandbox) {
 	checkAllReleasable(t, c, sb, true)
 }
 
+// testClientGatewayFrontendExecError is testing that an evaluated frontend
+// solve keeps the mounts of the failed exec.
+func testClientGatewayFrontendExecError(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+
+	ctx := context.TODO()
+
+	c, err := New(ctx, sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	id := identity.NewID()
+	dockerfile := []byte(fmt.Sprintf(`FROM busybox:latest
+RUN echo %s > /data && fail
+`, id))
+
+	b := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		st := llb.Scratch().File(llb.Mkfile("Dockerfile", 0600, dockerfile))
+		def, err := st.Marshal(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		_, solveErr := c.Solve(ctx, client.SolveRequest{
+			Frontend: "dockerfile.v0",
+			FrontendInputs: map[string]*pb.Definition{
+				"context":    def.ToPB(),
+				"dockerfile": def.ToPB(),
+			},
+			Evaluate: true,
+		})
+		require.Error(t, solveErr)
+
+		var se *errdefs.SolveError
+		require.True(t, errors.As(solveErr, &se))
+		require.Len(t, se.Solve.MountIDs, 1)
+
+		exec := se.Solve.Op.GetExec()
+		require.NotNil(t, exec)
+
+		ctr, err := c.NewContainer(ctx, client.NewContainerRequest{
+			Mounts: []client.Mount{{
+				Dest:      exec.Mounts[0].Dest,
+				ResultID:  se.Solve.MountIDs[0],
+				MountType: exec.Mounts[0].MountType,
+			}},
+			NetMode:  exec.Network,
+			Platform: se.Solve.Op.Platform,
+		})
+		require.NoError(t, err)
+		defer ctr.Release(ctx)
+
+		output := bytes.NewBuffer(nil)
+		proc, err := ctr.Start(ctx, client.StartRequest{
+			Args:   []string{"cat", "/data"},
+			Env:    exec.Meta.Env,
+			Cwd:    exec.Meta.Cwd,
+			Stdout: &nopCloser{output},
+		})
+		require.NoError(t, err)
+		require.NoError(t, proc.Wait())
+		require.Equal(t, id, strings.TrimSpace(output.String()))
+
+		return client.NewResult(), nil
+	}
+
+	_, err = c.Build(ctx, SolveOpt{}, "buildkit_test", b, nil)
+	require.NoError(t, err)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
 // testClientGatewaySlowCacheExecError is testing gateway exec into the ref
 // that failed to mount during an execop.
 func testClientGatewaySlowCacheExecError(t *testing.T, sb integration.Sandbox) {
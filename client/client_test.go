@@ -24,12 +24,15 @@ import (
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/content"
+	contentlocal "github.com/containerd/containerd/content/local"
 	ctderrdefs "github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/snapshots"
 	"github.com/containerd/continuity/fs/fstest"
 	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	gateway "github.com/moby/buildkit/frontend/gateway/client"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
@@ -104,6 +107,8 @@ func TestIntegration(t *testing.T) {
 		testNetworkMode,
 		testFrontendMetadataReturn,
 		testFrontendUseSolveResults,
+		testExportResultAttachments,
+		testOCILayoutSource,
 		testSSHMount,
 		testStdinClosed,
 		testHostnameLookup,
@@ -1514,6 +1519,71 @@ func testOCIExporter(t *testing.T, sb integration.Sandbox) {
 	checkAllReleasable(t, c, sb, true)
 }
 
+func testOCILayoutSource(t *testing.T, sb integration.Sandbox) {
+	skipDockerd(t, sb)
+	requiresLinux(t)
+	c, err := New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	busybox := llb.Image("busybox:latest")
+	st := llb.Scratch()
+	st = busybox.Run(llb.Shlex(`sh -c "echo -n layout > foo"`), llb.Dir("/wd")).AddMount("/wd", st)
+
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	layoutDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(layoutDir)
+
+	_, err = c.Solve(context.TODO(), def, SolveOpt{
+		Exports: []ExportEntry{
+			{
+				Type:      ExporterOCI,
+				OutputDir: layoutDir,
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := ioutil.ReadFile(filepath.Join(layoutDir, "index.json"))
+	require.NoError(t, err)
+	var index ocispec.Index
+	err = json.Unmarshal(dt, &index)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(index.Manifests))
+
+	store, err := contentlocal.NewStore(layoutDir)
+	require.NoError(t, err)
+
+	def, err = llb.OCILayout("teststore/image@" + index.Manifests[0].Digest.String()).Marshal(context.TODO())
+	require.NoError(t, err)
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = c.Solve(context.TODO(), def, SolveOpt{
+		OCIStores: map[string]content.Store{
+			"teststore": store,
+		},
+		Exports: []ExportEntry{
+			{
+				Type:      ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err = ioutil.ReadFile(filepath.Join(destDir, "foo"))
+	require.NoError(t, err)
+	require.Equal(t, "layout", string(dt))
+
+	checkAllReleasable(t, c, sb, true)
+}
+
 func testFrontendMetadataReturn(t *testing.T, sb integration.Sandbox) {
 	skipDockerd(t, sb)
 	requiresLinux(t)
@@ -1612,6 +1682,112 @@ func testFrontendUseSolveResults(t *testing.T, sb integration.Sandbox) {
 	require.Equal(t, dt, []byte("data"))
 }
 
+func testExportResultAttachments(t *testing.T, sb integration.Sandbox) {
+	skipDockerd(t, sb)
+	requiresLinux(t)
+	c, err := New(context.TODO(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	platformsToTest := []string{"linux/amd64", "linux/arm64"}
+
+	frontend := func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res := gateway.NewResult()
+		expPlatforms := &exptypes.Platforms{}
+		for _, p := range platformsToTest {
+			st := llb.Scratch().File(
+				llb.Mkfile("platform", 0600, []byte(p)),
+			)
+			def, err := st.Marshal(ctx)
+			if err != nil {
+				return nil, err
+			}
+			r, err := c.Solve(ctx, gateway.SolveRequest{
+				Definition: def.ToPB(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			ref, err := r.SingleRef()
+			if err != nil {
+				return nil, err
+			}
+
+			pl := platforms.MustParse(p)
+			img := ocispec.Image{
+				Architecture: pl.Architecture,
+				OS:           pl.OS,
+				Config: ocispec.ImageConfig{
+					Env: []string{"PLATFORM=" + p},
+				},
+			}
+			config, err := json.Marshal(img)
+			if err != nil {
+				return nil, err
+			}
+			res.AddRef(p, ref)
+			res.AddAttachment(p, exptypes.Attachment{Type: exptypes.AttachmentImageConfig, Data: config})
+			res.AddAttachment(p, exptypes.Attachment{Type: exptypes.AttachmentAnnotation, Name: "com.example.platform", Data: []byte(p)})
+			expPlatforms.Platforms = append(expPlatforms.Platforms, exptypes.Platform{ID: p, Platform: pl})
+		}
+		dt, err := json.Marshal(expPlatforms)
+		if err != nil {
+			return nil, err
+		}
+		res.AddMeta(exptypes.ExporterPlatformsKey, dt)
+		return res, nil
+	}
+
+	destDir, err := ioutil.TempDir("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	out := filepath.Join(destDir, "out.tar")
+	outW, err := os.Create(out)
+	require.NoError(t, err)
+
+	_, err = c.Build(context.TODO(), SolveOpt{
+		Exports: []ExportEntry{
+			{
+				Type:   ExporterOCI,
+				Output: fixedWriteCloser(outW),
+			},
+		},
+	}, "", frontend, nil)
+	require.NoError(t, err)
+
+	dt, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+	m, err := testutil.ReadTarToMap(dt, false)
+	require.NoError(t, err)
+
+	var index ocispec.Index
+	err = json.Unmarshal(m["index.json"].Data, &index)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(index.Manifests))
+
+	var mlist ocispec.Index
+	err = json.Unmarshal(m["blobs/sha256/"+index.Manifests[0].Digest.Hex()].Data, &mlist)
+	require.NoError(t, err)
+	require.Equal(t, len(platformsToTest), len(mlist.Manifests))
+
+	for _, desc := range mlist.Manifests {
+		p := platforms.Format(*desc.Platform)
+
+		var mfst ocispec.Manifest
+		err = json.Unmarshal(m["blobs/sha256/"+desc.Digest.Hex()].Data, &mfst)
+		require.NoError(t, err)
+		require.Equal(t, p, mfst.Annotations["com.example.platform"])
+
+		var img ocispec.Image
+		err = json.Unmarshal(m["blobs/sha256/"+mfst.Config.Digest.Hex()].Data, &img)
+		require.NoError(t, err)
+		require.Equal(t, []string{"PLATFORM=" + p}, img.Config.Env)
+	}
+
+	checkAllReleasable(t, c, sb, true)
+}
+
 func skipDockerd(t *testing.T, sb integration.Sandbox) {
 	// TODO: remove me once dockerd supports the image and exporter.
 	t.Helper()
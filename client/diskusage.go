@@ -75,10 +75,11 @@ type DiskUsageInfo struct {
 type UsageRecordType string
 
 const (
-	UsageRecordTypeInternal    UsageRecordType = "internal"
-	UsageRecordTypeFrontend    UsageRecordType = "frontend"
-	UsageRecordTypeLocalSource UsageRecordType = "source.local"
-	UsageRecordTypeGitCheckout UsageRecordType = "source.git.checkout"
-	UsageRecordTypeCacheMount  UsageRecordType = "exec.cachemount"
-	UsageRecordTypeRegular     UsageRecordType = "regular"
+	UsageRecordTypeInternal      UsageRecordType = "internal"
+	UsageRecordTypeFrontend      UsageRecordType = "frontend"
+	UsageRecordTypeLocalSource   UsageRecordType = "source.local"
+	UsageRecordTypeGitCheckout   UsageRecordType = "source.git.checkout"
+	UsageRecordTypeCacheMount    UsageRecordType = "exec.cachemount"
+	UsageRecordTypeMountSnapshot UsageRecordType = "gateway.mountsnapshot"
+	UsageRecordTypeRegular       UsageRecordType = "regular"
 )
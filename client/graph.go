@@ -3,6 +3,7 @@ package client
 import (
 	"time"
 
+	"github.com/moby/buildkit/solver/pb"
 	digest "github.com/opencontainers/go-digest"
 )
 
@@ -34,13 +35,32 @@ type VertexLog struct {
 	Timestamp time.Time
 }
 
+type VertexWarning struct {
+	Vertex     digest.Digest
+	Level      int
+	Short      []byte
+	Detail     [][]byte
+	URL        string
+	SourceInfo *pb.SourceInfo
+	Range      []*pb.Range
+}
+
 type SolveStatus struct {
 	Vertexes []*Vertex
 	Statuses []*VertexStatus
 	Logs     []*VertexLog
+	Warnings []*VertexWarning
 }
 
 type SolveResponse struct {
 	// ExporterResponse is also used for CacheExporter
 	ExporterResponse map[string]string
+	// ExporterResponses contains the response of each exporter in the order
+	// of SolveOpt.Exports
+	ExporterResponses []ExporterResponse
+}
+
+type ExporterResponse struct {
+	Type string
+	Data map[string]string
 }
@@ -57,6 +57,7 @@ type ExecOp struct {
 	isValidated bool
 	secrets     []SecretInfo
 	ssh         []SSHInfo
+	devices     []DeviceInfo
 }
 
 func (e *ExecOp) AddMount(target string, source Output, opt ...MountOption) Output {
@@ -253,14 +254,22 @@ func (e *ExecOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 		}
 	}
 
-	if len(e.secrets) > 0 {
-		addCap(&e.constraints, pb.CapExecMountSecret)
+	for _, s := range e.secrets {
+		if s.IsEnv {
+			addCap(&e.constraints, pb.CapExecSecretEnv)
+		} else {
+			addCap(&e.constraints, pb.CapExecMountSecret)
+		}
 	}
 
 	if len(e.ssh) > 0 {
 		addCap(&e.constraints, pb.CapExecMountSSH)
 	}
 
+	if len(e.devices) > 0 {
+		addCap(&e.constraints, pb.CapExecDevices)
+	}
+
 	if e.constraints.Platform == nil {
 		p, err := getPlatform(e.base)(ctx)
 		if err != nil {
@@ -337,6 +346,14 @@ func (e *ExecOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 	}
 
 	for _, s := range e.secrets {
+		if s.IsEnv {
+			peo.Secretenv = append(peo.Secretenv, &pb.SecretEnv{
+				ID:       s.ID,
+				Name:     s.Target,
+				Optional: s.Optional,
+			})
+			continue
+		}
 		pm := &pb.Mount{
 			Dest:      s.Target,
 			MountType: pb.MountType_SECRET,
@@ -351,6 +368,14 @@ func (e *ExecOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 		peo.Mounts = append(peo.Mounts, pm)
 	}
 
+	for _, d := range e.devices {
+		peo.Devices = append(peo.Devices, &pb.Device{
+			Source:      d.Source,
+			Dest:        d.Target,
+			Permissions: d.Permissions,
+		})
+	}
+
 	for _, s := range e.ssh {
 		pm := &pb.Mount{
 			Dest:      s.Target,
@@ -509,6 +534,49 @@ func AddMount(dest string, mountState State, opts ...MountOption) RunOption {
 	})
 }
 
+// AddDevice exposes the device node at source on the host to the process.
+// Running a process with devices requires the device entitlement.
+func AddDevice(source string, opts ...DeviceOption) RunOption {
+	return runOptionFunc(func(ei *ExecInfo) {
+		d := &DeviceInfo{Source: source}
+		for _, opt := range opts {
+			opt.SetDeviceOption(d)
+		}
+		ei.Devices = append(ei.Devices, *d)
+	})
+}
+
+type DeviceOption interface {
+	SetDeviceOption(*DeviceInfo)
+}
+
+type deviceOptionFunc func(*DeviceInfo)
+
+func (fn deviceOptionFunc) SetDeviceOption(di *DeviceInfo) {
+	fn(di)
+}
+
+// DeviceTarget sets the path of the device in the container
+func DeviceTarget(target string) DeviceOption {
+	return deviceOptionFunc(func(di *DeviceInfo) {
+		di.Target = target
+	})
+}
+
+// DevicePermissions sets the cgroup permissions of the device, any
+// combination of r, w and m
+func DevicePermissions(permissions string) DeviceOption {
+	return deviceOptionFunc(func(di *DeviceInfo) {
+		di.Permissions = permissions
+	})
+}
+
+type DeviceInfo struct {
+	Source      string
+	Target      string
+	Permissions string
+}
+
 func AddSSHSocket(opts ...SSHOption) RunOption {
 	return runOptionFunc(func(ei *ExecInfo) {
 		s := &SSHInfo{
@@ -592,6 +660,9 @@ type SecretInfo struct {
 	UID      int
 	GID      int
 	Optional bool
+	// IsEnv exposes the secret as the environment variable named by Target
+	// instead of mounting it as a file
+	IsEnv bool
 }
 
 var SecretOptional = secretOptionFunc(func(si *SecretInfo) {
@@ -604,6 +675,15 @@ func SecretID(id string) SecretOption {
 	})
 }
 
+// SecretAsEnv exposes the secret to the process as an environment variable
+// named by the destination passed to AddSecret. The value is read when the
+// process starts and is not part of the cache key.
+func SecretAsEnv(v bool) SecretOption {
+	return secretOptionFunc(func(si *SecretInfo) {
+		si.IsEnv = v
+	})
+}
+
 func SecretFileOpt(uid, gid, mode int) SecretOption {
 	return secretOptionFunc(func(si *SecretInfo) {
 		si.UID = uid
@@ -632,6 +712,7 @@ type ExecInfo struct {
 	ProxyEnv       *ProxyEnv
 	Secrets        []SecretInfo
 	SSH            []SSHInfo
+	Devices        []DeviceInfo
 }
 
 type MountInfo struct {
@@ -50,3 +50,60 @@ func TestValidGetMountIndex(t *testing.T) {
 	require.NoError(t, err, "failed to getIndex")
 	require.Equal(t, pb.OutputIndex(1), mountIndex, "unexpected mount index")
 }
+
+func TestExecSecretEnv(t *testing.T) {
+	t.Parallel()
+
+	st := Image("foo").Run(
+		Shlex("args"),
+		AddSecret("TOKEN", SecretID("token"), SecretAsEnv(true), SecretOptional),
+		AddSecret("/run/secrets/other", SecretID("other")),
+	).Root()
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	dgst, _ := last(t, arr)
+	exec := m[dgst].Op.(*pb.Op_Exec).Exec
+
+	require.Equal(t, 1, len(exec.Secretenv))
+	require.Equal(t, "token", exec.Secretenv[0].ID)
+	require.Equal(t, "TOKEN", exec.Secretenv[0].Name)
+	require.True(t, exec.Secretenv[0].Optional)
+
+	var secretMounts []*pb.Mount
+	for _, mnt := range exec.Mounts {
+		if mnt.MountType == pb.MountType_SECRET {
+			secretMounts = append(secretMounts, mnt)
+		}
+	}
+	require.Equal(t, 1, len(secretMounts))
+	require.Equal(t, "/run/secrets/other", secretMounts[0].Dest)
+
+	_, ok := def.Metadata[dgst].Caps[pb.CapExecSecretEnv]
+	require.True(t, ok)
+}
+
+func TestExecDevices(t *testing.T) {
+	t.Parallel()
+
+	st := Image("foo").Run(
+		Shlex("args"),
+		AddDevice("/dev/fuse"),
+		AddDevice("/dev/kvm", DeviceTarget("/dev/vm"), DevicePermissions("rw")),
+	).Root()
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	dgst, _ := last(t, arr)
+	exec := m[dgst].Op.(*pb.Op_Exec).Exec
+
+	require.Equal(t, []*pb.Device{
+		{Source: "/dev/fuse"},
+		{Source: "/dev/kvm", Dest: "/dev/vm", Permissions: "rw"},
+	}, exec.Devices)
+
+	_, ok := def.Metadata[dgst].Caps[pb.CapExecDevices]
+	require.True(t, ok)
+}
@@ -433,6 +433,8 @@ type CopyInfo struct {
 	AllowEmptyWildcard  bool
 	ChownOpt            *ChownOpt
 	CreatedTime         *time.Time
+	IncludePatterns     []string
+	ExcludePatterns     []string
 }
 
 func (mi *CopyInfo) SetCopyOption(mi2 *CopyInfo) {
@@ -465,6 +467,8 @@ func (a *fileActionCopy) toProtoAction(ctx context.Context, parent string, base
 		AttemptUnpackDockerCompatibility: a.info.AttemptUnpack,
 		CreateDestPath:                   a.info.CreateDestPath,
 		Timestamp:                        marshalTime(a.info.CreatedTime),
+		IncludePatterns:                  a.info.IncludePatterns,
+		ExcludePatterns:                  a.info.ExcludePatterns,
 	}
 	if a.info.Mode != nil {
 		c.Mode = int32(*a.info.Mode)
@@ -661,16 +665,21 @@ func (f *FileOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 
 	pfo := &pb.FileOp{}
 
-	pop, md := MarshalConstraints(c, &f.constraints)
-	pop.Op = &pb.Op_File{
-		File: pfo,
-	}
-
 	state := newMarshalState(ctx)
 	_, err := state.add(f.action, c)
 	if err != nil {
 		return "", nil, nil, nil, err
 	}
+	for _, st := range state.actions {
+		if a, ok := st.action.(*fileActionCopy); ok && (len(a.info.IncludePatterns) > 0 || len(a.info.ExcludePatterns) > 0) {
+			addCap(&f.constraints, pb.CapFileCopyIncludeExcludePatterns)
+		}
+	}
+
+	pop, md := MarshalConstraints(c, &f.constraints)
+	pop.Op = &pb.Op_File{
+		File: pfo,
+	}
 	pop.Inputs = state.inputs
 
 	for i, st := range state.actions {
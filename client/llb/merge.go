@@ -0,0 +1,100 @@
+package llb
+
+import (
+	"context"
+
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+type MergeOp struct {
+	MarshalCache
+	inputs      []Output
+	output      Output
+	constraints Constraints
+}
+
+func NewMerge(inputs []State, c Constraints) *MergeOp {
+	op := &MergeOp{constraints: c}
+	for _, st := range inputs {
+		if out := st.Output(); out != nil {
+			op.inputs = append(op.inputs, out)
+		}
+	}
+	op.output = &output{vertex: op, platform: c.Platform}
+	return op
+}
+
+func (m *MergeOp) Validate(ctx context.Context) error {
+	if len(m.inputs) < 2 {
+		return errors.Errorf("merge must have at least 2 inputs")
+	}
+	return nil
+}
+
+func (m *MergeOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []byte, *pb.OpMetadata, []*SourceLocation, error) {
+	if m.Cached(c) {
+		return m.Load()
+	}
+	if err := m.Validate(ctx); err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	addCap(&m.constraints, pb.CapMergeOp)
+
+	pop, md := MarshalConstraints(c, &m.constraints)
+	pop.Platform = nil // merge op is not platform specific
+
+	op := &pb.MergeOp{}
+	for i, out := range m.inputs {
+		inp, err := out.ToInput(ctx, c)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		pop.Inputs = append(pop.Inputs, inp)
+		op.Inputs = append(op.Inputs, &pb.MergeInput{Input: pb.InputIndex(i)})
+	}
+	pop.Op = &pb.Op_Merge{Merge: op}
+
+	dt, err := pop.Marshal()
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	m.Store(dt, md, m.constraints.SourceLocations, c)
+	return m.Load()
+}
+
+func (m *MergeOp) Output() Output {
+	return m.output
+}
+
+func (m *MergeOp) Inputs() []Output {
+	return m.inputs
+}
+
+// Merge stacks the filesystems of the states on top of each other, in order.
+// The layers of every state are reused as they are, so a state that was built
+// on top of Scratch() keeps the same layers no matter what it is merged on.
+// Scratch states are ignored.
+func Merge(inputs []State, opts ...ConstraintsOpt) State {
+	var nonScratch []State
+	for _, st := range inputs {
+		if st.Output() != nil {
+			nonScratch = append(nonScratch, st)
+		}
+	}
+	switch len(nonScratch) {
+	case 0:
+		return Scratch()
+	case 1:
+		return nonScratch[0]
+	}
+
+	var c Constraints
+	for _, o := range opts {
+		o.SetConstraintsOption(&c)
+	}
+	return nonScratch[0].WithOutput(NewMerge(nonScratch, c).Output())
+}
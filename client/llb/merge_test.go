@@ -0,0 +1,53 @@
+package llb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	base := Image("foo").AddEnv("FOO", "bar")
+	upper := Scratch().File(Mkfile("/foo", 0644, []byte("data")))
+	st := Merge([]State{base, Scratch(), upper})
+
+	env, err := st.Env(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, []string{"FOO=bar"}, env)
+
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	require.Equal(t, 4, len(arr))
+
+	dgst, idx := last(t, arr)
+	require.Equal(t, 0, idx)
+
+	op := m[dgst]
+	merge := op.Op.(*pb.Op_Merge).Merge
+	require.Equal(t, 2, len(op.Inputs))
+	require.Equal(t, 2, len(merge.Inputs))
+	require.Equal(t, 0, int(merge.Inputs[0].Input))
+	require.Equal(t, 1, int(merge.Inputs[1].Input))
+
+	_, ok := m[op.Inputs[0].Digest].Op.(*pb.Op_Source)
+	require.True(t, ok)
+	_, ok = m[op.Inputs[1].Digest].Op.(*pb.Op_File)
+	require.True(t, ok)
+}
+
+func TestMergeScratch(t *testing.T) {
+	t.Parallel()
+
+	st := Merge([]State{Scratch(), Scratch()})
+	require.Nil(t, st.Output())
+
+	base := Image("foo")
+	st = Merge([]State{Scratch(), base})
+	require.Equal(t, base.Output(), st.Output())
+}
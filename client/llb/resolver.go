@@ -3,6 +3,7 @@ package llb
 import (
 	"context"
 
+	"github.com/moby/buildkit/solver/pb"
 	digest "github.com/opencontainers/go-digest"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 )
@@ -28,8 +29,66 @@ type ImageMetaResolver interface {
 	ResolveImageConfig(ctx context.Context, ref string, opt ResolveImageConfigOpt) (digest.Digest, []byte, error)
 }
 
+type ResolverType int
+
+const (
+	ResolverTypeRegistry ResolverType = iota
+	ResolverTypeOCILayout
+)
+
 type ResolveImageConfigOpt struct {
+	ResolverType
+
 	Platform    *specs.Platform
 	ResolveMode string
 	LogName     string
+
+	Store ResolveImageConfigOptStore
+}
+
+// ResolveImageConfigOptStore selects the content store used by
+// ResolverTypeOCILayout.
+type ResolveImageConfigOptStore struct {
+	SessionID string
+	StoreID   string
+}
+
+// SourceMetadataResolver can resolve the immutable version of a source, e.g.
+// the commit of a git ref or the checksum of an HTTP URL.
+type SourceMetadataResolver interface {
+	ResolveSourceMetadata(ctx context.Context, op *pb.SourceOp, opt ResolveSourceMetadataOpt) (*SourceMetadata, error)
+}
+
+type ResolveSourceMetadataOpt struct {
+	Platform *specs.Platform
+	LogName  string
+}
+
+// SourceMetadata is the resolved metadata of a source. Only the field for
+// the type of the source is set.
+type SourceMetadata struct {
+	Image *ImageSourceMetadata
+	Git   *GitSourceMetadata
+	HTTP  *HTTPSourceMetadata
+}
+
+type ImageSourceMetadata struct {
+	// Digest is the digest of the manifest or index of the image.
+	Digest digest.Digest
+	Config []byte
+}
+
+type GitSourceMetadata struct {
+	// Ref is the ref that was resolved, "master" if the source has no ref.
+	Ref string
+	// Commit is the SHA of the commit Ref points to. Annotated tags are
+	// peeled to the commit they point to.
+	Commit string
+}
+
+type HTTPSourceMetadata struct {
+	// Checksum is the digest of the downloaded file.
+	Checksum digest.Digest
+	// ETag is the ETag of the file, empty if the server did not send one.
+	ETag string
 }
@@ -309,6 +309,12 @@ func Git(remote, ref string, opts ...GitOption) State {
 		addCap(&gi.Constraints, pb.CapSourceGitMountSSHSock)
 	}
 
+	if len(gi.SparsePaths) > 0 {
+		dt, _ := json.Marshal(gi.SparsePaths) // empty on error
+		attrs[pb.AttrGitSparsePaths] = string(dt)
+		addCap(&gi.Constraints, pb.CapSourceGitSparsePaths)
+	}
+
 	addCap(&gi.Constraints, pb.CapSourceGit)
 
 	source := NewSource("git://"+id, attrs, gi.Constraints)
@@ -332,6 +338,7 @@ type GitInfo struct {
 	addAuthCap       bool
 	KnownSSHHosts    string
 	MountSSHSock     string
+	SparsePaths      []string
 }
 
 func KeepGitDir() GitOption {
@@ -340,6 +347,17 @@ func KeepGitDir() GitOption {
 	})
 }
 
+// GitSparsePaths checks out only the specified files and directories of the
+// repository. Blobs of other paths are not fetched, which makes reading a few
+// files of a large repository cheap. Paths that don't exist in the commit are
+// ignored. Submodules are not checked out: a submodule is an empty directory
+// and paths inside it are ignored. Can't be combined with KeepGitDir.
+func GitSparsePaths(paths []string) GitOption {
+	return gitOptionFunc(func(gi *GitInfo) {
+		gi.SparsePaths = paths
+	})
+}
+
 func AuthTokenSecret(v string) GitOption {
 	return gitOptionFunc(func(gi *GitInfo) {
 		gi.AuthTokenSecret = v
@@ -544,8 +562,52 @@ func Chown(uid, gid int) HTTPOption {
 	})
 }
 
+// OCILayout returns a state for an image in an OCI layout content store that
+// the client exposes over the session. The ref has the form
+// <store>/<name>[:tag]@<digest> where <store> is the ID the client registered
+// the content store with. The digest is required as the store can't be
+// queried for tags.
+func OCILayout(ref string, opts ...OCILayoutOption) State {
+	var info OCILayoutInfo
+	for _, opt := range opts {
+		opt.SetOCILayoutOption(&info)
+	}
+
+	addCap(&info.Constraints, pb.CapSourceOCILayout)
+
+	attrs := map[string]string{}
+	if info.sessionID != "" {
+		attrs[pb.AttrOCILayoutSessionID] = info.sessionID
+	}
+
+	return NewState(NewSource("oci-layout://"+ref, attrs, info.Constraints).Output())
+}
+
+type OCILayoutOption interface {
+	SetOCILayoutOption(*OCILayoutInfo)
+}
+
+type ociLayoutOptionFunc func(*OCILayoutInfo)
+
+func (fn ociLayoutOptionFunc) SetOCILayoutOption(li *OCILayoutInfo) {
+	fn(li)
+}
+
+// OCISessionID sets the session that exposes the content store. If it isn't
+// set, any session of the build that has the store is used.
+func OCISessionID(id string) OCILayoutOption {
+	return ociLayoutOptionFunc(func(li *OCILayoutInfo) {
+		li.sessionID = id
+	})
+}
+
+type OCILayoutInfo struct {
+	constraintsWrapper
+	sessionID string
+}
+
 func platformSpecificSource(id string) bool {
-	return strings.HasPrefix(id, "docker-image://")
+	return strings.HasPrefix(id, "docker-image://") || strings.HasPrefix(id, "oci-layout://")
 }
 
 func addCap(c *Constraints, id apicaps.CapID) {
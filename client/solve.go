@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +34,7 @@ import (
 type SolveOpt struct {
 	Exports               []ExportEntry
 	LocalDirs             map[string]string
+	OCIStores             map[string]content.Store // key: ID of the store used by oci-layout sources
 	SharedKey             string
 	Frontend              string
 	FrontendAttrs         map[string]string
@@ -114,14 +117,6 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 		return nil, err
 	}
 
-	var ex ExportEntry
-	if len(opt.Exports) > 1 {
-		return nil, errors.New("currently only single Exports can be specified")
-	}
-	if len(opt.Exports) == 1 {
-		ex = opt.Exports[0]
-	}
-
 	if !opt.SessionPreInitialized {
 		if len(syncedDirs) > 0 {
 			s.Allow(filesync.NewFSSyncProvider(syncedDirs))
@@ -131,30 +126,18 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 			s.Allow(a)
 		}
 
-		switch ex.Type {
-		case ExporterLocal:
-			if ex.Output != nil {
-				return nil, errors.New("output file writer is not supported by local exporter")
-			}
-			if ex.OutputDir == "" {
-				return nil, errors.New("output directory is required for local exporter")
-			}
-			s.Allow(filesync.NewFSSyncTargetDir(ex.OutputDir))
-		case ExporterOCI, ExporterDocker, ExporterTar:
-			if ex.OutputDir != "" {
-				return nil, errors.Errorf("output directory %s is not supported by %s exporter", ex.OutputDir, ex.Type)
-			}
-			if ex.Output == nil {
-				return nil, errors.Errorf("output file writer is required for %s exporter", ex.Type)
-			}
-			s.Allow(filesync.NewFSSyncTarget(ex.Output))
-		default:
-			if ex.Output != nil {
-				return nil, errors.Errorf("output file writer is not supported by %s exporter", ex.Type)
-			}
-			if ex.OutputDir != "" {
-				return nil, errors.Errorf("output directory %s is not supported by %s exporter", ex.OutputDir, ex.Type)
-			}
+		targets, stores, err := exportTargets(opt.Exports)
+		if err != nil {
+			return nil, err
+		}
+		if len(targets) > 0 {
+			s.Allow(filesync.NewFSSyncMultiTarget(targets...))
+		}
+		for id, cs := range stores {
+			cacheOpt.contentStores[id] = cs
+		}
+		for id, cs := range opt.OCIStores {
+			cacheOpt.contentStores["oci-layout:"+id] = cs
 		}
 
 		if len(cacheOpt.contentStores) > 0 {
@@ -198,24 +181,56 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 			frontendInputs[key] = def.ToPB()
 		}
 
-		resp, err := c.controlClient().Solve(ctx, &controlapi.SolveRequest{
+		req := &controlapi.SolveRequest{
 			Ref:            ref,
 			Definition:     pbd,
-			Exporter:       ex.Type,
-			ExporterAttrs:  ex.Attrs,
 			Session:        s.ID(),
 			Frontend:       opt.Frontend,
 			FrontendAttrs:  opt.FrontendAttrs,
 			FrontendInputs: frontendInputs,
 			Cache:          cacheOpt.options,
 			Entitlements:   opt.AllowedEntitlements,
-		})
+		}
+		exporters := make([]*controlapi.Exporter, 0, len(opt.Exports))
+		for _, ex := range opt.Exports {
+			attrs := ex.Attrs
+			if ex.Type == ExporterOCI && ex.OutputDir != "" {
+				attrs = make(map[string]string, len(ex.Attrs)+1)
+				for k, v := range ex.Attrs {
+					attrs[k] = v
+				}
+				attrs["tar"] = "false"
+			}
+			exporters = append(exporters, &controlapi.Exporter{
+				Type:  ex.Type,
+				Attrs: attrs,
+			})
+		}
+		if len(exporters) == 1 {
+			// use the legacy fields so that a single export works with older daemons
+			req.Exporter = exporters[0].Type
+			req.ExporterAttrs = exporters[0].Attrs
+		} else {
+			req.Exporters = exporters
+		}
+
+		resp, err := c.controlClient().Solve(ctx, req)
 		if err != nil {
 			return errors.Wrap(err, "failed to solve")
 		}
+		if len(exporters) > 1 && len(resp.ExporterResponses) != len(exporters) {
+			// older daemons ignore the exporters field and don't export the result
+			return errors.Errorf("multiple exporters are not supported by the daemon")
+		}
 		res = &SolveResponse{
 			ExporterResponse: resp.ExporterResponse,
 		}
+		for _, r := range resp.ExporterResponses {
+			res.ExporterResponses = append(res.ExporterResponses, ExporterResponse{
+				Type: r.Type,
+				Data: r.Data,
+			})
+		}
 		return nil
 	})
 
@@ -288,6 +303,17 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 					Timestamp: v.Timestamp,
 				})
 			}
+			for _, v := range resp.Warnings {
+				s.Warnings = append(s.Warnings, &VertexWarning{
+					Vertex:     v.Vertex,
+					Level:      int(v.Level),
+					Short:      v.Short,
+					Detail:     v.Detail,
+					URL:        v.Url,
+					SourceInfo: v.Info,
+					Range:      v.Ranges,
+				})
+			}
 			if statusChan != nil {
 				statusChan <- &s
 			}
@@ -297,6 +323,27 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	// Update index.json of exported OCI layouts
+	for i, ex := range opt.Exports {
+		if ex.Type != ExporterOCI || ex.OutputDir == "" {
+			continue
+		}
+		resp := res.ExporterResponse
+		if i < len(res.ExporterResponses) {
+			resp = res.ExporterResponses[i].Data
+		}
+		var desc ocispec.Descriptor
+		if err := json.Unmarshal([]byte(resp["containerimage.descriptor"]), &desc); err != nil {
+			return nil, errors.Wrap(err, "failed to parse exported image descriptor")
+		}
+		tag := ex.Attrs["tag"]
+		if tag == "" {
+			tag = "latest"
+		}
+		if err := ociindex.PutDescToIndexJSONFileLocked(filepath.Join(ex.OutputDir, "index.json"), desc, tag); err != nil {
+			return nil, err
+		}
+	}
 	// Update index.json of exported cache content store
 	// FIXME(AkihiroSuda): dedupe const definition of cache/remotecache.ExporterResponseManifestDesc = "cache.manifest"
 	if manifestDescJSON := res.ExporterResponse["cache.manifest"]; manifestDescJSON != "" {
@@ -313,6 +360,77 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 	return res, nil
 }
 
+// exportTargets validates the exports and returns the session targets and
+// content stores for the exporters that send their output to the client.
+func exportTargets(exports []ExportEntry) ([]filesync.FSSyncTarget, map[string]content.Store, error) {
+	var targets []filesync.FSSyncTarget
+	stores := make(map[string]content.Store)
+	for i, ex := range exports {
+		switch ex.Type {
+		case ExporterLocal:
+			if ex.Output != nil {
+				return nil, nil, errors.New("output file writer is not supported by local exporter")
+			}
+			if ex.OutputDir == "" {
+				return nil, nil, errors.New("output directory is required for local exporter")
+			}
+			targets = append(targets, filesync.WithFSSyncDir(i, ex.OutputDir))
+		case ExporterOCI, ExporterDocker, ExporterTar:
+			if ex.Type == ExporterOCI && ex.OutputDir == "" && ex.Attrs["tar"] == "false" {
+				return nil, nil, errors.New("output directory is required for oci exporter with tar=false")
+			}
+			if ex.Type == ExporterOCI && ex.OutputDir != "" {
+				if ex.Output != nil {
+					return nil, nil, errors.New("output file writer is not supported by oci exporter with output directory")
+				}
+				cs, err := ociLayoutStore(ex.OutputDir)
+				if err != nil {
+					return nil, nil, err
+				}
+				// the exporter writes to the store with its ID
+				stores["oci:"+strconv.Itoa(i)] = cs
+				continue
+			}
+			if ex.OutputDir != "" {
+				return nil, nil, errors.Errorf("output directory %s is not supported by %s exporter", ex.OutputDir, ex.Type)
+			}
+			if ex.Output == nil {
+				return nil, nil, errors.Errorf("output file writer is required for %s exporter", ex.Type)
+			}
+			targets = append(targets, filesync.WithFSSync(i, ex.Output))
+		default:
+			if ex.Output != nil {
+				return nil, nil, errors.Errorf("output file writer is not supported by %s exporter", ex.Type)
+			}
+			if ex.OutputDir != "" {
+				return nil, nil, errors.Errorf("output directory %s is not supported by %s exporter", ex.OutputDir, ex.Type)
+			}
+		}
+	}
+	return targets, stores, nil
+}
+
+// ociLayoutStore returns the content store of the OCI image layout in dir,
+// creating the layout if it does not exist yet.
+func ociLayoutStore(dir string) (content.Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	layoutPath := filepath.Join(dir, ocispec.ImageLayoutFile)
+	if _, err := os.Stat(layoutPath); errors.Is(err, os.ErrNotExist) {
+		dt, err := json.Marshal(ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion})
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(layoutPath, dt, 0644); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return contentlocal.NewStore(dir)
+}
+
 func prepareSyncedDirs(def *llb.Definition, localDirs map[string]string) ([]filesync.SyncedDir, error) {
 	for _, d := range localDirs {
 		fi, err := os.Stat(d)
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/containerd/console"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/cmd/buildctl/build"
@@ -34,7 +35,7 @@ var buildCommand = cli.Command{
 	Flags: []cli.Flag{
 		cli.StringSliceFlag{
 			Name:  "output,o",
-			Usage: "Define exports for build result, e.g. --output type=image,name=docker.io/username/image,push=true",
+			Usage: "Define exports for build result, can be specified multiple times, e.g. --output type=image,name=docker.io/username/image,push=true",
 		},
 		cli.StringFlag{
 			Name:   "exporter",
@@ -59,6 +60,10 @@ var buildCommand = cli.Command{
 			Name:  "local",
 			Usage: "Allow build access to the local directory",
 		},
+		cli.StringSliceFlag{
+			Name:  "oci-layout",
+			Usage: "Allow build access to the local OCI layout, e.g. --oci-layout mystore=path/to/layout",
+		},
 		cli.StringFlag{
 			Name:  "frontend",
 			Usage: "Define frontend used for build",
@@ -95,12 +100,16 @@ var buildCommand = cli.Command{
 		},
 		cli.StringSliceFlag{
 			Name:  "allow",
-			Usage: "Allow extra privileged entitlement, e.g. network.host, security.insecure",
+			Usage: "Allow extra privileged entitlement, e.g. network.host, security.insecure, device",
 		},
 		cli.StringSliceFlag{
 			Name:  "ssh",
 			Usage: "Allow forwarding SSH agent to the builder. Format default|<id>[=<socket>|<key>[,<key>]]",
 		},
+		cli.BoolFlag{
+			Name:  "debug-on-failure",
+			Usage: "Start an interactive shell in the filesystem of the failed step. Requires a terminal",
+		},
 	},
 }
 
@@ -226,6 +235,11 @@ func buildAction(clicontext *cli.Context) error {
 		return errors.Wrap(err, "invalid local")
 	}
 
+	solveOpt.OCIStores, err = build.ParseOCILayout(clicontext.StringSlice("oci-layout"))
+	if err != nil {
+		return errors.Wrap(err, "invalid oci-layout")
+	}
+
 	var def *llb.Definition
 	if clicontext.String("frontend") == "" {
 		if fi, _ := os.Stdin.Stat(); (fi.Mode() & os.ModeCharDevice) != 0 {
@@ -244,8 +258,24 @@ func buildAction(clicontext *cli.Context) error {
 		}
 	}
 
+	progress := clicontext.String("progress")
+	var con console.Console
+	if clicontext.Bool("debug-on-failure") {
+		con, err = console.ConsoleFromFile(os.Stdin)
+		if err != nil {
+			return errors.New("--debug-on-failure requires a terminal")
+		}
+		// the interactive display would draw over the shell
+		switch progress {
+		case "auto":
+			progress = "plain"
+		case "tty":
+			return errors.New("--debug-on-failure cannot be used with --progress=tty")
+		}
+	}
+
 	// not using shared context to not disrupt display but let is finish reporting errors
-	pw, err := progresswriter.NewPrinter(context.TODO(), os.Stderr, clicontext.String("progress"))
+	pw, err := progresswriter.NewPrinter(context.TODO(), os.Stderr, progress)
 	if err != nil {
 		return err
 	}
@@ -283,7 +313,20 @@ func buildAction(clicontext *cli.Context) error {
 				close(w.Status())
 			}
 		}()
-		resp, err := c.Solve(ctx, def, solveOpt, progresswriter.ResetTime(mw.WithPrefix("", false)).Status())
+		var (
+			resp *client.SolveResponse
+			err  error
+		)
+		statusCh := progresswriter.ResetTime(mw.WithPrefix("", false)).Status()
+		if con != nil {
+			// the definition can't be read from stdin here, so a frontend
+			// is always set
+			buildFunc := debugBuildFunc(con, solveOpt.Frontend, solveOpt.FrontendAttrs)
+			solveOpt.Frontend = ""
+			resp, err = c.Build(ctx, solveOpt, "buildctl", buildFunc, statusCh)
+		} else {
+			resp, err = c.Solve(ctx, def, solveOpt, statusCh)
+		}
 		if err != nil {
 			return err
 		}
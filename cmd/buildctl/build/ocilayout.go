@@ -0,0 +1,27 @@
+package build
+
+import (
+	"github.com/containerd/containerd/content"
+	contentlocal "github.com/containerd/containerd/content/local"
+	"github.com/pkg/errors"
+)
+
+// ParseOCILayout parses --oci-layout
+func ParseOCILayout(layouts []string) (map[string]content.Store, error) {
+	dirs, err := attrMap(layouts)
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		return nil, nil
+	}
+	stores := make(map[string]content.Store, len(dirs))
+	for id, dir := range dirs {
+		cs, err := contentlocal.NewStore(dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open oci layout %s", dir)
+		}
+		stores[id] = cs
+	}
+	return stores, nil
+}
@@ -11,8 +11,9 @@ import (
 	"github.com/pkg/errors"
 )
 
-// parseOutputCSV parses a single --output CSV string
-func parseOutputCSV(s string) (client.ExportEntry, error) {
+// parseOutputCSV parses a single --output CSV string and returns the export
+// entry and its destination
+func parseOutputCSV(s string) (client.ExportEntry, string, error) {
 	ex := client.ExportEntry{
 		Type:  "",
 		Attrs: map[string]string{},
@@ -20,12 +21,12 @@ func parseOutputCSV(s string) (client.ExportEntry, error) {
 	csvReader := csv.NewReader(strings.NewReader(s))
 	fields, err := csvReader.Read()
 	if err != nil {
-		return ex, err
+		return ex, "", err
 	}
 	for _, field := range fields {
 		parts := strings.SplitN(field, "=", 2)
 		if len(parts) != 2 {
-			return ex, errors.Errorf("invalid value %s", field)
+			return ex, "", errors.Errorf("invalid value %s", field)
 		}
 		key := strings.ToLower(parts[0])
 		value := parts[1]
@@ -37,34 +38,60 @@ func parseOutputCSV(s string) (client.ExportEntry, error) {
 		}
 	}
 	if ex.Type == "" {
-		return ex, errors.New("--output requires type=<type>")
+		return ex, "", errors.New("--output requires type=<type>")
 	}
 	if v, ok := ex.Attrs["output"]; ok {
-		return ex, errors.Errorf("output=%s not supported for --output, you meant dest=%s?", v, v)
+		return ex, "", errors.Errorf("output=%s not supported for --output, you meant dest=%s?", v, v)
 	}
-	ex.Output, ex.OutputDir, err = resolveExporterDest(ex.Type, ex.Attrs["dest"])
+	dest := ex.Attrs["dest"]
+	if ex.Type == client.ExporterOCI && ex.Attrs["tar"] == "false" {
+		// write an OCI layout directory instead of a tarball
+		if dest == "" {
+			return ex, "", errors.New("output directory is required for oci exporter with tar=false")
+		}
+		ex.OutputDir = dest
+		delete(ex.Attrs, "dest")
+		return ex, dest, nil
+	}
+	ex.Output, ex.OutputDir, err = resolveExporterDest(ex.Type, dest)
 	if err != nil {
-		return ex, errors.Wrap(err, "invalid output option: output")
+		return ex, "", errors.Wrap(err, "invalid output option: output")
 	}
 	if ex.Output != nil || ex.OutputDir != "" {
 		delete(ex.Attrs, "dest")
 	}
-	return ex, nil
+	return ex, dest, nil
 }
 
 // ParseOutput parses --output
+// --output can be repeated to export the result with multiple exporters.
 func ParseOutput(exports []string) ([]client.ExportEntry, error) {
 	var entries []client.ExportEntry
+	stdout := false
 	for _, s := range exports {
-		e, err := parseOutputCSV(s)
+		e, dest, err := parseOutputCSV(s)
 		if err != nil {
 			return nil, err
 		}
+		if e.OutputDir == "" && isStdoutDest(e.Type, dest) {
+			if stdout {
+				return nil, errors.New("only one output can be written to stdout")
+			}
+			stdout = true
+		}
 		entries = append(entries, e)
 	}
 	return entries, nil
 }
 
+func isStdoutDest(exporter, dest string) bool {
+	switch exporter {
+	case client.ExporterOCI, client.ExporterDocker, client.ExporterTar:
+		return dest == "" || dest == "-"
+	}
+	return false
+}
+
 // ParseLegacyExporter parses legacy --exporter <type> --exporter-opt <opt>=<optval>
 func ParseLegacyExporter(legacyExporter string, legacyExporterOpts []string) ([]client.ExportEntry, error) {
 	var ex client.ExportEntry
@@ -0,0 +1,52 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	dir := t.TempDir()
+
+	exports, err := ParseOutput([]string{
+		"type=image,name=docker.io/foo/bar,push=true",
+		"type=local,dest=" + filepath.Join(dir, "out"),
+		"type=oci,dest=" + filepath.Join(dir, "out.tar"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(exports))
+
+	require.Equal(t, client.ExporterImage, exports[0].Type)
+	require.Equal(t, map[string]string{"name": "docker.io/foo/bar", "push": "true"}, exports[0].Attrs)
+
+	require.Equal(t, client.ExporterLocal, exports[1].Type)
+	require.Equal(t, filepath.Join(dir, "out"), exports[1].OutputDir)
+	require.Equal(t, map[string]string{}, exports[1].Attrs)
+
+	require.Equal(t, client.ExporterOCI, exports[2].Type)
+	require.NotNil(t, exports[2].Output)
+	require.Equal(t, map[string]string{}, exports[2].Attrs)
+
+	_, err = ParseOutput([]string{
+		"type=tar,dest=-",
+		"type=oci",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stdout")
+
+	exports, err = ParseOutput([]string{
+		"type=oci,dest=" + filepath.Join(dir, "layout") + ",tar=false,tag=v1",
+		"type=tar",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(exports))
+	require.Equal(t, filepath.Join(dir, "layout"), exports[0].OutputDir)
+	require.Nil(t, exports[0].Output)
+	require.Equal(t, map[string]string{"tar": "false", "tag": "v1"}, exports[0].Attrs)
+
+	_, err = ParseOutput([]string{"type=oci,tar=false"})
+	require.Error(t, err)
+}
@@ -111,6 +111,8 @@ func attr(dgst digest.Digest, op pb.Op) (string, string) {
 		return strings.Join(op.Exec.Meta.Args, " "), "box"
 	case *pb.Op_Build:
 		return "build", "box3d"
+	case *pb.Op_Merge:
+		return "merge", "invtriangle"
 	case *pb.Op_File:
 		names := []string{}
 
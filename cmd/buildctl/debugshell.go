@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/containerd/console"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/solver/errdefs"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const debugShellCommand = "/bin/sh"
+
+// debugBuildFunc returns a build function that solves the frontend request
+// and, if a step fails, starts an interactive shell in the filesystem of that
+// step before returning the error.
+func debugBuildFunc(con console.Console, frontend string, frontendOpt map[string]string) gateway.BuildFunc {
+	return func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := c.Solve(ctx, gateway.SolveRequest{
+			Frontend:    frontend,
+			FrontendOpt: frontendOpt,
+			Evaluate:    true,
+		})
+		if err != nil {
+			if derr := debugShell(ctx, c, con, err); derr != nil {
+				logrus.Errorf("failed to run debug shell: %v", derr)
+			}
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+// debugShell runs an interactive shell inside the mounts and environment of
+// the exec that caused solveErr. The container is released when the shell
+// exits. A non-zero exit status of the shell is reported on stderr and is not
+// an error. Gateway containers can't use devices or secrets exposed as
+// environment variables, so these are omitted from the shell and a notice is
+// printed instead.
+func debugShell(ctx context.Context, c gateway.Client, con console.Console, solveErr error) error {
+	var se *errdefs.SolveError
+	if !errors.As(solveErr, &se) {
+		return nil
+	}
+	op := se.Solve.Op
+	exec := op.GetExec()
+	if exec == nil {
+		return nil
+	}
+
+	mounts, err := debugMounts(exec, se.Solve.MountIDs)
+	if err != nil {
+		return err
+	}
+
+	caps := c.BuildOpts().Caps
+	if err := (&caps).Supports(gwpb.CapGatewayExec); err != nil {
+		return err
+	}
+
+	ctr, err := c.NewContainer(ctx, gateway.NewContainerRequest{
+		Mounts:      mounts,
+		NetMode:     exec.Network,
+		Platform:    op.Platform,
+		Constraints: op.Constraints,
+	})
+	if err != nil {
+		return err
+	}
+	defer ctr.Release(context.TODO())
+
+	if err := con.SetRaw(); err != nil {
+		return err
+	}
+	defer con.Reset()
+
+	fmt.Fprintf(os.Stderr, "\r\nstarting debug shell for failed step %q, exit the shell to continue\r\n", strings.Join(exec.Meta.Args, " "))
+	if len(exec.Devices) > 0 {
+		fmt.Fprintf(os.Stderr, "devices of the failed step are not available in the debug shell\r\n")
+	}
+	if len(exec.Secretenv) > 0 {
+		fmt.Fprintf(os.Stderr, "secret environment variables of the failed step are not set in the debug shell\r\n")
+	}
+
+	proc, err := ctr.Start(ctx, gateway.StartRequest{
+		Args:         []string{debugShellCommand},
+		Env:          exec.Meta.Env,
+		User:         exec.Meta.User,
+		Cwd:          exec.Meta.Cwd,
+		Tty:          true,
+		Stdin:        ioutil.NopCloser(con),
+		Stdout:       nopWriteCloser{con},
+		Stderr:       nopWriteCloser{con},
+		SecurityMode: exec.Security,
+	})
+	if err != nil {
+		return err
+	}
+
+	resizeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	resizeConsole(resizeCtx, con, proc)
+
+	if err := proc.Wait(); err != nil {
+		var ee *errdefs.ExitError
+		if errors.As(err, &ee) {
+			fmt.Fprintf(os.Stderr, "\r\ndebug shell exited with status %d\r\n", ee.ExitCode)
+			return nil
+		}
+		return errors.Wrap(err, "debug shell failed")
+	}
+	return nil
+}
+
+// debugMounts converts the mounts of a failed exec to container mounts
+// backed by the results kept alive by the solver.
+func debugMounts(exec *pb.ExecOp, mountIDs []string) ([]gateway.Mount, error) {
+	if len(mountIDs) != len(exec.Mounts) {
+		return nil, errors.Errorf("expected %d mounts for failed exec, got %d", len(exec.Mounts), len(mountIDs))
+	}
+	mounts := make([]gateway.Mount, 0, len(exec.Mounts))
+	for i, m := range exec.Mounts {
+		mounts = append(mounts, gateway.Mount{
+			Selector:  m.Selector,
+			Dest:      m.Dest,
+			ResultID:  mountIDs[i],
+			Readonly:  m.Readonly,
+			MountType: m.MountType,
+			CacheOpt:  m.CacheOpt,
+			SecretOpt: m.SecretOpt,
+			SSHOpt:    m.SSHOpt,
+		})
+	}
+	return mounts, nil
+}
+
+// resizeConsole sets the size of the process terminal to the size of the
+// console and keeps it in sync until ctx is done.
+func resizeConsole(ctx context.Context, con console.Console, proc gateway.ContainerProcess) {
+	resize := func() {
+		size, err := con.Size()
+		if err != nil {
+			return
+		}
+		if err := proc.Resize(ctx, gateway.WinSize{
+			Rows: uint32(size.Height),
+			Cols: uint32(size.Width),
+		}); err != nil {
+			logrus.Debugf("failed to resize debug shell: %v", err)
+		}
+	}
+	resize()
+	go watchConsoleSize(ctx, resize)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
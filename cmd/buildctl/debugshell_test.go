@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugMounts(t *testing.T) {
+	exec := &pb.ExecOp{
+		Mounts: []*pb.Mount{
+			{Dest: "/", Input: 0, Output: 0},
+			{Dest: "/src", Input: 1, Selector: "/app", Readonly: true, Output: pb.SkipOutput},
+			{Dest: "/tmp", Input: pb.Empty, MountType: pb.MountType_TMPFS, Output: pb.SkipOutput},
+		},
+	}
+
+	mounts, err := debugMounts(exec, []string{"root", "src", ""})
+	require.NoError(t, err)
+	require.Equal(t, []gateway.Mount{
+		{Dest: "/", ResultID: "root"},
+		{Dest: "/src", ResultID: "src", Selector: "/app", Readonly: true},
+		{Dest: "/tmp", MountType: pb.MountType_TMPFS},
+	}, mounts)
+
+	_, err = debugMounts(exec, []string{"root"})
+	require.Error(t, err)
+}
@@ -0,0 +1,6 @@
+package main
+
+import "context"
+
+func watchConsoleSize(ctx context.Context, resize func()) {
+}
@@ -11,6 +11,9 @@ type Config struct {
 
 	//Entitlements e.g. security.insecure, network.host
 	Entitlements []string `toml:"insecure-entitlements"`
+	// AllowedDevices are the host device paths, or path patterns, that can
+	// be used by builds granted the device entitlement
+	AllowedDevices []string `toml:"allowed-devices"`
 	// GRPC configuration settings
 	GRPC GRPCConfig `toml:"grpc"`
 
@@ -22,6 +25,26 @@ type Config struct {
 	Registries map[string]RegistryConfig `toml:"registry"`
 
 	DNS *DNSConfig `toml:"dns"`
+
+	Frontend FrontendConfig `toml:"frontend"`
+}
+
+type FrontendConfig struct {
+	Policy FrontendPolicyConfig `toml:"policy"`
+}
+
+// FrontendPolicyConfig restricts the frontend images that builds can run,
+// e.g. with the #syntax directive of Dockerfiles.
+type FrontendPolicyConfig struct {
+	// AllowedRepositories lists the repositories frontend images can be
+	// loaded from, e.g. docker.io/docker/dockerfile. A trailing "/*" allows
+	// all repositories under a prefix.
+	AllowedRepositories []string `toml:"allowedRepositories"`
+	// RequireDigest requires frontend images to be pinned by digest.
+	RequireDigest bool `toml:"requireDigest"`
+	// PublicKeys are paths to PEM encoded public keys. If set, frontend
+	// images must have a cosign signature made with one of the keys.
+	PublicKeys []string `toml:"publicKeys"`
 }
 
 type GRPCConfig struct {
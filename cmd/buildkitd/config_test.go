@@ -13,6 +13,7 @@ func TestConfig(t *testing.T) {
 root = "/foo/bar"
 debug=true
 insecure-entitlements = ["security.insecure"]
+allowed-devices = ["/dev/fuse"]
 
 [gc]
 enabled=true
@@ -70,6 +71,7 @@ searchDomains=["example.com"]
 	require.Equal(t, "/foo/bar", cfg.Root)
 	require.Equal(t, true, cfg.Debug)
 	require.Equal(t, "security.insecure", cfg.Entitlements[0])
+	require.Equal(t, []string{"/dev/fuse"}, cfg.AllowedDevices)
 
 	require.Equal(t, "buildkit.sock", cfg.GRPC.Address[0])
 	require.Equal(t, "debug.sock", cfg.GRPC.DebugAddress)
@@ -173,7 +173,11 @@ func main() {
 		},
 		cli.StringSliceFlag{
 			Name:  "allow-insecure-entitlement",
-			Usage: "allows insecure entitlements e.g. network.host, security.insecure",
+			Usage: "allows insecure entitlements e.g. network.host, security.insecure, device",
+		},
+		cli.StringSliceFlag{
+			Name:  "allow-device",
+			Usage: "allows builds with the device entitlement to use a host device path or pattern e.g. /dev/fuse",
 		},
 	)
 	app.Flags = append(app.Flags, appFlags...)
@@ -253,6 +257,8 @@ func main() {
 					cfg.Entitlements = append(cfg.Entitlements, e)
 				case "network.host":
 					cfg.Entitlements = append(cfg.Entitlements, e)
+				case "device":
+					cfg.Entitlements = append(cfg.Entitlements, e)
 				default:
 					return fmt.Errorf("invalid entitlement : %v", e)
 				}
@@ -433,6 +439,11 @@ func applyMainFlags(c *cli.Context, cfg *config.Config, md *toml.MetaData) error
 		cfg.Entitlements = c.StringSlice("allow-insecure-entitlement")
 	}
 
+	if c.IsSet("allow-device") {
+		//override values from config
+		cfg.AllowedDevices = c.StringSlice("allow-device")
+	}
+
 	if c.IsSet("debugaddr") {
 		cfg.GRPC.DebugAddress = c.String("debugaddr")
 	}
@@ -594,17 +605,22 @@ func newController(c *cli.Context, cfg *config.Config, md *toml.MetaData) (*cont
 	if err != nil {
 		return nil, err
 	}
+	resolverFn := resolverFunc(cfg)
+
+	frontendPolicy, err := newFrontendPolicy(cfg.Frontend.Policy, resolverFn)
+	if err != nil {
+		return nil, err
+	}
+
 	frontends := map[string]frontend.Frontend{}
 	frontends["dockerfile.v0"] = forwarder.NewGatewayForwarder(wc, dockerfile.Build)
-	frontends["gateway.v0"] = gateway.NewGatewayFrontend(wc)
+	frontends["gateway.v0"] = gateway.NewGatewayFrontend(wc, frontendPolicy)
 
 	cacheStorage, err := bboltcachestorage.NewStore(filepath.Join(cfg.Root, "cache.db"))
 	if err != nil {
 		return nil, err
 	}
 
-	resolverFn := resolverFunc(cfg)
-
 	w, err := wc.GetDefault()
 	if err != nil {
 		return nil, err
@@ -627,6 +643,7 @@ func newController(c *cli.Context, cfg *config.Config, md *toml.MetaData) (*cont
 		ResolveCacheImporterFuncs: remoteCacheImporterFuncs,
 		CacheKeyStorage:           cacheStorage,
 		Entitlements:              cfg.Entitlements,
+		AllowedDevices:            cfg.AllowedDevices,
 	})
 }
 
@@ -715,6 +732,26 @@ func getGCPolicy(cfg config.GCConfig, root string) []client.PruneInfo {
 	return out
 }
 
+func newFrontendPolicy(cfg config.FrontendPolicyConfig, hosts docker.RegistryHosts) (*gateway.Policy, error) {
+	if len(cfg.AllowedRepositories) == 0 && !cfg.RequireDigest && len(cfg.PublicKeys) == 0 {
+		return nil, nil
+	}
+	keys := make([][]byte, 0, len(cfg.PublicKeys))
+	for _, p := range cfg.PublicKeys {
+		dt, err := ioutil.ReadFile(p)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read frontend public key")
+		}
+		keys = append(keys, dt)
+	}
+	return gateway.NewPolicy(gateway.PolicyOpt{
+		AllowedRepositories: cfg.AllowedRepositories,
+		RequireDigest:       cfg.RequireDigest,
+		PublicKeys:          keys,
+		RegistryHosts:       hosts,
+	})
+}
+
 func getDNSConfig(cfg *config.DNSConfig) *oci.DNSConfig {
 	var dns *oci.DNSConfig
 	if cfg != nil {
@@ -35,6 +35,9 @@ type Opt struct {
 	ResolveCacheExporterFuncs map[string]remotecache.ResolveCacheExporterFunc
 	ResolveCacheImporterFuncs map[string]remotecache.ResolveCacheImporterFunc
 	Entitlements              []string
+	// AllowedDevices are the host device paths, or path patterns, that
+	// builds granted the device entitlement can use
+	AllowedDevices []string
 }
 
 type Controller struct { // TODO: ControlService
@@ -52,7 +55,7 @@ func NewController(opt Opt) (*Controller, error) {
 
 	gatewayForwarder := controlgateway.NewGatewayForwarder()
 
-	solver, err := llbsolver.New(opt.WorkerController, opt.Frontends, cache, opt.ResolveCacheImporterFuncs, gatewayForwarder, opt.SessionManager, opt.Entitlements)
+	solver, err := llbsolver.New(opt.WorkerController, opt.Frontends, cache, opt.ResolveCacheImporterFuncs, gatewayForwarder, opt.SessionManager, opt.Entitlements, opt.AllowedDevices)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create solver")
 	}
@@ -210,6 +213,15 @@ func translateLegacySolveRequest(req *controlapi.SolveRequest) error {
 		req.Cache.Imports = append(req.Cache.Imports, im)
 	}
 	req.Cache.ImportRefsDeprecated = nil
+	// translates Exporter to Exporters
+	if req.Exporter != "" && len(req.Exporters) == 0 {
+		req.Exporters = []*controlapi.Exporter{{
+			Type:  req.Exporter,
+			Attrs: req.ExporterAttrs,
+		}}
+	}
+	req.Exporter = ""
+	req.ExporterAttrs = nil
 	return nil
 }
 
@@ -225,22 +237,23 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		time.AfterFunc(time.Second, c.throttledGC)
 	}()
 
-	var expi exporter.ExporterInstance
+	var expis []exporter.ExporterInstance
 	// TODO: multiworker
 	// This is actually tricky, as the exporter should come from the worker that has the returned reference. We may need to delay this so that the solver loads this.
 	w, err := c.opt.WorkerController.GetDefault()
 	if err != nil {
 		return nil, err
 	}
-	if req.Exporter != "" {
-		exp, err := w.Exporter(req.Exporter, c.opt.SessionManager)
+	for i, ex := range req.Exporters {
+		exp, err := w.Exporter(ex.Type, c.opt.SessionManager)
 		if err != nil {
 			return nil, err
 		}
-		expi, err = exp.Resolve(ctx, req.ExporterAttrs)
+		expi, err := exp.Resolve(ctx, i, ex.Attrs)
 		if err != nil {
 			return nil, err
 		}
+		expis = append(expis, expi)
 	}
 
 	var (
@@ -279,16 +292,23 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		FrontendInputs: req.FrontendInputs,
 		CacheImports:   cacheImports,
 	}, llbsolver.ExporterRequest{
-		Exporter:        expi,
+		Exporters:       expis,
 		CacheExporter:   cacheExporter,
 		CacheExportMode: cacheExportMode,
 	}, req.Entitlements)
 	if err != nil {
 		return nil, err
 	}
-	return &controlapi.SolveResponse{
+	res := &controlapi.SolveResponse{
 		ExporterResponse: resp.ExporterResponse,
-	}, nil
+	}
+	for i, r := range resp.ExporterResponses {
+		res.ExporterResponses = append(res.ExporterResponses, &controlapi.ExporterResponse{
+			Type: req.Exporters[i].Type,
+			Data: r.Data,
+		})
+	}
+	return res, nil
 }
 
 func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Control_StatusServer) error {
@@ -332,6 +352,17 @@ func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Con
 						Completed: v.Completed,
 					})
 				}
+				for _, v := range ss.Warnings {
+					sr.Warnings = append(sr.Warnings, &controlapi.VertexWarning{
+						Vertex: v.Vertex,
+						Level:  int64(v.Level),
+						Short:  v.Short,
+						Detail: v.Detail,
+						Url:    v.URL,
+						Info:   v.SourceInfo,
+						Ranges: v.Range,
+					})
+				}
 				for i, v := range ss.Logs {
 					sr.Logs = append(sr.Logs, &controlapi.VertexLog{
 						Vertex:    v.Vertex,
@@ -344,6 +375,7 @@ func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Con
 					if logSize > 1024*1024 {
 						ss.Vertexes = nil
 						ss.Statuses = nil
+						ss.Warnings = nil
 						ss.Logs = ss.Logs[i+1:]
 						retry = true
 						break
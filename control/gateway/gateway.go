@@ -85,6 +85,15 @@ func (gwf *GatewayForwarder) lookupForwarder(ctx context.Context) (gateway.LLBBr
 	}
 }
 
+func (gwf *GatewayForwarder) ResolveSourceMetadata(ctx context.Context, req *gwapi.ResolveSourceMetadataRequest) (*gwapi.ResolveSourceMetadataResponse, error) {
+	fwd, err := gwf.lookupForwarder(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "forwarding ResolveSourceMetadata")
+	}
+
+	return fwd.ResolveSourceMetadata(ctx, req)
+}
+
 func (gwf *GatewayForwarder) ResolveImageConfig(ctx context.Context, req *gwapi.ResolveImageConfigRequest) (*gwapi.ResolveImageConfigResponse, error) {
 	fwd, err := gwf.lookupForwarder(ctx)
 	if err != nil {
@@ -169,6 +178,30 @@ func (gwf *GatewayForwarder) ReleaseContainer(ctx context.Context, req *gwapi.Re
 	return fwd.ReleaseContainer(ctx, req)
 }
 
+func (gwf *GatewayForwarder) SnapshotMount(ctx context.Context, req *gwapi.SnapshotMountRequest) (*gwapi.SnapshotMountResponse, error) {
+	fwd, err := gwf.lookupForwarder(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "forwarding SnapshotMount")
+	}
+	return fwd.SnapshotMount(ctx, req)
+}
+
+func (gwf *GatewayForwarder) Warn(ctx context.Context, req *gwapi.WarnRequest) (*gwapi.WarnResponse, error) {
+	fwd, err := gwf.lookupForwarder(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "forwarding Warn")
+	}
+	return fwd.Warn(ctx, req)
+}
+
+func (gwf *GatewayForwarder) Progress(ctx context.Context, req *gwapi.ProgressRequest) (*gwapi.ProgressResponse, error) {
+	fwd, err := gwf.lookupForwarder(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "forwarding Progress")
+	}
+	return fwd.Progress(ctx, req)
+}
+
 func (gwf *GatewayForwarder) ExecProcess(srv gwapi.LLBBridge_ExecProcessServer) error {
 	fwd, err := gwf.lookupForwarder(srv.Context())
 	if err != nil {
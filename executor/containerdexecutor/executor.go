@@ -209,7 +209,7 @@ func (w *containerdExecutor) Run(ctx context.Context, id string, root executor.M
 		}
 	}()
 
-	err = w.runProcess(ctx, task, process.Resize, func() {
+	err = w.runProcess(ctx, task, process.Resize, process.Signal, func() {
 		startedOnce.Do(func() {
 			if started != nil {
 				close(started)
@@ -303,7 +303,7 @@ func (w *containerdExecutor) Exec(ctx context.Context, id string, process execut
 		return errors.WithStack(err)
 	}
 
-	err = w.runProcess(ctx, taskProcess, process.Resize, nil)
+	err = w.runProcess(ctx, taskProcess, process.Resize, process.Signal, nil)
 	return err
 }
 
@@ -320,7 +320,7 @@ func fixProcessOutput(process *executor.ProcessInfo) {
 	}
 }
 
-func (w *containerdExecutor) runProcess(ctx context.Context, p containerd.Process, resize <-chan executor.WinSize, started func()) error {
+func (w *containerdExecutor) runProcess(ctx context.Context, p containerd.Process, resize <-chan executor.WinSize, signal <-chan syscall.Signal, started func()) error {
 	// Not using `ctx` here because the context passed only affects the statusCh which we
 	// don't want cancelled when ctx.Done is sent.  We want to process statusCh on cancel.
 	statusCh, err := p.Wait(context.Background())
@@ -339,23 +339,33 @@ func (w *containerdExecutor) runProcess(ctx context.Context, p containerd.Proces
 
 	p.CloseIO(ctx, containerd.WithStdinCloser)
 
-	// resize in separate go loop so it does not potentially block
+	// resize and signal in separate go loop so it does not potentially block
 	// the container cancel/exit status loop below.
-	resizeCtx, resizeCancel := context.WithCancel(ctx)
-	defer resizeCancel()
+	eventCtx, eventCancel := context.WithCancel(ctx)
+	defer eventCancel()
 	go func() {
 		for {
 			select {
-			case <-resizeCtx.Done():
+			case <-eventCtx.Done():
 				return
 			case size, ok := <-resize:
 				if !ok {
-					return // chan closed
+					resize = nil // chan closed
+					continue
 				}
-				err = p.Resize(resizeCtx, size.Cols, size.Rows)
+				err := p.Resize(eventCtx, size.Cols, size.Rows)
 				if err != nil {
 					logrus.Warnf("Failed to resize %s: %s", p.ID(), err)
 				}
+			case sig, ok := <-signal:
+				if !ok {
+					signal = nil // chan closed
+					continue
+				}
+				err := p.Kill(eventCtx, sig)
+				if err != nil {
+					logrus.Warnf("Failed to send %s to %s: %s", sig, p.ID(), err)
+				}
 			}
 		}
 	}()
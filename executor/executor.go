@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net"
+	"syscall"
 
 	"github.com/moby/buildkit/snapshot"
 	"github.com/moby/buildkit/solver/pb"
@@ -20,6 +21,7 @@ type Meta struct {
 	ExtraHosts     []HostIP
 	NetMode        pb.NetMode
 	SecurityMode   pb.SecurityMode
+	Devices        []*pb.Device
 }
 
 type Mountable interface {
@@ -43,6 +45,7 @@ type ProcessInfo struct {
 	Stdin          io.ReadCloser
 	Stdout, Stderr io.WriteCloser
 	Resize         <-chan WinSize
+	Signal         <-chan syscall.Signal
 }
 
 type Executor interface {
@@ -64,6 +64,12 @@ func GenerateSpec(ctx context.Context, meta executor.Meta, mounts []executor.Mou
 		return nil, nil, err
 	}
 
+	if deviceOpts, err := generateDeviceOpts(meta.Devices); err == nil {
+		opts = append(opts, deviceOpts...)
+	} else {
+		return nil, nil, err
+	}
+
 	if idmapOpts, err := generateIDmapOpts(idmap); err == nil {
 		opts = append(opts, idmapOpts...)
 	} else {
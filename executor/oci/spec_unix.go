@@ -13,6 +13,7 @@ import (
 	"github.com/moby/buildkit/util/entitlements/security"
 	"github.com/moby/buildkit/util/system"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
 )
 
 func generateMountOpts(resolvConf, hostsFile string) ([]oci.SpecOpts, error) {
@@ -51,6 +52,32 @@ func generateProcessModeOpts(mode ProcessMode) ([]oci.SpecOpts, error) {
 	return nil, nil
 }
 
+func generateDeviceOpts(devices []*pb.Device) ([]oci.SpecOpts, error) {
+	opts := make([]oci.SpecOpts, 0, len(devices))
+	for _, d := range devices {
+		opts = append(opts, withDevice(d))
+	}
+	return opts, nil
+}
+
+// withDevice adds the host device to the spec and allows access to it in
+// the devices cgroup
+func withDevice(d *pb.Device) oci.SpecOpts {
+	return func(ctx context.Context, c oci.Client, ctr *containers.Container, s *specs.Spec) error {
+		permissions := d.Permissions
+		if permissions == "" {
+			permissions = "rwm"
+		}
+		if err := oci.WithLinuxDevice(d.Source, permissions)(ctx, c, ctr, s); err != nil {
+			return errors.Wrapf(err, "failed to add device %s", d.Source)
+		}
+		if d.Dest != "" {
+			s.Linux.Devices[len(s.Linux.Devices)-1].Path = d.Dest
+		}
+		return nil
+	}
+}
+
 func generateIDmapOpts(idmap *idtools.IdentityMapping) ([]oci.SpecOpts, error) {
 	if idmap == nil {
 		return nil, nil
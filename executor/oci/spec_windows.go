@@ -29,6 +29,13 @@ func generateProcessModeOpts(mode ProcessMode) ([]oci.SpecOpts, error) {
 	return nil, nil
 }
 
+func generateDeviceOpts(devices []*pb.Device) ([]oci.SpecOpts, error) {
+	if len(devices) > 0 {
+		return nil, errors.New("no support for devices on Windows")
+	}
+	return nil, nil
+}
+
 func generateIDmapOpts(idmap *idtools.IdentityMapping) ([]oci.SpecOpts, error) {
 	if idmap == nil {
 		return nil, nil
@@ -3,7 +3,11 @@ package runcexecutor
 import (
 	"context"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -11,6 +15,7 @@ import (
 	runc "github.com/containerd/go-runc"
 	"github.com/docker/docker/pkg/signal"
 	"github.com/moby/buildkit/executor"
+	"github.com/moby/buildkit/identity"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -23,7 +28,10 @@ func updateRuncFieldsForHostOS(runtime *runc.Runc) {
 }
 
 func (w *runcExecutor) run(ctx context.Context, id, bundle string, process executor.ProcessInfo) error {
-	return w.callWithIO(ctx, id, bundle, process, func(ctx context.Context, started chan<- int, io runc.IO) error {
+	kill := func(ctx context.Context) error {
+		return w.runc.Kill(ctx, id, int(syscall.SIGKILL), nil)
+	}
+	return w.callWithIO(ctx, id, bundle, process, kill, func(ctx context.Context, started chan<- int, io runc.IO) error {
 		_, err := w.runc.Run(ctx, id, bundle, &runc.CreateOpts{
 			NoPivot: w.noPivot,
 			Started: started,
@@ -34,22 +42,58 @@ func (w *runcExecutor) run(ctx context.Context, id, bundle string, process execu
 }
 
 func (w *runcExecutor) exec(ctx context.Context, id, bundle string, specsProcess *specs.Process, process executor.ProcessInfo) error {
-	return w.callWithIO(ctx, id, bundle, process, func(ctx context.Context, started chan<- int, io runc.IO) error {
+	pidFile := filepath.Join(bundle, identity.NewID()+".pid")
+	defer os.Remove(pidFile)
+
+	// runc only forwards the signals it can catch, SIGKILL has to be sent
+	// to the process in the container directly
+	kill := func(ctx context.Context) error {
+		dt, err := ioutil.ReadFile(pidFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read exec pid")
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(dt)))
+		if err != nil {
+			return errors.Wrap(err, "invalid exec pid")
+		}
+		return syscall.Kill(pid, syscall.SIGKILL)
+	}
+	return w.callWithIO(ctx, id, bundle, process, kill, func(ctx context.Context, started chan<- int, io runc.IO) error {
 		return w.runc.Exec(ctx, id, *specsProcess, &runc.ExecOpts{
 			Started: started,
 			IO:      io,
+			PidFile: pidFile,
 		})
 	})
 }
 
 type runcCall func(ctx context.Context, started chan<- int, io runc.IO) error
 
-func (w *runcExecutor) callWithIO(ctx context.Context, id, bundle string, process executor.ProcessInfo, call runcCall) error {
+func (w *runcExecutor) callWithIO(ctx context.Context, id, bundle string, process executor.ProcessInfo, kill func(context.Context) error, call runcCall) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	if !process.Meta.Tty {
-		return call(ctx, nil, &forwardIO{stdin: process.Stdin, stdout: process.Stdout, stderr: process.Stderr})
+		forward := &forwardIO{stdin: process.Stdin, stdout: process.Stdout, stderr: process.Stderr}
+		if process.Signal == nil {
+			return call(ctx, nil, forward)
+		}
+
+		eg, ctx := errgroup.WithContext(ctx)
+		defer func() {
+			cancel() // this will shutdown signal loop
+			if err := eg.Wait(); err != nil {
+				logrus.Warningf("error while shutting down signal handling: %s", err)
+			}
+		}()
+
+		started := make(chan int, 1)
+		eg.Go(func() error {
+			// runc may take a long time to start the process, e.g. when the
+			// container is created, so there is no deadline without a tty
+			return monitorProcess(ctx, started, 0, process, nil, kill)
+		})
+		return call(ctx, started, forward)
 	}
 
 	ptm, ptsName, err := console.NewPty()
@@ -108,41 +152,7 @@ func (w *runcExecutor) callWithIO(ctx context.Context, id, bundle string, proces
 	started := make(chan int, 1)
 
 	eg.Go(func() error {
-		startedCtx, timeout := context.WithTimeout(ctx, 10*time.Second)
-		defer timeout()
-		var runcProcess *os.Process
-		select {
-		case <-startedCtx.Done():
-			return errors.New("runc started message never received")
-		case pid, ok := <-started:
-			if !ok {
-				return errors.New("runc process failed to send pid")
-			}
-			runcProcess, err = os.FindProcess(pid)
-			if err != nil {
-				return errors.Wrapf(err, "unable to find runc process for pid %d", pid)
-			}
-			defer runcProcess.Release()
-		}
-
-		for {
-			select {
-			case <-ctx.Done():
-				return nil
-			case resize := <-process.Resize:
-				err = ptm.Resize(console.WinSize{
-					Height: uint16(resize.Rows),
-					Width:  uint16(resize.Cols),
-				})
-				if err != nil {
-					logrus.Errorf("failed to resize ptm: %s", err)
-				}
-				err = runcProcess.Signal(signal.SIGWINCH)
-				if err != nil {
-					logrus.Errorf("failed to send SIGWINCH to process: %s", err)
-				}
-			}
-		}
+		return monitorProcess(ctx, started, 10*time.Second, process, ptm, kill)
 	})
 
 	runcIO := &forwardIO{}
@@ -158,3 +168,76 @@ func (w *runcExecutor) callWithIO(ctx context.Context, id, bundle string, proces
 
 	return call(ctx, started, runcIO)
 }
+
+// monitorProcess waits for runc to start and then forwards the resize
+// events to ptm and the signals to the runc process, which passes them on to
+// the process in the container. A zero startedTimeout waits for runc to start
+// without a deadline.
+func monitorProcess(ctx context.Context, started <-chan int, startedTimeout time.Duration, process executor.ProcessInfo, ptm console.Console, kill func(context.Context) error) error {
+	startedCtx := ctx
+	if startedTimeout > 0 {
+		var timeout context.CancelFunc
+		startedCtx, timeout = context.WithTimeout(ctx, startedTimeout)
+		defer timeout()
+	}
+	var runcProcess *os.Process
+	select {
+	case <-startedCtx.Done():
+		if ctx.Err() != nil {
+			// runc returned before it started the process
+			return nil
+		}
+		return errors.New("runc started message never received")
+	case pid, ok := <-started:
+		if !ok {
+			return errors.New("runc process failed to send pid")
+		}
+		var err error
+		runcProcess, err = os.FindProcess(pid)
+		if err != nil {
+			return errors.Wrapf(err, "unable to find runc process for pid %d", pid)
+		}
+		defer runcProcess.Release()
+	}
+
+	resize, signals := process.Resize, process.Signal
+	if ptm == nil {
+		resize = nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case size, ok := <-resize:
+			if !ok {
+				resize = nil
+				continue
+			}
+			err := ptm.Resize(console.WinSize{
+				Height: uint16(size.Rows),
+				Width:  uint16(size.Cols),
+			})
+			if err != nil {
+				logrus.Errorf("failed to resize ptm: %s", err)
+			}
+			err = runcProcess.Signal(signal.SIGWINCH)
+			if err != nil {
+				logrus.Errorf("failed to send SIGWINCH to process: %s", err)
+			}
+		case sig, ok := <-signals:
+			if !ok {
+				signals = nil
+				continue
+			}
+			var err error
+			if sig == syscall.SIGKILL {
+				err = kill(ctx)
+			} else {
+				err = runcProcess.Signal(sig)
+			}
+			if err != nil {
+				logrus.Errorf("failed to send %s to process: %s", sig, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package containerimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/platforms"
+	"github.com/moby/buildkit/solver/llbsolver/provenance"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MediaTypeInToto is the media type of in-toto statements stored as
+	// layers of an attestation manifest.
+	MediaTypeInToto = "application/vnd.in-toto+json"
+
+	// PredicateTypeSPDX is the in-toto predicate type of SBOM attestations.
+	PredicateTypeSPDX = "https://spdx.dev/Document"
+
+	inTotoStatementType = "https://in-toto.io/Statement/v0.1"
+
+	// annotations set on the attestation layers and manifest descriptors
+	annotationPredicateType = "in-toto.io/predicate-type"
+	annotationReferenceType = "vnd.docker.reference.type"
+	annotationReferenceDgst = "vnd.docker.reference.digest"
+
+	attestationManifestType = "attestation-manifest"
+)
+
+// inTotoStatement is an in-toto v0.1 statement about an image manifest.
+type inTotoStatement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Subject       []inTotoSubject `json:"subject"`
+	Predicate     interface{}     `json:"predicate"`
+}
+
+type inTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// attestation is a single predicate attached to an image manifest.
+type attestation struct {
+	PredicateType string
+	Predicate     interface{}
+}
+
+// commitAttestations writes the attestation manifest for the target image
+// manifest from the provenance and SBOM metadata of its build result. It
+// returns nil if there is nothing to attach.
+func (ic *ImageWriter) commitAttestations(ctx context.Context, target ocispec.Descriptor, provenanceDt []byte, mode provenance.Mode, sbomDt []byte) (*ocispec.Descriptor, error) {
+	var atts []attestation
+	if mode != "" {
+		att, err := provenanceAttestation(target, provenanceDt, mode)
+		if err != nil {
+			return nil, err
+		}
+		atts = append(atts, *att)
+	}
+	if len(sbomDt) > 0 {
+		sboms, err := sbomAttestations(sbomDt)
+		if err != nil {
+			return nil, err
+		}
+		atts = append(atts, sboms...)
+	}
+	if len(atts) == 0 {
+		return nil, nil
+	}
+	return ic.commitAttestationsManifest(ctx, target, atts)
+}
+
+func provenanceAttestation(target ocispec.Descriptor, dt []byte, mode provenance.Mode) (*attestation, error) {
+	if len(dt) == 0 {
+		return nil, errors.Errorf("no provenance available for %s", target.Digest)
+	}
+	var c provenance.Capture
+	if err := json.Unmarshal(dt, &c); err != nil {
+		return nil, errors.Wrap(err, "failed to parse provenance")
+	}
+	pr, err := provenance.NewPredicate(&c, mode)
+	if err != nil {
+		return nil, err
+	}
+	if target.Platform != nil {
+		pr.Invocation.Environment.Platform = platforms.Format(*target.Platform)
+	}
+	return &attestation{
+		PredicateType: provenance.PredicateType,
+		Predicate:     pr,
+	}, nil
+}
+
+// sbomAttestations converts the SPDX documents generated for a build result
+// to attestations.
+func sbomAttestations(dt []byte) ([]attestation, error) {
+	var docs []json.RawMessage
+	if err := json.Unmarshal(dt, &docs); err != nil {
+		return nil, errors.Wrap(err, "failed to parse sbom")
+	}
+	atts := make([]attestation, 0, len(docs))
+	for _, doc := range docs {
+		atts = append(atts, attestation{
+			PredicateType: PredicateTypeSPDX,
+			Predicate:     doc,
+		})
+	}
+	return atts, nil
+}
+
+// commitAttestationsManifest writes a manifest that contains the in-toto
+// statements for the target image manifest as layers. The returned descriptor
+// is meant to be added to the image index next to the target.
+func (ic *ImageWriter) commitAttestationsManifest(ctx context.Context, target ocispec.Descriptor, atts []attestation) (*ocispec.Descriptor, error) {
+	layers := make([]ocispec.Descriptor, 0, len(atts))
+	for _, att := range atts {
+		stmt := inTotoStatement{
+			Type:          inTotoStatementType,
+			PredicateType: att.PredicateType,
+			Subject: []inTotoSubject{{
+				Name:   "_",
+				Digest: map[string]string{target.Digest.Algorithm().String(): target.Digest.Encoded()},
+			}},
+			Predicate: att.Predicate,
+		}
+		dt, err := json.Marshal(stmt)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal attestation")
+		}
+		desc := ocispec.Descriptor{
+			MediaType: MediaTypeInToto,
+			Digest:    digest.FromBytes(dt),
+			Size:      int64(len(dt)),
+			Annotations: map[string]string{
+				annotationPredicateType: att.PredicateType,
+			},
+		}
+		if err := content.WriteBlob(ctx, ic.opt.ContentStore, desc.Digest.String(), bytes.NewReader(dt), desc); err != nil {
+			return nil, errors.Wrapf(err, "error writing attestation blob %s", desc.Digest)
+		}
+		layers = append(layers, desc)
+	}
+
+	config, err := json.Marshal(ocispec.Image{
+		Architecture: "unknown",
+		OS:           "unknown",
+		RootFS: ocispec.RootFS{
+			Type: "layers",
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal attestation config")
+	}
+	configDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageConfig,
+		Digest:    digest.FromBytes(config),
+		Size:      int64(len(config)),
+	}
+	if err := content.WriteBlob(ctx, ic.opt.ContentStore, configDesc.Digest.String(), bytes.NewReader(config), configDesc); err != nil {
+		return nil, errors.Wrap(err, "error writing attestation config blob")
+	}
+
+	mfst := struct {
+		// MediaType is reserved in the OCI spec but
+		// excluded from go types.
+		MediaType string `json:"mediaType,omitempty"`
+
+		ocispec.Manifest
+	}{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Manifest: ocispec.Manifest{
+			Versioned: specs.Versioned{
+				SchemaVersion: 2,
+			},
+			Config: configDesc,
+			Layers: layers,
+		},
+	}
+
+	labels := map[string]string{
+		"containerd.io/gc.ref.content.0": configDesc.Digest.String(),
+	}
+	for i, desc := range layers {
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", i+1)] = desc.Digest.String()
+	}
+
+	mfstJSON, err := json.MarshalIndent(mfst, "", "   ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal attestation manifest")
+	}
+	mfstDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(mfstJSON),
+		Size:      int64(len(mfstJSON)),
+	}
+	mfstDone := oneOffProgress(ctx, "exporting attestation manifest "+mfstDesc.Digest.String())
+	if err := content.WriteBlob(ctx, ic.opt.ContentStore, mfstDesc.Digest.String(), bytes.NewReader(mfstJSON), mfstDesc, content.WithLabels(labels)); err != nil {
+		return nil, mfstDone(errors.Wrapf(err, "error writing attestation manifest blob %s", mfstDesc.Digest))
+	}
+	mfstDone(nil)
+
+	mfstDesc.Platform = &ocispec.Platform{
+		Architecture: "unknown",
+		OS:           "unknown",
+	}
+	mfstDesc.Annotations = map[string]string{
+		annotationReferenceType: attestationManifestType,
+		annotationReferenceDgst: target.Digest.String(),
+	}
+	return &mfstDesc, nil
+}
+
+func platformFromConfig(dt []byte) (ocispec.Platform, error) {
+	if len(dt) == 0 {
+		return platforms.Normalize(platforms.DefaultSpec()), nil
+	}
+	var img struct {
+		ocispec.Image
+
+		// Variant defines platform variant. To be added to OCI.
+		Variant string `json:"variant,omitempty"`
+	}
+	if err := json.Unmarshal(dt, &img); err != nil {
+		return ocispec.Platform{}, errors.Wrap(err, "failed to parse image config")
+	}
+	if img.OS == "" || img.Architecture == "" {
+		return platforms.Normalize(platforms.DefaultSpec()), nil
+	}
+	return platforms.Normalize(ocispec.Platform{
+		OS:           img.OS,
+		Architecture: img.Architecture,
+		Variant:      img.Variant,
+	}), nil
+}
@@ -2,6 +2,7 @@ package containerimage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -16,8 +17,10 @@ import (
 	"github.com/containerd/containerd/rootfs"
 	"github.com/moby/buildkit/cache"
 	"github.com/moby/buildkit/exporter"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/snapshot"
+	"github.com/moby/buildkit/solver/llbsolver/provenance"
 	"github.com/moby/buildkit/util/compression"
 	"github.com/moby/buildkit/util/contentutil"
 	"github.com/moby/buildkit/util/leaseutil"
@@ -26,6 +29,7 @@ import (
 	"github.com/opencontainers/image-spec/identity"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -37,6 +41,8 @@ const (
 	keyDanglingPrefix   = "dangling-name-prefix"
 	keyNameCanonical    = "name-canonical"
 	keyLayerCompression = "compression"
+	keyProvenance       = "provenance"
+	keySBOMGenerator    = "sbom-generator"
 	ociTypes            = "oci-mediatypes"
 )
 
@@ -61,7 +67,7 @@ func New(opt Opt) (exporter.Exporter, error) {
 	return im, nil
 }
 
-func (e *imageExporter) Resolve(ctx context.Context, opt map[string]string) (exporter.ExporterInstance, error) {
+func (e *imageExporter) Resolve(ctx context.Context, id int, opt map[string]string) (exporter.ExporterInstance, error) {
 	i := &imageExporterInstance{
 		imageExporter:    e,
 		layerCompression: compression.Default,
@@ -142,6 +148,14 @@ func (e *imageExporter) Resolve(ctx context.Context, opt map[string]string) (exp
 			default:
 				return nil, errors.Errorf("unsupported layer compression type: %v", v)
 			}
+		case keyProvenance:
+			mode, err := provenance.ParseMode(v)
+			if err != nil {
+				return nil, err
+			}
+			i.provenanceMode = mode
+		case keySBOMGenerator:
+			i.sbomGenerator = v
 		default:
 			if i.meta == nil {
 				i.meta = make(map[string][]byte)
@@ -149,6 +163,11 @@ func (e *imageExporter) Resolve(ctx context.Context, opt map[string]string) (exp
 			i.meta[k] = []byte(v)
 		}
 	}
+	if (i.provenanceMode != "" || i.sbomGenerator != "") && !i.ociTypes {
+		// attestation manifests can only be referenced from an OCI index
+		logrus.Warn("forcing oci-mediatypes for exporting attestations")
+		i.ociTypes = true
+	}
 	return i, nil
 }
 
@@ -163,6 +182,8 @@ type imageExporterInstance struct {
 	nameCanonical    bool
 	danglingPrefix   string
 	layerCompression compression.Type
+	provenanceMode   provenance.Mode
+	sbomGenerator    string
 	meta             map[string][]byte
 }
 
@@ -170,6 +191,18 @@ func (e *imageExporterInstance) Name() string {
 	return "exporting to image"
 }
 
+// ProvenanceMode returns the mode of the provenance attestation attached to
+// the exported image, empty if no provenance was requested.
+func (e *imageExporterInstance) ProvenanceMode() provenance.Mode {
+	return e.provenanceMode
+}
+
+// SBOMGenerator returns the scanner image that the solver runs over the
+// build result to generate the SBOM attached to the exported image.
+func (e *imageExporterInstance) SBOMGenerator() string {
+	return e.sbomGenerator
+}
+
 func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source, sessionID string) (map[string]string, error) {
 	if src.Metadata == nil {
 		src.Metadata = make(map[string][]byte)
@@ -177,6 +210,9 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 	for k, v := range e.meta {
 		src.Metadata[k] = v
 	}
+	// attachments passed as exporter attributes override the ones of the
+	// result
+	src.Attachments = src.Attachments.Merge(exptypes.AttachmentsFromMetadata(e.meta, nil))
 
 	ctx, done, err := leaseutil.WithLease(ctx, e.opt.LeaseManager, leaseutil.MakeTemporary)
 	if err != nil {
@@ -184,7 +220,7 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 	}
 	defer done(context.TODO())
 
-	desc, err := e.opt.ImageWriter.Commit(ctx, src, e.ociTypes, e.layerCompression, sessionID)
+	desc, err := e.opt.ImageWriter.Commit(ctx, src, e.ociTypes, e.layerCompression, e.provenanceMode, sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -238,36 +274,17 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 					}
 				}
 			}
-			if e.push {
-				annotations := map[digest.Digest]map[string]string{}
-				mprovider := contentutil.NewMultiProvider(e.opt.ImageWriter.ContentStore())
-				if src.Ref != nil {
-					remote, err := src.Ref.GetRemote(ctx, false, e.layerCompression, session.NewGroup(sessionID))
-					if err != nil {
-						return nil, err
-					}
-					for _, desc := range remote.Descriptors {
-						mprovider.Add(desc.Digest, remote.Provider)
-						addAnnotations(annotations, desc)
-					}
-				}
-				if len(src.Refs) > 0 {
-					for _, r := range src.Refs {
-						remote, err := r.GetRemote(ctx, false, e.layerCompression, session.NewGroup(sessionID))
-						if err != nil {
-							return nil, err
-						}
-						for _, desc := range remote.Descriptors {
-							mprovider.Add(desc.Digest, remote.Provider)
-							addAnnotations(annotations, desc)
-						}
-					}
-				}
-
-				if err := push.Push(ctx, e.opt.SessionManager, sessionID, mprovider, e.opt.ImageWriter.ContentStore(), desc.Digest, targetName, e.insecure, e.opt.RegistryHosts, e.pushByDigest, annotations); err != nil {
-					return nil, err
-				}
+		}
+		if e.push {
+			results, err := e.pushImage(ctx, src, sessionID, desc.Digest, targetNames)
+			if err != nil {
+				return nil, err
+			}
+			dt, err := json.Marshal(results)
+			if err != nil {
+				return nil, err
 			}
+			resp[exptypes.ExporterImagePushKey] = string(dt)
 		}
 		resp["image.name"] = e.targetName
 	}
@@ -276,6 +293,42 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 	return resp, nil
 }
 
+// pushImage pushes the image to all names and returns the result for each of
+// them. Every name is pushed even if an earlier push failed, the returned
+// error lists all the names that failed.
+func (e *imageExporterInstance) pushImage(ctx context.Context, src exporter.Source, sessionID string, dgst digest.Digest, names []string) ([]push.Result, error) {
+	annotations := map[digest.Digest]map[string]string{}
+	mprovider := contentutil.NewMultiProvider(e.opt.ImageWriter.ContentStore())
+	if src.Ref != nil {
+		remote, err := src.Ref.GetRemote(ctx, false, e.layerCompression, session.NewGroup(sessionID))
+		if err != nil {
+			return nil, err
+		}
+		for _, desc := range remote.Descriptors {
+			mprovider.Add(desc.Digest, remote.Provider)
+			addAnnotations(annotations, desc)
+		}
+	}
+	if len(src.Refs) > 0 {
+		for _, r := range src.Refs {
+			remote, err := r.GetRemote(ctx, false, e.layerCompression, session.NewGroup(sessionID))
+			if err != nil {
+				return nil, err
+			}
+			for _, desc := range remote.Descriptors {
+				mprovider.Add(desc.Digest, remote.Provider)
+				addAnnotations(annotations, desc)
+			}
+		}
+	}
+
+	results := push.PushAll(ctx, e.opt.SessionManager, sessionID, mprovider, e.opt.ImageWriter.ContentStore(), dgst, names, e.insecure, e.opt.RegistryHosts, e.pushByDigest, annotations)
+	if err := push.ResultsError(results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func (e *imageExporterInstance) unpackImage(ctx context.Context, img images.Image, src exporter.Source, s session.Group) (err0 error) {
 	unpackDone := oneOffProgress(ctx, "unpacking to "+img.Name)
 	defer func() {
@@ -0,0 +1,148 @@
+package exptypes
+
+import "strings"
+
+// AttachmentType identifies the kind of data attached to a ref of a result.
+// The types that used to be passed as metadata keys use the same names as
+// those keys.
+type AttachmentType string
+
+const (
+	// AttachmentImageConfig is the JSON image config of the ref.
+	AttachmentImageConfig AttachmentType = ExporterImageConfigKey
+	// AttachmentInlineCache is the inline cache metadata of the ref.
+	AttachmentInlineCache AttachmentType = ExporterInlineCache
+	// AttachmentProvenance is the provenance attestation of the ref.
+	AttachmentProvenance AttachmentType = ExporterProvenanceKey
+	// AttachmentSBOM is the SBOM attestation of the ref.
+	AttachmentSBOM AttachmentType = ExporterSBOMKey
+	// AttachmentSBOMStages lists the build stages to scan for the SBOM of
+	// the ref.
+	AttachmentSBOMStages AttachmentType = ExporterSBOMStagesKey
+	// AttachmentAnnotation is an annotation of the image manifest of the ref.
+	// The name of the attachment is the annotation key.
+	AttachmentAnnotation AttachmentType = "containerimage.annotation"
+)
+
+// legacyAttachmentTypes are the attachment types that frontends without
+// attachments support pass as <type> or <type>/<ref key> metadata keys.
+var legacyAttachmentTypes = []AttachmentType{
+	AttachmentImageConfig,
+	AttachmentInlineCache,
+	AttachmentProvenance,
+	AttachmentSBOM,
+	AttachmentSBOMStages,
+}
+
+// Attachment is typed data attached to a ref of a result.
+type Attachment struct {
+	Type AttachmentType
+	Name string
+	Data []byte
+}
+
+// Attachments holds the attachments of the refs of a result by ref key. The
+// attachments of the default ref of a result use the empty key.
+type Attachments map[string][]Attachment
+
+// Get returns the data of the first attachment of type t for the ref key.
+func (a Attachments) Get(key string, t AttachmentType) ([]byte, bool) {
+	for _, att := range a[key] {
+		if att.Type == t {
+			return att.Data, true
+		}
+	}
+	return nil, false
+}
+
+// Set adds att to the attachments of the ref key, replacing the attachment
+// with the same type and name.
+func (a Attachments) Set(key string, att Attachment) {
+	atts := a[key][:0:0]
+	for _, existing := range a[key] {
+		if existing.Type != att.Type || existing.Name != att.Name {
+			atts = append(atts, existing)
+		}
+	}
+	a[key] = append(atts, att)
+}
+
+// Clone returns a copy of the attachments that can be modified without
+// affecting a.
+func (a Attachments) Clone() Attachments {
+	out := make(Attachments, len(a))
+	for k, atts := range a {
+		out[k] = append([]Attachment(nil), atts...)
+	}
+	return out
+}
+
+// Merge returns a copy of a with the attachments in b replacing the ones with
+// the same type and name for the same ref key.
+func (a Attachments) Merge(b Attachments) Attachments {
+	out := a.Clone()
+	for k, atts := range b {
+		for _, att := range atts {
+			out.Set(k, att)
+		}
+	}
+	return out
+}
+
+// AttachmentsFromMetadata returns the attachments of a result with metadata
+// md and attachments atts, adding the attachments that older frontends pass
+// as metadata keys. Attachments take precedence over metadata.
+func AttachmentsFromMetadata(md map[string][]byte, atts Attachments) Attachments {
+	out := atts.Clone()
+	for k, v := range md {
+		for _, t := range legacyAttachmentTypes {
+			key, ok := legacyRefKey(k, t)
+			if !ok {
+				continue
+			}
+			if _, ok := out.Get(key, t); !ok {
+				out[key] = append(out[key], Attachment{Type: t, Data: v})
+			}
+		}
+	}
+	return out
+}
+
+// MetadataFromAttachments returns the metadata keys that older daemons
+// without attachments support expect for atts. Attachment types that have
+// no metadata key are skipped.
+func MetadataFromAttachments(atts Attachments) map[string][]byte {
+	md := map[string][]byte{}
+	for key, refAtts := range atts {
+		for _, att := range refAtts {
+			if !isLegacyAttachmentType(att.Type) {
+				continue
+			}
+			k := string(att.Type)
+			if key != "" {
+				k += "/" + key
+			}
+			md[k] = att.Data
+		}
+	}
+	return md
+}
+
+func legacyRefKey(k string, t AttachmentType) (string, bool) {
+	if k == string(t) {
+		return "", true
+	}
+	if strings.HasPrefix(k, string(t)+"/") {
+		return strings.TrimPrefix(k, string(t)+"/"), true
+	}
+	return "", false
+}
+
+func isLegacyAttachmentType(t AttachmentType) bool {
+	for _, lt := range legacyAttachmentTypes {
+		if t == lt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package exptypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentsFromMetadata(t *testing.T) {
+	md := map[string][]byte{
+		ExporterImageConfigKey:                   []byte("config"),
+		ExporterImageConfigKey + "/linux/arm64":  []byte("config-arm64"),
+		ExporterSBOMStagesKey + "/linux/arm64":   []byte("stages-arm64"),
+		ExporterInlineCache + "/linux/amd64":     []byte("cache-amd64"),
+		ExporterPlatformsKey:                     []byte("platforms"),
+		"containerimage.config.unrelated/foobar": []byte("foobar"),
+	}
+	atts := Attachments{
+		"linux/arm64": {{Type: AttachmentImageConfig, Data: []byte("attached-arm64")}},
+	}
+
+	out := AttachmentsFromMetadata(md, atts)
+
+	dt, ok := out.Get("", AttachmentImageConfig)
+	require.True(t, ok)
+	require.Equal(t, "config", string(dt))
+
+	// attachments take precedence over metadata
+	dt, ok = out.Get("linux/arm64", AttachmentImageConfig)
+	require.True(t, ok)
+	require.Equal(t, "attached-arm64", string(dt))
+
+	dt, ok = out.Get("linux/arm64", AttachmentSBOMStages)
+	require.True(t, ok)
+	require.Equal(t, "stages-arm64", string(dt))
+
+	dt, ok = out.Get("linux/amd64", AttachmentInlineCache)
+	require.True(t, ok)
+	require.Equal(t, "cache-amd64", string(dt))
+
+	_, ok = out.Get("linux/amd64", AttachmentSBOM)
+	require.False(t, ok)
+	require.Equal(t, 3, len(out))
+
+	// the input attachments are not modified
+	require.Equal(t, 1, len(atts["linux/arm64"]))
+}
+
+func TestMetadataFromAttachments(t *testing.T) {
+	atts := Attachments{
+		"": {
+			{Type: AttachmentImageConfig, Data: []byte("config")},
+			{Type: AttachmentAnnotation, Name: "org.opencontainers.image.title", Data: []byte("title")},
+		},
+		"linux/arm64": {{Type: AttachmentSBOMStages, Data: []byte("stages")}},
+	}
+	require.Equal(t, map[string][]byte{
+		ExporterImageConfigKey:                 []byte("config"),
+		ExporterSBOMStagesKey + "/linux/arm64": []byte("stages"),
+	}, MetadataFromAttachments(atts))
+}
+
+func TestAttachmentsMerge(t *testing.T) {
+	a := Attachments{
+		"": {
+			{Type: AttachmentImageConfig, Data: []byte("config")},
+			{Type: AttachmentAnnotation, Name: "foo", Data: []byte("foo")},
+			{Type: AttachmentAnnotation, Name: "bar", Data: []byte("bar")},
+		},
+	}
+	out := a.Merge(Attachments{
+		"": {
+			{Type: AttachmentImageConfig, Data: []byte("override")},
+			{Type: AttachmentAnnotation, Name: "bar", Data: []byte("baz")},
+		},
+	})
+	require.Equal(t, []Attachment{
+		{Type: AttachmentAnnotation, Name: "foo", Data: []byte("foo")},
+		{Type: AttachmentImageConfig, Data: []byte("override")},
+		{Type: AttachmentAnnotation, Name: "bar", Data: []byte("baz")},
+	}, out[""])
+	dt, _ := a.Get("", AttachmentImageConfig)
+	require.Equal(t, "config", string(dt))
+}
@@ -6,8 +6,13 @@ import (
 )
 
 const ExporterImageConfigKey = "containerimage.config"
+const ExporterImageDescriptorKey = "containerimage.descriptor"
+const ExporterImagePushKey = "containerimage.push"
 const ExporterInlineCache = "containerimage.inlinecache"
 const ExporterPlatformsKey = "refs.platforms"
+const ExporterProvenanceKey = "containerimage.provenance"
+const ExporterSBOMKey = "containerimage.sbom"
+const ExporterSBOMStagesKey = "containerimage.sbom.stages"
 
 const EmptyGZLayer = digest.Digest("sha256:4f4fb700ef54461cfa02571ae0db9a0dc1e0cdb5577484a6d75e68dc38e8acc1")
 
@@ -18,6 +18,7 @@ import (
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/snapshot"
 	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/llbsolver/provenance"
 	"github.com/moby/buildkit/util/compression"
 	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/util/system"
@@ -44,7 +45,11 @@ type ImageWriter struct {
 	opt WriterOpt
 }
 
-func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool, compressionType compression.Type, sessionID string) (*ocispec.Descriptor, error) {
+// Commit writes the image for the exported source to the content store and
+// returns the descriptor of its root manifest or manifest list. If
+// provenanceMode is set or an SBOM was generated for the source, an
+// attestation manifest for each platform image is added to the manifest list.
+func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool, compressionType compression.Type, provenanceMode provenance.Mode, sessionID string) (*ocispec.Descriptor, error) {
 	platformsBytes, ok := inp.Metadata[exptypes.ExporterPlatformsKey]
 
 	if len(inp.Refs) > 0 && !ok {
@@ -56,7 +61,28 @@ func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool
 		if err != nil {
 			return nil, err
 		}
-		return ic.commitDistributionManifest(ctx, inp.Ref, inp.Metadata[exptypes.ExporterImageConfigKey], &remotes[0], oci, inp.Metadata[exptypes.ExporterInlineCache])
+		config, _ := inp.Attachments.Get("", exptypes.AttachmentImageConfig)
+		inlineCache, _ := inp.Attachments.Get("", exptypes.AttachmentInlineCache)
+		mfstDesc, err := ic.commitDistributionManifest(ctx, inp.Ref, config, &remotes[0], oci, inlineCache, annotations(inp.Attachments[""]))
+		if err != nil {
+			return nil, err
+		}
+		sbom, _ := inp.Attachments.Get("", exptypes.AttachmentSBOM)
+		if provenanceMode == "" && len(sbom) == 0 {
+			return mfstDesc, nil
+		}
+
+		p, err := platformFromConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		mfstDesc.Platform = &p
+		provenance, _ := inp.Attachments.Get("", exptypes.AttachmentProvenance)
+		attDesc, err := ic.commitAttestations(ctx, *mfstDesc, provenance, provenanceMode, sbom)
+		if err != nil {
+			return nil, err
+		}
+		return ic.commitIndex(ctx, []ocispec.Descriptor{*mfstDesc, *attDesc}, oci)
 	}
 
 	var p exptypes.Platforms
@@ -80,6 +106,39 @@ func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool
 		return nil, err
 	}
 
+	var manifests, attestations []ocispec.Descriptor
+
+	for _, p := range p.Platforms {
+		r, ok := inp.Refs[p.ID]
+		if !ok {
+			return nil, errors.Errorf("failed to find ref for ID %s", p.ID)
+		}
+		config, _ := inp.Attachments.Get(p.ID, exptypes.AttachmentImageConfig)
+		inlineCache, _ := inp.Attachments.Get(p.ID, exptypes.AttachmentInlineCache)
+
+		desc, err := ic.commitDistributionManifest(ctx, r, config, &remotes[remotesMap[p.ID]], oci, inlineCache, annotations(inp.Attachments[p.ID]))
+		if err != nil {
+			return nil, err
+		}
+		dp := p.Platform
+		desc.Platform = &dp
+		manifests = append(manifests, *desc)
+
+		provenance, _ := inp.Attachments.Get(p.ID, exptypes.AttachmentProvenance)
+		sbom, _ := inp.Attachments.Get(p.ID, exptypes.AttachmentSBOM)
+		attDesc, err := ic.commitAttestations(ctx, *desc, provenance, provenanceMode, sbom)
+		if err != nil {
+			return nil, err
+		}
+		if attDesc != nil {
+			attestations = append(attestations, *attDesc)
+		}
+	}
+
+	return ic.commitIndex(ctx, append(manifests, attestations...), oci)
+}
+
+func (ic *ImageWriter) commitIndex(ctx context.Context, manifests []ocispec.Descriptor, oci bool) (*ocispec.Descriptor, error) {
 	idx := struct {
 		// MediaType is reserved in the OCI spec but
 		// excluded from go types.
@@ -92,6 +151,7 @@ func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool
 			Versioned: specs.Versioned{
 				SchemaVersion: 2,
 			},
+			Manifests: manifests,
 		},
 	}
 
@@ -100,22 +160,7 @@ func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool
 	}
 
 	labels := map[string]string{}
-
-	for i, p := range p.Platforms {
-		r, ok := inp.Refs[p.ID]
-		if !ok {
-			return nil, errors.Errorf("failed to find ref for ID %s", p.ID)
-		}
-		config := inp.Metadata[fmt.Sprintf("%s/%s", exptypes.ExporterImageConfigKey, p.ID)]
-
-		desc, err := ic.commitDistributionManifest(ctx, r, config, &remotes[remotesMap[p.ID]], oci, inp.Metadata[fmt.Sprintf("%s/%s", exptypes.ExporterInlineCache, p.ID)])
-		if err != nil {
-			return nil, err
-		}
-		dp := p.Platform
-		desc.Platform = &dp
-		idx.Manifests = append(idx.Manifests, *desc)
-
+	for i, desc := range manifests {
 		labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", i)] = desc.Digest.String()
 	}
 
@@ -169,7 +214,11 @@ func (ic *ImageWriter) exportLayers(ctx context.Context, compressionType compres
 	return out, nil
 }
 
-func (ic *ImageWriter) commitDistributionManifest(ctx context.Context, ref cache.ImmutableRef, config []byte, remote *solver.Remote, oci bool, inlineCache []byte) (*ocispec.Descriptor, error) {
+func (ic *ImageWriter) commitDistributionManifest(ctx context.Context, ref cache.ImmutableRef, config []byte, remote *solver.Remote, oci bool, inlineCache []byte, annotations map[string]string) (*ocispec.Descriptor, error) {
+	if len(annotations) > 0 && !oci {
+		return nil, errors.Errorf("manifest annotations require OCI media types")
+	}
+
 	if len(config) == 0 {
 		var err error
 		config, err = emptyImageConfig()
@@ -220,6 +269,7 @@ func (ic *ImageWriter) commitDistributionManifest(ctx context.Context, ref cache
 			Versioned: specs.Versioned{
 				SchemaVersion: 2,
 			},
+			Annotations: annotations,
 			Config: ocispec.Descriptor{
 				Digest:    configDigest,
 				Size:      int64(len(config)),
@@ -297,6 +347,21 @@ func (ic *ImageWriter) Applier() diff.Applier {
 	return ic.opt.Applier
 }
 
+// annotations returns the manifest annotations attached to a ref.
+func annotations(atts []exptypes.Attachment) map[string]string {
+	var m map[string]string
+	for _, att := range atts {
+		if att.Type != exptypes.AttachmentAnnotation {
+			continue
+		}
+		if m == nil {
+			m = map[string]string{}
+		}
+		m[att.Name] = string(att.Data)
+	}
+	return m
+}
+
 func emptyImageConfig() ([]byte, error) {
 	pl := platforms.Normalize(platforms.DefaultSpec())
 
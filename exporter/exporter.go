@@ -4,10 +4,14 @@ import (
 	"context"
 
 	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 )
 
 type Exporter interface {
-	Resolve(context.Context, map[string]string) (ExporterInstance, error)
+	// Resolve returns an instance of the exporter configured with attrs. id
+	// is the index of the exporter in the solve request and identifies the
+	// destination of the exporter in the client session.
+	Resolve(ctx context.Context, id int, attrs map[string]string) (ExporterInstance, error)
 }
 
 type ExporterInstance interface {
@@ -19,4 +23,7 @@ type Source struct {
 	Ref      cache.ImmutableRef
 	Refs     map[string]cache.ImmutableRef
 	Metadata map[string][]byte
+	// Attachments holds typed data for the refs by ref key, the empty key
+	// for Ref.
+	Attachments exptypes.Attachments
 }
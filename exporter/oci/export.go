@@ -2,6 +2,7 @@ package oci
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
 	"strings"
 	"time"
@@ -12,7 +13,9 @@ import (
 	"github.com/moby/buildkit/cache"
 	"github.com/moby/buildkit/exporter"
 	"github.com/moby/buildkit/exporter/containerimage"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/session"
+	sessioncontent "github.com/moby/buildkit/session/content"
 	"github.com/moby/buildkit/session/filesync"
 	"github.com/moby/buildkit/util/compression"
 	"github.com/moby/buildkit/util/contentutil"
@@ -29,9 +32,16 @@ type ExporterVariant string
 const (
 	keyImageName        = "name"
 	keyLayerCompression = "compression"
+	keyTar              = "tar"
+	keyTag              = "tag"
 	VariantOCI          = "oci"
 	VariantDocker       = "docker"
 	ociTypes            = "oci-mediatypes"
+
+	// contentStoreIDPrefix is the prefix of the ID of the session content
+	// store that the exporter writes an OCI layout directory to. The suffix is
+	// the ID of the exporter.
+	contentStoreIDPrefix = "oci:"
 )
 
 type Opt struct {
@@ -50,10 +60,12 @@ func New(opt Opt) (exporter.Exporter, error) {
 	return im, nil
 }
 
-func (e *imageExporter) Resolve(ctx context.Context, opt map[string]string) (exporter.ExporterInstance, error) {
+func (e *imageExporter) Resolve(ctx context.Context, id int, opt map[string]string) (exporter.ExporterInstance, error) {
 	var ot *bool
 	i := &imageExporterInstance{
 		imageExporter:    e,
+		id:               id,
+		tar:              true,
 		layerCompression: compression.Default,
 	}
 	for k, v := range opt {
@@ -80,6 +92,18 @@ func (e *imageExporter) Resolve(ctx context.Context, opt map[string]string) (exp
 				return nil, errors.Wrapf(err, "non-bool value specified for %s", k)
 			}
 			*ot = b
+		case keyTar:
+			if v == "" {
+				i.tar = true
+				continue
+			}
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "non-bool value specified for %s", k)
+			}
+			i.tar = b
+		case keyTag:
+			// only used by the client for updating index.json of the layout
 		default:
 			if i.meta == nil {
 				i.meta = make(map[string][]byte)
@@ -87,6 +111,9 @@ func (e *imageExporter) Resolve(ctx context.Context, opt map[string]string) (exp
 			i.meta[k] = []byte(v)
 		}
 	}
+	if !i.tar && e.opt.Variant != VariantOCI {
+		return nil, errors.Errorf("%s=false is only supported by the oci exporter", keyTar)
+	}
 	if ot == nil {
 		i.ociTypes = e.opt.Variant == VariantOCI
 	} else {
@@ -97,6 +124,8 @@ func (e *imageExporter) Resolve(ctx context.Context, opt map[string]string) (exp
 
 type imageExporterInstance struct {
 	*imageExporter
+	id               int
+	tar              bool
 	meta             map[string][]byte
 	name             string
 	ociTypes         bool
@@ -118,6 +147,9 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 	for k, v := range e.meta {
 		src.Metadata[k] = v
 	}
+	// attachments passed as exporter attributes override the ones of the
+	// result
+	src.Attachments = src.Attachments.Merge(exptypes.AttachmentsFromMetadata(e.meta, nil))
 
 	ctx, done, err := leaseutil.WithLease(ctx, e.opt.LeaseManager, leaseutil.MakeTemporary)
 	if err != nil {
@@ -125,7 +157,7 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 	}
 	defer done(context.TODO())
 
-	desc, err := e.opt.ImageWriter.Commit(ctx, src, e.ociTypes, e.layerCompression, sessionID)
+	desc, err := e.opt.ImageWriter.Commit(ctx, src, e.ociTypes, e.layerCompression, "", sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -162,19 +194,6 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 		return nil, errors.Errorf("invalid variant %q", e.opt.Variant)
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	caller, err := e.opt.SessionManager.Get(timeoutCtx, sessionID, false)
-	if err != nil {
-		return nil, err
-	}
-
-	w, err := filesync.CopyFileWriter(ctx, resp, caller)
-	if err != nil {
-		return nil, err
-	}
-
 	mprovider := contentutil.NewMultiProvider(e.opt.ImageWriter.ContentStore())
 	if src.Ref != nil {
 		remote, err := src.Ref.GetRemote(ctx, false, e.layerCompression, session.NewGroup(sessionID))
@@ -209,6 +228,34 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 		}
 	}
 
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	caller, err := e.opt.SessionManager.Get(timeoutCtx, sessionID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !e.tar {
+		dt, err := json.Marshal(desc)
+		if err != nil {
+			return nil, err
+		}
+		resp[exptypes.ExporterImageDescriptorKey] = string(dt)
+
+		store := sessioncontent.NewCallerStore(caller, contentStoreIDPrefix+strconv.Itoa(e.id))
+		report := oneOffProgress(ctx, "sending image layout")
+		if err := contentutil.CopyChain(ctx, store, mprovider, *desc); err != nil {
+			return nil, report(err)
+		}
+		return resp, report(nil)
+	}
+
+	w, err := filesync.CopyFileWriter(ctx, resp, e.id, caller)
+	if err != nil {
+		return nil, err
+	}
+
 	report := oneOffProgress(ctx, "sending tarball")
 	if err := archiveexporter.Export(ctx, mprovider, w, expOpts...); err != nil {
 		w.Close()
@@ -32,13 +32,14 @@ func New(opt Opt) (exporter.Exporter, error) {
 	return le, nil
 }
 
-func (e *localExporter) Resolve(ctx context.Context, opt map[string]string) (exporter.ExporterInstance, error) {
-	li := &localExporterInstance{localExporter: e}
+func (e *localExporter) Resolve(ctx context.Context, id int, opt map[string]string) (exporter.ExporterInstance, error) {
+	li := &localExporterInstance{localExporter: e, id: id}
 	return li, nil
 }
 
 type localExporterInstance struct {
 	*localExporter
+	id int
 }
 
 func (e *localExporterInstance) Name() string {
@@ -140,7 +141,7 @@ func (e *localExporterInstance) Export(ctx context.Context, inp exporter.Source,
 		return nil, err
 	}
 
-	w, err := filesync.CopyFileWriter(ctx, nil, caller)
+	w, err := filesync.CopyFileWriter(ctx, nil, e.id, caller)
 	if err != nil {
 		return nil, err
 	}
@@ -6,7 +6,6 @@ import (
 	"context"
 	"encoding/csv"
 	"encoding/json"
-	"fmt"
 	"net"
 	"path"
 	"regexp"
@@ -19,6 +18,7 @@ import (
 	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/frontend/dockerfile/dockerfile2llb"
 	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/gateway/client"
 	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
@@ -43,8 +43,6 @@ const (
 	buildArgPrefix             = "build-arg:"
 	labelPrefix                = "label:"
 	keyNoCache                 = "no-cache"
-	keyTargetPlatform          = "platform"
-	keyMultiPlatform           = "multi-platform"
 	keyImageResolveMode        = "image-resolve-mode"
 	keyGlobalAddHosts          = "add-hosts"
 	keyForceNetwork            = "force-network-mode"
@@ -90,13 +88,9 @@ func Build(ctx context.Context, c client.Client) (*client.Result, error) {
 	}
 
 	buildPlatforms := []specs.Platform{defaultBuildPlatform}
-	targetPlatforms := []*specs.Platform{nil}
-	if v := opts[keyTargetPlatform]; v != "" {
-		var err error
-		targetPlatforms, err = parsePlatforms(v)
-		if err != nil {
-			return nil, err
-		}
+	_, exportMap, err := client.TargetPlatforms(opts)
+	if err != nil {
+		return nil, err
 	}
 
 	resolveMode, err := parseResolveMode(opts[keyImageResolveMode])
@@ -363,149 +357,127 @@ func Build(ctx context.Context, c client.Client) (*client.Result, error) {
 		return nil, capsError
 	}
 
-	if res, ok, err := checkSubRequest(ctx, opts); ok {
+	if res, ok, err := checkSubRequest(ctx, c, dtDockerfile, filename); ok {
 		return res, err
 	}
 
-	exportMap := len(targetPlatforms) > 1
-
-	if v := opts[keyMultiPlatform]; v != "" {
-		b, err := strconv.ParseBool(v)
-		if err != nil {
-			return nil, errors.Errorf("invalid boolean value %s", v)
-		}
-		if !b && exportMap {
-			return nil, errors.Errorf("returning multiple target plaforms is not allowed")
-		}
-		exportMap = b
-	}
-
-	expPlatforms := &exptypes.Platforms{
-		Platforms: make([]exptypes.Platform, len(targetPlatforms)),
-	}
-	res := client.NewResult()
-
-	eg, ctx = errgroup.WithContext(ctx)
-
-	for i, tp := range targetPlatforms {
-		func(i int, tp *specs.Platform) {
-			eg.Go(func() (err error) {
-				defer func() {
-					var el *parser.ErrorLocation
-					if errors.As(err, &el) {
-						err = wrapSource(err, sourceMap, el.Location)
-					}
-				}()
-				st, img, err := dockerfile2llb.Dockerfile2LLB(ctx, dtDockerfile, dockerfile2llb.ConvertOpt{
-					Target:            opts[keyTarget],
-					MetaResolver:      c,
-					BuildArgs:         filter(opts, buildArgPrefix),
-					Labels:            filter(opts, labelPrefix),
-					CacheIDNamespace:  opts[keyCacheNS],
-					SessionID:         c.BuildOpts().SessionID,
-					BuildContext:      buildContext,
-					Excludes:          excludes,
-					IgnoreCache:       ignoreCache,
-					TargetPlatform:    tp,
-					BuildPlatforms:    buildPlatforms,
-					ImageResolveMode:  resolveMode,
-					PrefixPlatform:    exportMap,
-					ExtraHosts:        extraHosts,
-					ForceNetMode:      defaultNetMode,
-					OverrideCopyImage: opts[keyOverrideCopyImage],
-					LLBCaps:           &caps,
-					SourceMap:         sourceMap,
-					Hostname:          opts[keyHostname],
-				})
-
-				if err != nil {
-					return errors.Wrapf(err, "failed to create LLB definition")
-				}
+	lintCfg, lintEnabled, err := lintConfig(dtDockerfile, opts, sourceMap)
+	if err != nil {
+		return nil, err
+	}
+	var lintFunc func([]linter.Warning) error
+	if lintEnabled {
+		lintFunc = newLint(ctx, c, lintCfg, sourceMap)
+	}
 
-				def, err := st.Marshal(ctx)
-				if err != nil {
-					return errors.Wrapf(err, "failed to marshal LLB definition")
-				}
+	return client.BuildPlatforms(ctx, c, func(ctx context.Context, tp *specs.Platform) (_ *client.PlatformResult, err error) {
+		defer func() {
+			var el *parser.ErrorLocation
+			if errors.As(err, &el) {
+				err = wrapSource(err, sourceMap, el.Location)
+			}
+		}()
+		scanStages := map[string]llb.State{}
+		st, img, err := dockerfile2llb.Dockerfile2LLB(ctx, dtDockerfile, dockerfile2llb.ConvertOpt{
+			Target:            opts[keyTarget],
+			MetaResolver:      c,
+			BuildArgs:         filter(opts, buildArgPrefix),
+			Labels:            filter(opts, labelPrefix),
+			CacheIDNamespace:  opts[keyCacheNS],
+			SessionID:         c.BuildOpts().SessionID,
+			BuildContext:      buildContext,
+			Excludes:          excludes,
+			IgnoreCache:       ignoreCache,
+			TargetPlatform:    tp,
+			BuildPlatforms:    buildPlatforms,
+			ImageResolveMode:  resolveMode,
+			PrefixPlatform:    exportMap,
+			ExtraHosts:        extraHosts,
+			ForceNetMode:      defaultNetMode,
+			OverrideCopyImage: opts[keyOverrideCopyImage],
+			LLBCaps:           &caps,
+			SourceMap:         sourceMap,
+			Hostname:          opts[keyHostname],
+			ScanStage: func(name string, st llb.State) {
+				scanStages[name] = st
+			},
+			ContextByName: func(ctx context.Context, name, resolveMode string, platform *specs.Platform) (*llb.State, *dockerfile2llb.Image, error) {
+				return contextByName(ctx, c, name, resolveMode, platform)
+			},
+			Lint:       lintFunc,
+			LintConfig: *lintCfg,
+		})
 
-				config, err := json.Marshal(img)
-				if err != nil {
-					return errors.Wrapf(err, "failed to marshal image config")
-				}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create LLB definition")
+		}
 
-				var cacheImports []client.CacheOptionsEntry
-				// new API
-				if cacheImportsStr := opts[keyCacheImports]; cacheImportsStr != "" {
-					var cacheImportsUM []controlapi.CacheOptionsEntry
-					if err := json.Unmarshal([]byte(cacheImportsStr), &cacheImportsUM); err != nil {
-						return errors.Wrapf(err, "failed to unmarshal %s (%q)", keyCacheImports, cacheImportsStr)
-					}
-					for _, um := range cacheImportsUM {
-						cacheImports = append(cacheImports, client.CacheOptionsEntry{Type: um.Type, Attrs: um.Attrs})
-					}
-				}
-				// old API
-				if cacheFromStr := opts[keyCacheFrom]; cacheFromStr != "" {
-					cacheFrom := strings.Split(cacheFromStr, ",")
-					for _, s := range cacheFrom {
-						im := client.CacheOptionsEntry{
-							Type: "registry",
-							Attrs: map[string]string{
-								"ref": s,
-							},
-						}
-						// FIXME(AkihiroSuda): skip append if already exists
-						cacheImports = append(cacheImports, im)
-					}
-				}
+		def, err := st.Marshal(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal LLB definition")
+		}
 
-				r, err := c.Solve(ctx, client.SolveRequest{
-					Definition:   def.ToPB(),
-					CacheImports: cacheImports,
-				})
-				if err != nil {
-					return err
-				}
+		config, err := json.Marshal(img)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal image config")
+		}
 
-				ref, err := r.SingleRef()
-				if err != nil {
-					return err
-				}
+		var sbomStages []byte
+		if len(scanStages) > 0 {
+			sbomStages, err = marshalScanStages(ctx, scanStages)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-				if !exportMap {
-					res.AddMeta(exptypes.ExporterImageConfigKey, config)
-					res.SetRef(ref)
-				} else {
-					p := platforms.DefaultSpec()
-					if tp != nil {
-						p = *tp
-					}
-
-					k := platforms.Format(p)
-					res.AddMeta(fmt.Sprintf("%s/%s", exptypes.ExporterImageConfigKey, k), config)
-					res.AddRef(k, ref)
-					expPlatforms.Platforms[i] = exptypes.Platform{
-						ID:       k,
-						Platform: p,
-					}
+		var cacheImports []client.CacheOptionsEntry
+		// new API
+		if cacheImportsStr := opts[keyCacheImports]; cacheImportsStr != "" {
+			var cacheImportsUM []controlapi.CacheOptionsEntry
+			if err := json.Unmarshal([]byte(cacheImportsStr), &cacheImportsUM); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal %s (%q)", keyCacheImports, cacheImportsStr)
+			}
+			for _, um := range cacheImportsUM {
+				cacheImports = append(cacheImports, client.CacheOptionsEntry{Type: um.Type, Attrs: um.Attrs})
+			}
+		}
+		// old API
+		if cacheFromStr := opts[keyCacheFrom]; cacheFromStr != "" {
+			cacheFrom := strings.Split(cacheFromStr, ",")
+			for _, s := range cacheFrom {
+				im := client.CacheOptionsEntry{
+					Type: "registry",
+					Attrs: map[string]string{
+						"ref": s,
+					},
 				}
-				return nil
-			})
-		}(i, tp)
-	}
+				// FIXME(AkihiroSuda): skip append if already exists
+				cacheImports = append(cacheImports, im)
+			}
+		}
 
-	if err := eg.Wait(); err != nil {
-		return nil, err
-	}
+		r, err := c.Solve(ctx, client.SolveRequest{
+			Definition:   def.ToPB(),
+			CacheImports: cacheImports,
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	if exportMap {
-		dt, err := json.Marshal(expPlatforms)
+		ref, err := r.SingleRef()
 		if err != nil {
 			return nil, err
 		}
-		res.AddMeta(exptypes.ExporterPlatformsKey, dt)
-	}
 
-	return res, nil
+		pr := &client.PlatformResult{
+			Ref:    ref,
+			Config: config,
+		}
+		if sbomStages != nil {
+			pr.Attachments = append(pr.Attachments, exptypes.Attachment{Type: exptypes.AttachmentSBOMStages, Data: sbomStages})
+		}
+		return pr, nil
+	})
 }
 
 func forwardGateway(ctx context.Context, c client.Client, ref string, cmdline string) (*client.Result, error) {
@@ -541,6 +513,24 @@ func forwardGateway(ctx context.Context, c client.Client, ref string, cmdline st
 	})
 }
 
+// marshalScanStages returns the JSON encoded LLB definitions of the
+// intermediate stages that should be included in the SBOM scan.
+func marshalScanStages(ctx context.Context, stages map[string]llb.State) ([]byte, error) {
+	m := make(map[string][]byte, len(stages))
+	for name, st := range stages {
+		def, err := st.Marshal(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal stage %s", name)
+		}
+		dt, err := def.ToPB().Marshal()
+		if err != nil {
+			return nil, err
+		}
+		m[name] = dt
+	}
+	return json.Marshal(m)
+}
+
 func filter(opt map[string]string, key string) map[string]string {
 	m := map[string]string{}
 	for k, v := range opt {
@@ -607,19 +597,6 @@ func isArchive(header []byte) bool {
 	return err == nil
 }
 
-func parsePlatforms(v string) ([]*specs.Platform, error) {
-	var pp []*specs.Platform
-	for _, v := range strings.Split(v, ",") {
-		p, err := platforms.Parse(v)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse target platform %s", v)
-		}
-		p = platforms.Normalize(p)
-		pp = append(pp, &p)
-	}
-	return pp, nil
-}
-
 func parseResolveMode(v string) (llb.ResolveMode, error) {
 	switch v {
 	case pb.AttrImageResolveModeDefault, "":
@@ -709,10 +686,15 @@ func wrapSource(err error, sm *llb.SourceMap, ranges []parser.Range) error {
 			Filename:   sm.Filename,
 			Definition: sm.Definition.ToPB(),
 		},
-		Ranges: make([]*pb.Range, 0, len(ranges)),
+		Ranges: toPBRanges(ranges),
 	}
+	return errdefs.WithSource(err, s)
+}
+
+func toPBRanges(ranges []parser.Range) []*pb.Range {
+	out := make([]*pb.Range, 0, len(ranges))
 	for _, r := range ranges {
-		s.Ranges = append(s.Ranges, &pb.Range{
+		out = append(out, &pb.Range{
 			Start: pb.Position{
 				Line:      int32(r.Start.Line),
 				Character: int32(r.Start.Character),
@@ -723,5 +705,5 @@ func wrapSource(err error, sm *llb.SourceMap, ranges []parser.Range) error {
 			},
 		})
 	}
-	return errdefs.WithSource(err, s)
+	return out
 }
@@ -0,0 +1,106 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerfile2llb"
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// lintConfig returns the configuration of the checks that run during the
+// build. ok is false if the checks aren't enabled.
+func lintConfig(dt []byte, opts map[string]string, sm *llb.SourceMap) (*linter.Config, bool, error) {
+	cfg, ok, err := dockerfile2llb.LintConfig(dt, filter(opts, buildArgPrefix))
+	if err != nil {
+		var el *parser.ErrorLocation
+		if errors.As(err, &el) {
+			err = wrapSource(err, sm, el.Location)
+		}
+		return nil, false, err
+	}
+	return cfg, ok, nil
+}
+
+// newLint returns the callback that reports the warnings of the checks. The
+// Dockerfile is converted once per platform, but the warnings are only
+// reported for the first conversion.
+func newLint(ctx context.Context, c client.Client, cfg *linter.Config, sm *llb.SourceMap) func([]linter.Warning) error {
+	var once sync.Once
+	var err error
+	return func(warnings []linter.Warning) error {
+		once.Do(func() {
+			err = lint(ctx, c, warnings, cfg, sm)
+		})
+		return err
+	}
+}
+
+// lint sends the warnings to the client. If the check configuration sets
+// error=true, any warning fails the build.
+func lint(ctx context.Context, c client.Client, warnings []linter.Warning, cfg *linter.Config, sm *llb.SourceMap) error {
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	caps := c.BuildOpts().Caps
+	if (&caps).Supports(gwpb.CapGatewayWarnings) == nil && sm != nil {
+		dgst, err := definitionVertex(sm.Definition)
+		if err != nil {
+			return err
+		}
+		info := &pb.SourceInfo{
+			Data:       sm.Data,
+			Filename:   sm.Filename,
+			Definition: sm.Definition.ToPB(),
+		}
+		for _, warning := range warnings {
+			if err := c.Warn(ctx, dgst, fmt.Sprintf("%s: %s", warning.RuleName, warning.Detail), client.WarnOpts{
+				Level:      1,
+				SourceInfo: info,
+				Range:      toPBRanges(warning.Location),
+				Detail:     [][]byte{[]byte(warning.Description)},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cfg.Error {
+		rules := make([]string, 0, len(warnings))
+		seen := map[string]struct{}{}
+		for _, warning := range warnings {
+			if _, ok := seen[warning.RuleName]; !ok {
+				seen[warning.RuleName] = struct{}{}
+				rules = append(rules, warning.RuleName)
+			}
+		}
+		return wrapSource(errors.Errorf("lint violation found for rules: %s", strings.Join(rules, ", ")), sm, warnings[0].Location)
+	}
+	return nil
+}
+
+// definitionVertex returns the digest of the vertex producing the output of
+// the definition.
+func definitionVertex(def *llb.Definition) (digest.Digest, error) {
+	if def == nil || len(def.Def) == 0 {
+		return "", errors.Errorf("empty definition")
+	}
+	var op pb.Op
+	if err := op.Unmarshal(def.Def[len(def.Def)-1]); err != nil {
+		return "", errors.Wrap(err, "failed to parse definition")
+	}
+	if len(op.Inputs) == 0 {
+		return "", errors.Errorf("definition has no output")
+	}
+	return op.Inputs[0].Digest, nil
+}
@@ -0,0 +1,231 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ctdreference "github.com/containerd/containerd/reference"
+	"github.com/docker/distribution/reference"
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerfile2llb"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	keyContextPrefix       = "context:"
+	keyInputMetadataPrefix = "input-metadata:"
+
+	dockerImagePrefix = "docker-image://"
+	ociLayoutPrefix   = "oci-layout://"
+	localPrefix       = "local:"
+	inputPrefix       = "input:"
+)
+
+// contextByName returns the build context defined with the context:<name>
+// frontend option. Supported values are docker-image://<ref>,
+// oci-layout://<store>/<name>@<digest> for an image in an OCI layout of the
+// client, git URLs, HTTP URLs of tarballs, local:<name> for a local directory
+// sent by the client and input:<name> for an input passed by a parent
+// frontend.
+func contextByName(ctx context.Context, c client.Client, name string, resolveMode string, platform *specs.Platform) (*llb.State, *dockerfile2llb.Image, error) {
+	opts := c.BuildOpts().Opts
+	v, ok := opts[keyContextPrefix+name]
+	if !ok {
+		return nil, nil, nil
+	}
+	logName := fmt.Sprintf("[context %s]", name)
+
+	switch {
+	case strings.HasPrefix(v, dockerImagePrefix):
+		ref := strings.TrimPrefix(v, dockerImagePrefix)
+		named, err := reference.ParseNormalizedNamed(ref)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid image reference %s for context %s", ref, name)
+		}
+		ref = reference.TagNameOnly(named).String()
+
+		_, dt, err := c.ResolveImageConfig(ctx, ref, llb.ResolveImageConfigOpt{
+			Platform:    platform,
+			ResolveMode: resolveMode,
+			LogName:     fmt.Sprintf("%s load metadata for %s", logName, ref),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		var img dockerfile2llb.Image
+		if err := json.Unmarshal(dt, &img); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse image config for %s", ref)
+		}
+		img.Created = nil
+
+		imgOpt := []llb.ImageOption{
+			llb.WithCustomName(logName + " " + ref),
+		}
+		if platform != nil {
+			imgOpt = append(imgOpt, llb.Platform(*platform))
+		}
+		st, err := llb.Image(ref, imgOpt...).WithImageConfig(dt)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &st, &img, nil
+	case strings.HasPrefix(v, ociLayoutPrefix):
+		return ociLayoutContext(ctx, c, name, strings.TrimPrefix(v, ociLayoutPrefix), platform)
+	case strings.HasPrefix(v, localPrefix):
+		return localContext(ctx, c, name, strings.TrimPrefix(v, localPrefix))
+	case strings.HasPrefix(v, inputPrefix):
+		return inputContext(ctx, c, name, strings.TrimPrefix(v, inputPrefix))
+	}
+
+	if st, ok := detectGitContext(v, opts[keyContextKeepGitDir]); ok {
+		return st, nil, nil
+	}
+	if httpPrefix.MatchString(v) {
+		httpContext := llb.HTTP(v, llb.Filename("context"), llb.WithCustomName(logName+" "+v))
+		st := llb.Scratch().File(llb.Copy(httpContext, "/context", "/", &llb.CopyInfo{
+			AttemptUnpack: true,
+		}), llb.WithCustomName(logName+" extracting "+v))
+		return &st, nil, nil
+	}
+	return nil, nil, errors.Errorf("unsupported context source %s for %s", v, name)
+}
+
+// ociLayoutContext loads an image from an OCI layout that the client exposes
+// as a content store.
+func ociLayoutContext(ctx context.Context, c client.Client, name, ref string, platform *specs.Platform) (*llb.State, *dockerfile2llb.Image, error) {
+	gwcaps := c.BuildOpts().Caps
+	if err := (&gwcaps).Supports(gwpb.CapSourceMetaResolverOCILayout); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load oci-layout context %s", name)
+	}
+	spec, err := ctdreference.Parse(ref)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid oci-layout reference %s for context %s", ref, name)
+	}
+	if spec.Digest() == "" {
+		return nil, nil, errors.Errorf("oci-layout reference %s for context %s must have a digest", ref, name)
+	}
+	logName := fmt.Sprintf("[context %s]", name)
+	sessionID := c.BuildOpts().SessionID
+
+	_, dt, err := c.ResolveImageConfig(ctx, ref, llb.ResolveImageConfigOpt{
+		ResolverType: llb.ResolverTypeOCILayout,
+		Platform:     platform,
+		LogName:      fmt.Sprintf("%s load metadata for %s", logName, ref),
+		Store: llb.ResolveImageConfigOptStore{
+			SessionID: sessionID,
+			StoreID:   spec.Hostname(),
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var img dockerfile2llb.Image
+	if err := json.Unmarshal(dt, &img); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse image config for %s", ref)
+	}
+	img.Created = nil
+
+	opts := []llb.OCILayoutOption{
+		llb.OCISessionID(sessionID),
+		llb.WithCustomName(logName + " " + ref),
+	}
+	if platform != nil {
+		opts = append(opts, llb.Platform(*platform))
+	}
+	st, err := llb.OCILayout(ref, opts...).WithImageConfig(dt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &st, &img, nil
+}
+
+// localContext loads a local directory sent by the client, respecting the
+// .dockerignore file at its root.
+func localContext(ctx context.Context, c client.Client, name, localName string) (*llb.State, *dockerfile2llb.Image, error) {
+	sessionID := c.BuildOpts().SessionID
+	st := llb.Local(localName,
+		llb.SessionID(sessionID),
+		llb.FollowPaths([]string{dockerignoreFilename}),
+		llb.SharedKeyHint(keyContextPrefix+localName+"-"+dockerignoreFilename),
+		llb.WithCustomName(fmt.Sprintf("[context %s] load %s", name, dockerignoreFilename)),
+	)
+	def, err := st.Marshal(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := c.Solve(ctx, client.SolveRequest{
+		Definition: def.ToPB(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	ref, err := res.SingleRef()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var excludes []string
+	if dt, err := ref.ReadFile(ctx, client.ReadRequest{
+		Filename: dockerignoreFilename,
+	}); err == nil {
+		excludes, err = dockerignore.ReadAll(bytes.NewBuffer(dt))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse dockerignore for context %s", name)
+		}
+	}
+
+	st = llb.Local(localName,
+		llb.SessionID(sessionID),
+		llb.ExcludePatterns(excludes),
+		llb.SharedKeyHint(keyContextPrefix+localName),
+		llb.WithCustomName(fmt.Sprintf("[context %s] load from client", name)),
+	)
+	return &st, nil, nil
+}
+
+// inputContext loads an input passed by a parent frontend. The image config
+// of the input can be passed as the input-metadata:<input> option.
+func inputContext(ctx context.Context, c client.Client, name, inputName string) (*llb.State, *dockerfile2llb.Image, error) {
+	gwcaps := c.BuildOpts().Caps
+	if err := (&gwcaps).Supports(gwpb.CapFrontendInputs); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load input context %s", name)
+	}
+	inputs, err := c.Inputs(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get frontend inputs")
+	}
+	st, ok := inputs[inputName]
+	if !ok {
+		return nil, nil, errors.Errorf("invalid input %s for context %s", inputName, name)
+	}
+
+	md, ok := c.BuildOpts().Opts[keyInputMetadataPrefix+inputName]
+	if !ok {
+		return &st, nil, nil
+	}
+	m := map[string][]byte{}
+	if err := json.Unmarshal([]byte(md), &m); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse input metadata for %s", inputName)
+	}
+	dt, ok := m[exptypes.ExporterImageConfigKey]
+	if !ok {
+		return &st, nil, nil
+	}
+	var img dockerfile2llb.Image
+	if err := json.Unmarshal(dt, &img); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse image config for input %s", inputName)
+	}
+	st, err = st.WithImageConfig(dt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &st, &img, nil
+}
@@ -2,38 +2,74 @@ package builder
 
 import (
 	"context"
-	"encoding/json"
 
+	"github.com/moby/buildkit/frontend/dockerfile/dockerfile2llb"
 	"github.com/moby/buildkit/frontend/gateway/client"
 	"github.com/moby/buildkit/frontend/subrequests"
-	"github.com/moby/buildkit/solver/errdefs"
 )
 
-func checkSubRequest(ctx context.Context, opts map[string]string) (*client.Result, bool, error) {
-	req, ok := opts["requestid"]
-	if !ok {
-		return nil, false, nil
+func checkSubRequest(ctx context.Context, c client.Client, dt []byte, filename string) (*client.Result, bool, error) {
+	opts := c.BuildOpts().Opts
+	return subrequests.Dispatch(ctx, c,
+		subrequests.Handler{
+			Request: subrequests.LintDefinition,
+			Handle: func(ctx context.Context, c client.Client) (*client.Result, error) {
+				return lintSubrequest(dt, opts, filename)
+			},
+		},
+		subrequests.Handler{
+			Request: subrequests.OutlineDefinition,
+			Handle: func(ctx context.Context, c client.Client) (*client.Result, error) {
+				return outlineSubrequest(dt, opts)
+			},
+		},
+		subrequests.Handler{
+			Request: subrequests.TargetsDefinition,
+			Handle: func(ctx context.Context, c client.Client) (*client.Result, error) {
+				return targetsSubrequest(dt)
+			},
+		},
+	)
+}
+
+func lintSubrequest(dt []byte, opts map[string]string, filename string) (*client.Result, error) {
+	warnings, cfg, err := dockerfile2llb.Lint(dt, dockerfile2llb.ConvertOpt{
+		BuildArgs: filter(opts, buildArgPrefix),
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := subrequests.LintResults{
+		Warnings: []subrequests.LintWarning{},
+		Error:    cfg.Error && len(warnings) > 0,
 	}
-	switch req {
-	case subrequests.RequestSubrequestsDescribe:
-		res, err := describe()
-		return res, true, err
-	default:
-		return nil, true, errdefs.NewUnsupportedSubrequestError(req)
+	for _, w := range warnings {
+		results.Warnings = append(results.Warnings, subrequests.LintWarning{
+			RuleName:    w.RuleName,
+			Description: w.Description,
+			Detail:      w.Detail,
+			Filename:    filename,
+			Ranges:      toPBRanges(w.Location),
+		})
 	}
+	return subrequests.NewResult(results, results.String())
 }
 
-func describe() (*client.Result, error) {
-	all := []subrequests.Request{
-		subrequests.SubrequestsDescribeDefinition,
-	}
-	dt, err := json.MarshalIndent(all, "  ", "")
+func outlineSubrequest(dt []byte, opts map[string]string) (*client.Result, error) {
+	o, err := dockerfile2llb.Dockerfile2Outline(dt, dockerfile2llb.ConvertOpt{
+		Target:    opts[keyTarget],
+		BuildArgs: filter(opts, buildArgPrefix),
+	})
 	if err != nil {
 		return nil, err
 	}
-	res := client.NewResult()
-	res.Metadata = map[string][]byte{
-		"result.json": dt,
+	return subrequests.NewResult(o, o.String())
+}
+
+func targetsSubrequest(dt []byte) (*client.Result, error) {
+	l, err := dockerfile2llb.ListTargets(dt)
+	if err != nil {
+		return nil, err
 	}
-	return res, nil
+	return subrequests.NewResult(l, l.String())
 }
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,11 +22,13 @@ import (
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/client/llb/imagemetaresolver"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/apicaps"
 	"github.com/moby/buildkit/util/system"
+	digest "github.com/opencontainers/go-digest"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -35,10 +38,13 @@ const (
 	emptyImageName          = "scratch"
 	defaultContextLocalName = "context"
 	historyComment          = "buildkit.dockerfile.v0"
+	sbomScanStageArg        = "BUILDKIT_SBOM_SCAN_STAGE"
 
 	DefaultCopyImage = "docker/dockerfile-copy:v0.1.9@sha256:e8f159d3f00786604b93c675ee2783f8dc194bb565e61ca5788f6a6e9d304061"
 )
 
+var gitURLPathWithFragmentSuffix = regexp.MustCompile(`\.git(?:#.+)?$`)
+
 type ConvertOpt struct {
 	Target       string
 	MetaResolver llb.ImageMetaResolver
@@ -63,6 +69,21 @@ type ConvertOpt struct {
 	ContextLocalName  string
 	SourceMap         *llb.SourceMap
 	Hostname          string
+	// ScanStage is called for every intermediate stage that sets the
+	// BUILDKIT_SBOM_SCAN_STAGE build argument so that its filesystem can be
+	// included when generating an SBOM for the build result.
+	ScanStage func(name string, st llb.State)
+	// ContextByName returns the build context that replaces the stage or
+	// image with the given name. A nil state means that no named context was
+	// defined for the name. The returned image config is optional. It may be
+	// called concurrently for different stages.
+	ContextByName func(ctx context.Context, name string, resolveMode string, platform *specs.Platform) (*llb.State, *Image, error)
+	// Lint is called with the warnings found by the checks of the linter
+	// package before the Dockerfile is converted. The checks don't run if it
+	// is nil.
+	Lint func(warnings []linter.Warning) error
+	// LintConfig selects the checks that are run for Lint.
+	LintConfig linter.Config
 }
 
 func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State, *Image, error) {
@@ -93,7 +114,13 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 		return nil, nil, err
 	}
 
-	shlex := shell.NewLex(dockerfile.EscapeToken)
+	if opt.Lint != nil {
+		if err := opt.Lint(lint(dockerfile, stages, metaArgs, opt.LintConfig, opt)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	shlex := newShellLex(dockerfile.EscapeToken)
 
 	for _, cmd := range metaArgs {
 		for _, metaArg := range cmd.Args {
@@ -146,6 +173,33 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 			}
 			ds.platform = &p
 		}
+
+		if st.Name != "" && opt.ContextByName != nil {
+			platform := ds.platform
+			if platform == nil {
+				platform = &platformOpt.targetPlatform
+			}
+			s, img, err := opt.ContextByName(ctx, st.Name, opt.ImageResolveMode.String(), platform)
+			if err != nil {
+				return nil, nil, parser.WithLocation(err, st.Location)
+			}
+			if s != nil {
+				// the named context replaces the whole stage
+				ds.noinit = true
+				ds.stage.Commands = nil
+				ds.state = *s
+				ds.platform = platform
+				if img != nil {
+					ds.image = *img
+				} else {
+					ds.image = emptyImage(*platform)
+				}
+				allDispatchStates.addState(ds)
+				ds.base = nil
+				continue
+			}
+		}
+
 		allDispatchStates.addState(ds)
 
 		total := 0
@@ -220,7 +274,7 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 	for i, d := range allDispatchStates.states {
 		reachable := isReachable(target, d)
 		// resolve image config for every stage
-		if d.base == nil {
+		if d.base == nil && !d.noinit {
 			if d.stage.BaseName == emptyImageName {
 				d.state = llb.Scratch()
 				d.image = emptyImage(platformOpt.targetPlatform)
@@ -236,7 +290,27 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 					if platform == nil {
 						platform = &platformOpt.targetPlatform
 					}
+					origName := d.stage.BaseName
 					d.stage.BaseName = reference.TagNameOnly(ref).String()
+					if opt.ContextByName != nil && reachable {
+						for _, name := range uniqueNames(origName, d.stage.BaseName) {
+							st, img, err := opt.ContextByName(ctx, name, opt.ImageResolveMode.String(), platform)
+							if err != nil {
+								return parser.WithLocation(err, d.stage.Location)
+							}
+							if st == nil {
+								continue
+							}
+							if img != nil {
+								d.image = *img
+							} else {
+								d.image = emptyImage(*platform)
+							}
+							d.state = *st
+							d.platform = platform
+							return nil
+						}
+					}
 					var isScratch bool
 					if metaResolver != nil && reachable && !d.unregistered {
 						prefix := "["
@@ -305,9 +379,10 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 
 	buildContext := &mutableOutput{}
 	ctxPaths := map[string]struct{}{}
+	var scanStages []*dispatchState
 
 	for _, d := range allDispatchStates.states {
-		if !isReachable(target, d) {
+		if !isReachable(target, d) || d.noinit {
 			continue
 		}
 		if d.base != nil {
@@ -379,6 +454,10 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 		for p := range d.ctxPaths {
 			ctxPaths[p] = struct{}{}
 		}
+
+		if d != target && isScanStage(d) {
+			scanStages = append(scanStages, d)
+		}
 	}
 
 	if len(opt.Labels) != 0 && target.image.Config.Labels == nil {
@@ -412,6 +491,12 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 	}
 	st := target.state.SetMarshalDefaults(defaults...)
 
+	if opt.ScanStage != nil {
+		for _, d := range scanStages {
+			opt.ScanStage(d.stageName, d.state.SetMarshalDefaults(defaults...))
+		}
+	}
+
 	if !platformOpt.implicitTarget {
 		target.image.OS = platformOpt.targetPlatform.OS
 		target.image.Architecture = platformOpt.targetPlatform.Architecture
@@ -421,6 +506,29 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 	return &st, &target.image, nil
 }
 
+func uniqueNames(names ...string) []string {
+	out := make([]string, 0, len(names))
+	seen := map[string]struct{}{}
+	for _, n := range names {
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		out = append(out, n)
+	}
+	return out
+}
+
+func isScanStage(d *dispatchState) bool {
+	for _, arg := range d.buildArgs {
+		if arg.Key == sbomScanStageArg && arg.Value != nil {
+			b, err := strconv.ParseBool(*arg.Value)
+			return err == nil && b
+		}
+	}
+	return false
+}
+
 func metaArgsToMap(metaArgs []instructions.KeyValuePairOptional) map[string]string {
 	m := map[string]string{}
 
@@ -493,6 +601,21 @@ func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
 			return err
 		}
 	}
+	if ex, ok := cmd.Command.(instructions.SupportsSingleWordExpansionRaw); ok {
+		err := ex.ExpandRaw(func(word string) (string, error) {
+			env, err := d.state.Env(context.TODO())
+			if err != nil {
+				return "", err
+			}
+			lex := *opt.shlex
+			lex.SkipProcessQuotes = true
+			lex.EscapeDollarOnly = true
+			return lex.ProcessWord(word, env)
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	var err error
 	switch c := cmd.Command.(type) {
@@ -505,10 +628,23 @@ func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
 	case *instructions.WorkdirCommand:
 		err = dispatchWorkdir(d, c, true, &opt)
 	case *instructions.AddCommand:
-		err = dispatchCopy(d, c.SourcesAndDest, opt.buildContext, true, c, c.Chown, c.Chmod, c.Location(), opt)
+		err = dispatchCopy(d, copyConfig{
+			params:       c.SourcesAndDest,
+			contents:     c.SourceContents,
+			source:       opt.buildContext,
+			isAddCommand: true,
+			cmdToPrint:   c,
+			chown:        c.Chown,
+			chmod:        c.Chmod,
+			link:         c.Link,
+			checksum:     digest.Digest(c.Checksum),
+			keepGitDir:   c.KeepGitDir,
+			location:     c.Location(),
+			opt:          opt,
+		})
 		if err == nil {
 			for _, src := range c.Sources() {
-				if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+				if !isHTTPSource(src) && !isGitSource(src) {
 					d.ctxPaths[path.Join("/", filepath.ToSlash(src))] = struct{}{}
 				}
 			}
@@ -540,7 +676,19 @@ func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
 		if len(cmd.sources) != 0 {
 			l = cmd.sources[0].state
 		}
-		err = dispatchCopy(d, c.SourcesAndDest, l, false, c, c.Chown, c.Chmod, c.Location(), opt)
+		err = dispatchCopy(d, copyConfig{
+			params:     c.SourcesAndDest,
+			contents:   c.SourceContents,
+			source:     l,
+			cmdToPrint: c,
+			chown:      c.Chown,
+			chmod:      c.Chmod,
+			link:       c.Link,
+			parents:    c.Parents,
+			excludes:   c.Excludes,
+			location:   c.Location(),
+			opt:        opt,
+		})
 		if err == nil && len(cmd.sources) == 0 {
 			for _, src := range c.Sources() {
 				d.ctxPaths[path.Join("/", filepath.ToSlash(src))] = struct{}{}
@@ -559,11 +707,13 @@ type dispatchState struct {
 	base           *dispatchState
 	deps           map[*dispatchState]struct{}
 	buildArgs      []instructions.KeyValuePairOptional
+	secretArgs     []instructions.KeyValuePair
 	commands       []command
 	ctxPaths       map[string]struct{}
 	ignoreCache    bool
 	cmdSet         bool
 	unregistered   bool
+	noinit         bool
 	stageName      string
 	cmdIndex       int
 	cmdTotal       int
@@ -647,7 +797,42 @@ func dispatchEnv(d *dispatchState, c *instructions.EnvCommand) error {
 }
 
 func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyEnv, sources []*dispatchState, dopt dispatchOpt) error {
+	var opt []llb.RunOption
+
 	var args []string = c.CmdLine
+	if len(c.Files) > 0 {
+		if len(args) != 1 || !c.PrependShell {
+			return errors.Errorf("parsing produced an invalid run command: %v", args)
+		}
+
+		if heredoc := parser.MustParseHeredoc(args[0]); heredoc != nil {
+			data := c.Files[0].Data
+			if c.Files[0].Chomp {
+				data = parser.ChompHeredocContent(data)
+			}
+			if d.image.OS != "windows" && strings.HasPrefix(data, "#!") {
+				// A single heredoc with a shebang is written to a file and
+				// executed directly so that the interpreter is respected.
+				const scriptDir = "/dev/pipes/"
+				st := inlineFileState(c.Files[0].Name, data, 0755)
+				opt = append(opt, llb.AddMount(scriptDir, st, llb.Readonly))
+				args = []string{path.Join(scriptDir, c.Files[0].Name)}
+			} else {
+				// A single heredoc without a shebang is passed to the shell as
+				// a script, which also works for shells that don't support
+				// heredocs. Variables are expanded by the shell itself.
+				args = []string{data}
+			}
+		} else {
+			// Heredocs used inside a command (e.g. cat <<EOF > file) are
+			// reconstituted and left for the shell to handle.
+			full := args[0]
+			for _, file := range c.Files {
+				full += "\n" + file.Data + file.Name
+			}
+			args = []string{full}
+		}
+	}
 	if c.PrependShell {
 		args = withShell(d.image, args)
 	}
@@ -655,7 +840,7 @@ func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyE
 	if err != nil {
 		return err
 	}
-	opt := []llb.RunOption{llb.Args(args), dfCmd(c), location(dopt.sourceMap, c.Location())}
+	opt = append(opt, llb.Args(args), dfCmd(c), location(dopt.sourceMap, c.Location()))
 	if d.ignoreCache {
 		opt = append(opt, llb.IgnoreCache)
 	}
@@ -669,6 +854,12 @@ func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyE
 	}
 	opt = append(opt, runMounts...)
 
+	secretArgOpts, err := dispatchRunSecretArgs(d, dopt.llbCaps)
+	if err != nil {
+		return err
+	}
+	opt = append(opt, secretArgOpts...)
+
 	securityOpt, err := dispatchRunSecurity(c)
 	if err != nil {
 		return err
@@ -685,6 +876,12 @@ func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyE
 		opt = append(opt, networkOpt)
 	}
 
+	deviceOpts, err := dispatchRunDevices(c, dopt.llbCaps)
+	if err != nil {
+		return err
+	}
+	opt = append(opt, deviceOpts...)
+
 	shlex := *dopt.shlex
 	shlex.RawQuotes = true
 	shlex.SkipUnsetEnv = true
@@ -734,25 +931,48 @@ func dispatchWorkdir(d *dispatchState, c *instructions.WorkdirCommand, commit bo
 	return nil
 }
 
-func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceState llb.State, isAddCommand bool, cmdToPrint fmt.Stringer, chown string, chmod string, loc []parser.Range, opt dispatchOpt) error {
-	pp, err := pathRelativeToWorkingDir(d.state, c.Dest())
+type copyConfig struct {
+	params       instructions.SourcesAndDest
+	contents     []instructions.SourceContent
+	source       llb.State
+	isAddCommand bool
+	cmdToPrint   fmt.Stringer
+	chown        string
+	chmod        string
+	link         bool
+	checksum     digest.Digest
+	keepGitDir   bool
+	parents      bool
+	excludes     []string
+	location     []parser.Range
+	opt          dispatchOpt
+}
+
+func dispatchCopyFileOp(d *dispatchState, cfg copyConfig) error {
+	if cfg.link && cfg.chown != "" {
+		if _, _, err := parseUser(cfg.chown); err != nil {
+			return errors.New("--link does not support user or group names in --chown, use numeric IDs")
+		}
+	}
+
+	pp, err := pathRelativeToWorkingDir(d.state, cfg.params.Dest())
 	if err != nil {
 		return err
 	}
 	dest := path.Join("/", pp)
-	if c.Dest() == "." || c.Dest() == "" || c.Dest()[len(c.Dest())-1] == filepath.Separator {
+	if cfg.params.Dest() == "." || cfg.params.Dest() == "" || cfg.params.Dest()[len(cfg.params.Dest())-1] == filepath.Separator {
 		dest += string(filepath.Separator)
 	}
 
 	var copyOpt []llb.CopyOption
 
-	if chown != "" {
-		copyOpt = append(copyOpt, llb.WithUser(chown))
+	if cfg.chown != "" {
+		copyOpt = append(copyOpt, llb.WithUser(cfg.chown))
 	}
 
 	var mode *os.FileMode
-	if chmod != "" {
-		p, err := strconv.ParseUint(chmod, 8, 32)
+	if cfg.chmod != "" {
+		p, err := strconv.ParseUint(cfg.chmod, 8, 32)
 		if err == nil {
 			perm := os.FileMode(p)
 			mode = &perm
@@ -760,7 +980,7 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 	}
 
 	commitMessage := bytes.NewBufferString("")
-	if isAddCommand {
+	if cfg.isAddCommand {
 		commitMessage.WriteString("ADD")
 	} else {
 		commitMessage.WriteString("COPY")
@@ -768,10 +988,23 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 
 	var a *llb.FileAction
 
-	for _, src := range c.Sources() {
+	for _, src := range cfg.params.Sources() {
 		commitMessage.WriteString(" " + src)
-		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
-			if !isAddCommand {
+		if cfg.isAddCommand && isGitSource(src) {
+			opts := append([]llb.CopyOption{&llb.CopyInfo{
+				Mode:                mode,
+				CopyDirContentsOnly: true,
+				CreateDestPath:      true,
+			}}, copyOpt...)
+
+			st := gitSourceState(src, cfg)
+			if a == nil {
+				a = llb.Copy(st, "/", dest, opts...)
+			} else {
+				a = a.Copy(st, "/", dest, opts...)
+			}
+		} else if isHTTPSource(src) {
+			if !cfg.isAddCommand {
 				return errors.New("source can't be a URL for COPY")
 			}
 
@@ -788,7 +1021,7 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 				}
 			}
 
-			st := llb.HTTP(src, llb.Filename(f), dfCmd(c))
+			st := llb.HTTP(src, llb.Filename(f), llb.Checksum(cfg.checksum), dfCmd(cfg.params))
 
 			opts := append([]llb.CopyOption{&llb.CopyInfo{
 				CreateDestPath: true,
@@ -800,27 +1033,57 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 				a = a.Copy(st, f, dest, opts...)
 			}
 		} else {
-			opts := append([]llb.CopyOption{&llb.CopyInfo{
+			srcPath := filepath.Join("/", src)
+			info := &llb.CopyInfo{
 				Mode:                mode,
 				FollowSymlinks:      true,
 				CopyDirContentsOnly: true,
-				AttemptUnpack:       isAddCommand,
+				AttemptUnpack:       cfg.isAddCommand,
 				CreateDestPath:      true,
 				AllowWildcard:       true,
 				AllowEmptyWildcard:  true,
-			}}, copyOpt...)
+				ExcludePatterns:     cfg.excludes,
+			}
+			if cfg.parents {
+				// the source is copied from the root of the context, or the
+				// directory before a "/./" component, keeping the path to it
+				root, pattern := splitParentsPattern(src)
+				srcPath = root
+				if pattern != "." {
+					info.IncludePatterns = []string{pattern}
+				}
+				info.AllowWildcard = false
+				info.AllowEmptyWildcard = false
+			}
+			opts := append([]llb.CopyOption{info}, copyOpt...)
 
 			if a == nil {
-				a = llb.Copy(sourceState, filepath.Join("/", src), dest, opts...)
+				a = llb.Copy(cfg.source, srcPath, dest, opts...)
 			} else {
-				a = a.Copy(sourceState, filepath.Join("/", src), dest, opts...)
+				a = a.Copy(cfg.source, srcPath, dest, opts...)
 			}
 		}
 	}
 
-	commitMessage.WriteString(" " + c.Dest())
+	for _, src := range cfg.contents {
+		commitMessage.WriteString(" <<" + src.Path)
+
+		st := inlineFileState(src.Path, src.Data, 0644)
+		opts := append([]llb.CopyOption{&llb.CopyInfo{
+			Mode:           mode,
+			CreateDestPath: true,
+		}}, copyOpt...)
+
+		if a == nil {
+			a = llb.Copy(st, path.Join("/", src.Path), dest, opts...)
+		} else {
+			a = a.Copy(st, path.Join("/", src.Path), dest, opts...)
+		}
+	}
+
+	commitMessage.WriteString(" " + cfg.params.Dest())
 
-	platform := opt.targetPlatform
+	platform := cfg.opt.targetPlatform
 	if d.platform != nil {
 		platform = *d.platform
 	}
@@ -831,45 +1094,89 @@ func dispatchCopyFileOp(d *dispatchState, c instructions.SourcesAndDest, sourceS
 	}
 
 	fileOpt := []llb.ConstraintsOpt{
-		llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(opt.shlex, cmdToPrint.String(), env)), d.prefixPlatform, &platform)),
-		location(opt.sourceMap, loc),
+		llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(cfg.opt.shlex, cfg.cmdToPrint.String(), env)), d.prefixPlatform, &platform)),
+		location(cfg.opt.sourceMap, cfg.location),
 	}
 	if d.ignoreCache {
 		fileOpt = append(fileOpt, llb.IgnoreCache)
 	}
 
-	d.state = d.state.File(a, fileOpt...)
+	if cfg.link {
+		// The copy is done on an empty filesystem and merged with the previous
+		// state, so its layer doesn't depend on the layers below it.
+		d.state = llb.Merge([]llb.State{d.state, llb.Scratch().File(a, fileOpt...)})
+	} else {
+		d.state = d.state.File(a, fileOpt...)
+	}
 	return commitToHistory(&d.image, commitMessage.String(), true, &d.state)
 }
 
-func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState llb.State, isAddCommand bool, cmdToPrint fmt.Stringer, chown string, chmod string, loc []parser.Range, opt dispatchOpt) error {
-	if useFileOp(opt.buildArgValues, opt.llbCaps) {
-		return dispatchCopyFileOp(d, c, sourceState, isAddCommand, cmdToPrint, chown, chmod, loc, opt)
+func dispatchCopy(d *dispatchState, cfg copyConfig) error {
+	if cfg.checksum != "" {
+		if err := cfg.checksum.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid checksum %s", cfg.checksum)
+		}
+		if sources := cfg.params.Sources(); len(sources) != 1 || len(cfg.contents) != 0 || !isHTTPSource(sources[0]) || isGitSource(sources[0]) {
+			return errors.New("checksum can only be used with a single HTTP source")
+		}
+	}
+	if cfg.keepGitDir {
+		for _, src := range cfg.params.Sources() {
+			if !isGitSource(src) {
+				return errors.Errorf("--keep-git-dir can only be used with git sources, got %s", src)
+			}
+		}
 	}
 
-	if chmod != "" {
-		if opt.llbCaps != nil && opt.llbCaps.Supports(pb.CapFileBase) != nil {
-			return errors.Wrap(opt.llbCaps.Supports(pb.CapFileBase), "chmod is not supported")
+	if cfg.parents || len(cfg.excludes) > 0 {
+		if cfg.opt.llbCaps != nil {
+			if err := cfg.opt.llbCaps.Supports(pb.CapFileCopyIncludeExcludePatterns); err != nil {
+				return errors.Wrap(err, "--parents and --exclude are not supported")
+			}
+		}
+		if !useFileOp(cfg.opt.buildArgValues, cfg.opt.llbCaps) {
+			return errors.New("--parents and --exclude are not supported")
+		}
+	}
+
+	if cfg.link {
+		if cfg.opt.llbCaps != nil {
+			if err := cfg.opt.llbCaps.Supports(pb.CapMergeOp); err != nil {
+				return errors.Wrap(err, "--link is not supported")
+			}
+		}
+		if !useFileOp(cfg.opt.buildArgValues, cfg.opt.llbCaps) {
+			return errors.New("--link is not supported")
+		}
+	}
+
+	if useFileOp(cfg.opt.buildArgValues, cfg.opt.llbCaps) {
+		return dispatchCopyFileOp(d, cfg)
+	}
+
+	if cfg.chmod != "" {
+		if cfg.opt.llbCaps != nil && cfg.opt.llbCaps.Supports(pb.CapFileBase) != nil {
+			return errors.Wrap(cfg.opt.llbCaps.Supports(pb.CapFileBase), "chmod is not supported")
 		}
 		return errors.New("chmod is not supported")
 	}
 
-	img := llb.Image(opt.copyImage, llb.MarkImageInternal, llb.Platform(opt.buildPlatforms[0]), WithInternalName("helper image for file operations"))
-	pp, err := pathRelativeToWorkingDir(d.state, c.Dest())
+	img := llb.Image(cfg.opt.copyImage, llb.MarkImageInternal, llb.Platform(cfg.opt.buildPlatforms[0]), WithInternalName("helper image for file operations"))
+	pp, err := pathRelativeToWorkingDir(d.state, cfg.params.Dest())
 	if err != nil {
 		return err
 	}
 	dest := path.Join(".", pp)
-	if c.Dest() == "." || c.Dest() == "" || c.Dest()[len(c.Dest())-1] == filepath.Separator {
+	if cfg.params.Dest() == "." || cfg.params.Dest() == "" || cfg.params.Dest()[len(cfg.params.Dest())-1] == filepath.Separator {
 		dest += string(filepath.Separator)
 	}
 	args := []string{"copy"}
-	unpack := isAddCommand
+	unpack := cfg.isAddCommand
 
-	mounts := make([]llb.RunOption, 0, len(c.Sources()))
-	if chown != "" {
-		args = append(args, fmt.Sprintf("--chown=%s", chown))
-		_, _, err := parseUser(chown)
+	mounts := make([]llb.RunOption, 0, len(cfg.params.Sources()))
+	if cfg.chown != "" {
+		args = append(args, fmt.Sprintf("--chown=%s", cfg.chown))
+		_, _, err := parseUser(cfg.chown)
 		if err != nil {
 			mounts = append(mounts, llb.AddMount("/etc/passwd", d.state, llb.SourcePath("/etc/passwd"), llb.Readonly))
 			mounts = append(mounts, llb.AddMount("/etc/group", d.state, llb.SourcePath("/etc/group"), llb.Readonly))
@@ -877,16 +1184,20 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 	}
 
 	commitMessage := bytes.NewBufferString("")
-	if isAddCommand {
+	if cfg.isAddCommand {
 		commitMessage.WriteString("ADD")
 	} else {
 		commitMessage.WriteString("COPY")
 	}
 
-	for i, src := range c.Sources() {
+	for i, src := range cfg.params.Sources() {
 		commitMessage.WriteString(" " + src)
-		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
-			if !isAddCommand {
+		if cfg.isAddCommand && isGitSource(src) {
+			target := path.Join(fmt.Sprintf("/src-%d", i), "repo")
+			args = append(args, target)
+			mounts = append(mounts, llb.AddMount(target, gitSourceState(src, cfg), llb.Readonly))
+		} else if isHTTPSource(src) {
+			if !cfg.isAddCommand {
 				return errors.New("source can't be a URL for COPY")
 			}
 
@@ -905,7 +1216,7 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 			}
 			target := path.Join(fmt.Sprintf("/src-%d", i), f)
 			args = append(args, target)
-			mounts = append(mounts, llb.AddMount(path.Dir(target), llb.HTTP(src, llb.Filename(f), dfCmd(c)), llb.Readonly))
+			mounts = append(mounts, llb.AddMount(path.Dir(target), llb.HTTP(src, llb.Filename(f), llb.Checksum(cfg.checksum), dfCmd(cfg.params)), llb.Readonly))
 		} else {
 			d, f := splitWildcards(src)
 			targetCmd := fmt.Sprintf("/src-%d", i)
@@ -916,18 +1227,25 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 			}
 			targetCmd = path.Join(targetCmd, f)
 			args = append(args, targetCmd)
-			mounts = append(mounts, llb.AddMount(targetMount, sourceState, llb.SourcePath(d), llb.Readonly))
+			mounts = append(mounts, llb.AddMount(targetMount, cfg.source, llb.SourcePath(d), llb.Readonly))
 		}
 	}
 
-	commitMessage.WriteString(" " + c.Dest())
+	for i, src := range cfg.contents {
+		commitMessage.WriteString(" <<" + src.Path)
+		target := fmt.Sprintf("/src-%d", len(cfg.params.Sources())+i)
+		args = append(args, path.Join(target, src.Path))
+		mounts = append(mounts, llb.AddMount(target, inlineFileState(src.Path, src.Data, 0644), llb.Readonly))
+	}
+
+	commitMessage.WriteString(" " + cfg.params.Dest())
 
 	args = append(args, dest)
 	if unpack {
 		args = append(args[:1], append([]string{"--unpack"}, args[1:]...)...)
 	}
 
-	platform := opt.targetPlatform
+	platform := cfg.opt.targetPlatform
 	if d.platform != nil {
 		platform = *d.platform
 	}
@@ -941,16 +1259,16 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 		llb.Args(args),
 		llb.Dir("/dest"),
 		llb.ReadonlyRootFS(),
-		dfCmd(cmdToPrint),
-		llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(opt.shlex, cmdToPrint.String(), env)), d.prefixPlatform, &platform)),
-		location(opt.sourceMap, loc),
+		dfCmd(cfg.cmdToPrint),
+		llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(cfg.opt.shlex, cfg.cmdToPrint.String(), env)), d.prefixPlatform, &platform)),
+		location(cfg.opt.sourceMap, cfg.location),
 	}
 	if d.ignoreCache {
 		runOpt = append(runOpt, llb.IgnoreCache)
 	}
 
-	if opt.llbCaps != nil {
-		if err := opt.llbCaps.Supports(pb.CapExecMetaNetwork); err == nil {
+	if cfg.opt.llbCaps != nil {
+		if err := cfg.opt.llbCaps.Supports(pb.CapExecMetaNetwork); err == nil {
 			runOpt = append(runOpt, llb.Network(llb.NetModeNone))
 		}
 	}
@@ -961,6 +1279,46 @@ func dispatchCopy(d *dispatchState, c instructions.SourcesAndDest, sourceState l
 	return commitToHistory(&d.image, commitMessage.String(), true, &d.state)
 }
 
+// splitParentsPattern splits a COPY --parents source into the directory it is
+// copied from and the pattern of the paths that are kept under the
+// destination. A "/./" component marks where the kept path starts.
+func splitParentsPattern(src string) (string, string) {
+	src = filepath.ToSlash(src)
+	if i := strings.Index(src, "/./"); i != -1 {
+		return path.Join("/", src[:i]), path.Clean(src[i+3:])
+	}
+	return "/", path.Clean(strings.TrimPrefix(path.Join("/", src), "/"))
+}
+
+// isHTTPSource returns true if the ADD source is fetched from a URL.
+func isHTTPSource(src string) bool {
+	return strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://")
+}
+
+// isGitSource returns true if the ADD source is a git repository. HTTP URLs
+// are only treated as repositories if their path ends with .git.
+func isGitSource(src string) bool {
+	if strings.HasPrefix(src, "git://") || strings.HasPrefix(src, "git@") {
+		return true
+	}
+	return isHTTPSource(src) && gitURLPathWithFragmentSuffix.MatchString(src)
+}
+
+// gitSourceState returns the state of a git source. The fragment of the URL
+// selects the branch, tag or commit to check out.
+func gitSourceState(src string, cfg copyConfig) llb.State {
+	parts := strings.SplitN(src, "#", 2)
+	ref := ""
+	if len(parts) > 1 {
+		ref = parts[1]
+	}
+	gitOpts := []llb.GitOption{dfCmd(cfg.params)}
+	if cfg.keepGitDir {
+		gitOpts = append(gitOpts, llb.KeepGitDir())
+	}
+	return llb.Git(parts[0], ref, gitOpts...)
+}
+
 func dispatchMaintainer(d *dispatchState, c *instructions.MaintainerCommand) error {
 	d.image.Author = c.Maintainer
 	return commitToHistory(&d.image, fmt.Sprintf("MAINTAINER %v", c.Maintainer), false, nil)
@@ -1083,6 +1441,13 @@ func dispatchShell(d *dispatchState, c *instructions.ShellCommand) error {
 }
 
 func dispatchArg(d *dispatchState, c *instructions.ArgCommand, metaArgs []instructions.KeyValuePairOptional, buildArgValues map[string]string) error {
+	if c.Secret != "" {
+		// the value is only read from the secret when RUN executes, so it is
+		// not part of the state env, the history or the image config
+		key := c.Args[0].Key
+		d.secretArgs = append(d.secretArgs, instructions.KeyValuePair{Key: key, Value: c.Secret})
+		return commitToHistory(&d.image, "ARG --secret="+c.Secret+" "+key, false, nil)
+	}
 	commitStrs := make([]string, 0, len(c.Args))
 	for _, arg := range c.Args {
 		buildArg := setKVValue(arg, buildArgValues)
@@ -1373,6 +1738,15 @@ func autoDetectPlatform(img Image, target specs.Platform, supported []specs.Plat
 	return target
 }
 
+// inlineFileState returns a state containing a single file created from the
+// content of a here-document.
+func inlineFileState(name, data string, mode os.FileMode) llb.State {
+	return llb.Scratch().File(
+		llb.Mkfile(path.Join("/", name), mode, []byte(data)),
+		WithInternalName("preparing inline document"),
+	)
+}
+
 func WithInternalName(name string) llb.ConstraintsOpt {
 	return llb.WithCustomName("[internal] " + name)
 }
@@ -0,0 +1,26 @@
+// +build dfargsecret
+
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/apicaps"
+	"github.com/pkg/errors"
+)
+
+func dispatchRunSecretArgs(d *dispatchState, llbCaps *apicaps.CapSet) ([]llb.RunOption, error) {
+	if len(d.secretArgs) == 0 {
+		return nil, nil
+	}
+	if llbCaps != nil {
+		if err := llbCaps.Supports(pb.CapExecSecretEnv); err != nil {
+			return nil, errors.Wrap(err, "ARG --secret is not supported")
+		}
+	}
+	out := make([]llb.RunOption, 0, len(d.secretArgs))
+	for _, arg := range d.secretArgs {
+		out = append(out, llb.AddSecret(arg.Key, llb.SecretID(arg.Value), llb.SecretAsEnv(true), llb.SecretOptional))
+	}
+	return out, nil
+}
@@ -0,0 +1,55 @@
+// +build dfargsecret
+
+package dockerfile2llb
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/appcontext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerfileSecretArg(t *testing.T) {
+	t.Parallel()
+	df := `FROM scratch
+ARG --secret=token TOKEN
+ARG VERSION=1
+RUN echo "$TOKEN"
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, img, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps:   &caps,
+		BuildArgs: map[string]string{"TOKEN": "leaked"},
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	var execs []*pb.ExecOp
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if exec := op.GetExec(); exec != nil {
+			execs = append(execs, exec)
+		}
+	}
+	require.Equal(t, 1, len(execs))
+	require.Equal(t, []*pb.SecretEnv{{ID: "token", Name: "TOKEN", Optional: true}}, execs[0].Secretenv)
+	require.Contains(t, execs[0].Meta.Env, "VERSION=1")
+	for _, e := range execs[0].Meta.Env {
+		require.NotContains(t, e, "TOKEN")
+	}
+
+	for _, e := range img.Config.Env {
+		require.NotContains(t, e, "TOKEN")
+	}
+	for _, h := range img.History {
+		require.NotContains(t, h.CreatedBy, "leaked")
+	}
+	require.Equal(t, "RUN |1 VERSION=1 /bin/sh -c echo \"$TOKEN\" # buildkit", img.History[len(img.History)-1].CreatedBy)
+
+	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte("ARG --secret=token TOKEN\nFROM scratch\n"), ConvertOpt{})
+	require.Error(t, err)
+}
@@ -0,0 +1,54 @@
+// +build dfheredoc
+
+package dockerfile2llb
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/appcontext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerfileHeredoc(t *testing.T) {
+	t.Parallel()
+	df := `FROM scratch
+ENV FOO=bar
+RUN <<EOF
+echo "$FOO"
+EOF
+COPY <<EOF /expanded
+foo=$FOO \$FOO C:\dir\
+EOF
+COPY <<"EOF" /literal
+foo=$FOO
+EOF
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	var args [][]string
+	var files []string
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if exec := op.GetExec(); exec != nil {
+			args = append(args, exec.Meta.Args)
+		}
+		if file := op.GetFile(); file != nil {
+			for _, a := range file.Actions {
+				if mkfile := a.GetMkfile(); mkfile != nil {
+					files = append(files, string(mkfile.Data))
+				}
+			}
+		}
+	}
+	require.Equal(t, [][]string{{"/bin/sh", "-c", "echo \"$FOO\"\n"}}, args)
+	require.ElementsMatch(t, []string{"foo=bar $FOO C:\\dir\\\n", "foo=$FOO\n"}, files)
+}
@@ -0,0 +1,12 @@
+// +build !dfargsecret
+
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/util/apicaps"
+)
+
+func dispatchRunSecretArgs(d *dispatchState, llbCaps *apicaps.CapSet) ([]llb.RunOption, error) {
+	return nil, nil
+}
@@ -0,0 +1,13 @@
+// +build !dfrundevice
+
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/util/apicaps"
+)
+
+func dispatchRunDevices(c *instructions.RunCommand, llbCaps *apicaps.CapSet) ([]llb.RunOption, error) {
+	return nil, nil
+}
@@ -0,0 +1,11 @@
+// +build !dfsubstitutions
+
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+)
+
+func newShellLex(escapeToken rune) *shell.Lex {
+	return shell.NewLex(escapeToken)
+}
@@ -0,0 +1,42 @@
+// +build dfrundevice
+
+package dockerfile2llb
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/appcontext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerfileRunDevice(t *testing.T) {
+	t.Parallel()
+	df := `FROM scratch
+RUN --device=/dev/fuse --device=/dev/kvm:/dev/vm:rw true
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	var devices []*pb.Device
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if exec := op.GetExec(); exec != nil {
+			devices = append(devices, exec.Devices...)
+		}
+	}
+	require.Equal(t, []*pb.Device{
+		{Source: "/dev/fuse"},
+		{Source: "/dev/kvm", Dest: "/dev/vm", Permissions: "rw"},
+	}, devices)
+
+	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte("FROM scratch\nRUN --device=dev/fuse true\n"), ConvertOpt{})
+	require.Error(t, err)
+}
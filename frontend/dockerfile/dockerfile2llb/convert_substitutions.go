@@ -0,0 +1,13 @@
+// +build dfsubstitutions
+
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+)
+
+func newShellLex(escapeToken rune) *shell.Lex {
+	lex := shell.NewLex(escapeToken)
+	lex.Substitutions = true
+	return lex
+}
@@ -1,12 +1,19 @@
 package dockerfile2llb
 
 import (
+	"context"
+	"sort"
+	"sync"
 	"testing"
 
+	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/appcontext"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func toEnvMap(args []instructions.KeyValuePairOptional, env []string) map[string]string {
@@ -188,3 +195,249 @@ COPY --from=stage1 f2 /sub/
 	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{})
 	assert.EqualError(t, err, "circular dependency detected on stage: stage0")
 }
+
+func TestDockerfileScanStages(t *testing.T) {
+	t.Parallel()
+	df := `FROM scratch AS build
+ARG BUILDKIT_SBOM_SCAN_STAGE=true
+COPY f1 /
+
+FROM scratch AS other
+ARG BUILDKIT_SBOM_SCAN_STAGE
+COPY f2 /
+
+FROM scratch
+ARG BUILDKIT_SBOM_SCAN_STAGE=true
+COPY --from=build /f1 /
+COPY --from=other /f2 /
+`
+	var stages []string
+	_, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		ScanStage: func(name string, st llb.State) {
+			stages = append(stages, name)
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"build"}, stages)
+
+	stages = nil
+	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		BuildArgs: map[string]string{"BUILDKIT_SBOM_SCAN_STAGE": "1"},
+		ScanStage: func(name string, st llb.State) {
+			stages = append(stages, name)
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"build", "other"}, stages)
+}
+
+func TestDockerfileNamedContext(t *testing.T) {
+	t.Parallel()
+	df := `FROM busybox AS base
+RUN false
+
+FROM alpine
+COPY --from=base /foo /
+COPY --from=tools /bar /
+`
+	var (
+		mu    sync.Mutex
+		names []string
+	)
+	_, img, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		ContextByName: func(ctx context.Context, name, resolveMode string, platform *specs.Platform) (*llb.State, *Image, error) {
+			mu.Lock()
+			names = append(names, name)
+			mu.Unlock()
+			switch name {
+			case "base", "tools":
+				st := llb.Local(name)
+				return &st, nil, nil
+			case "alpine":
+				st := llb.Image("alpine")
+				img := emptyImage(*platform)
+				img.Config.Env = append(img.Config.Env, "FOO=bar")
+				return &st, &img, nil
+			}
+			return nil, nil, nil
+		},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"base", "alpine", "tools"}, names)
+	require.Contains(t, img.Config.Env, "FOO=bar")
+}
+
+func TestDockerfileCopyLink(t *testing.T) {
+	t.Parallel()
+	df := `FROM scratch
+COPY --link --chown=1000:1000 foo /bar/
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	var merges, copies int
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if op.GetMerge() != nil {
+			merges++
+		}
+		if file := op.GetFile(); file != nil {
+			// the copy is done on scratch so it doesn't depend on the base
+			require.Equal(t, pb.Empty, file.Actions[0].Input)
+			copies++
+		}
+	}
+	// merging on top of scratch is a no-op
+	require.Equal(t, 0, merges)
+	require.Equal(t, 1, copies)
+
+	df = `FROM scratch
+COPY foo /
+COPY --link foo /bar/
+`
+	st, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err = st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	merges = 0
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if merge := op.GetMerge(); merge != nil {
+			require.Equal(t, 2, len(merge.Inputs))
+			merges++
+		}
+	}
+	require.Equal(t, 1, merges)
+
+	df = `FROM scratch
+COPY --link --chown=user foo /bar/
+`
+	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--link does not support user or group names")
+
+	df = `FROM scratch
+COPY --link foo /bar/
+`
+	all := pb.Caps.All()
+	noMerge := all[:0]
+	for _, c := range all {
+		if c.ID != string(pb.CapMergeOp) {
+			noMerge = append(noMerge, c)
+		}
+	}
+	noMergeCaps := pb.Caps.CapSet(noMerge)
+	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &noMergeCaps,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--link is not supported")
+
+	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps:   &caps,
+		BuildArgs: map[string]string{"BUILDKIT_DISABLE_FILEOP": "1"},
+	})
+	require.EqualError(t, err, "--link is not supported")
+}
+
+func TestDockerfileAddRemote(t *testing.T) {
+	t.Parallel()
+	df := `FROM scratch
+ADD --keep-git-dir https://github.com/moby/buildkit.git#v0.8.0 /src
+ADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d https://example.com/foo.tar.gz /
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	sources := map[string]map[string]string{}
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if src := op.GetSource(); src != nil {
+			sources[src.Identifier] = src.Attrs
+		}
+	}
+	git, ok := sources["git://github.com/moby/buildkit.git#v0.8.0"]
+	require.True(t, ok)
+	require.Equal(t, "true", git[pb.AttrKeepGitDir])
+
+	http, ok := sources["https://example.com/foo.tar.gz"]
+	require.True(t, ok)
+	require.Equal(t, "sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d", http[pb.AttrHTTPChecksum])
+
+	for _, df := range []string{
+		"FROM scratch\nADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d foo /\n",
+		"FROM scratch\nADD --checksum=foo https://example.com/foo /\n",
+		"FROM scratch\nADD --keep-git-dir https://example.com/foo /\n",
+	} {
+		_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+			LLBCaps: &caps,
+		})
+		require.Error(t, err, df)
+	}
+}
+
+func TestDockerfileCopyParentsExclude(t *testing.T) {
+	t.Parallel()
+	df := `FROM scratch
+COPY --parents --exclude=*.md ./services/*/package.json /app/
+COPY --parents src/./lib/*.go /lib/
+COPY --exclude=*.md --exclude=*.txt docs /docs/
+`
+	caps := pb.Caps.CapSet(pb.Caps.All())
+	st, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		LLBCaps: &caps,
+	})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	var copies []*pb.FileActionCopy
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if file := op.GetFile(); file != nil {
+			for _, a := range file.Actions {
+				if c := a.GetCopy(); c != nil {
+					copies = append(copies, c)
+				}
+			}
+		}
+	}
+	require.Equal(t, 3, len(copies))
+	sort.Slice(copies, func(i, j int) bool {
+		return copies[i].Dest < copies[j].Dest
+	})
+
+	require.Equal(t, "/", copies[0].Src)
+	require.Equal(t, []string{"services/*/package.json"}, copies[0].IncludePatterns)
+	require.Equal(t, []string{"*.md"}, copies[0].ExcludePatterns)
+
+	require.Equal(t, "/docs", copies[1].Src)
+	require.Equal(t, 0, len(copies[1].IncludePatterns))
+	require.Equal(t, []string{"*.md", "*.txt"}, copies[1].ExcludePatterns)
+
+	require.Equal(t, "/src", copies[2].Src)
+	require.Equal(t, []string{"lib/*.go"}, copies[2].IncludePatterns)
+}
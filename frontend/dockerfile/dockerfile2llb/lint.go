@@ -0,0 +1,67 @@
+package dockerfile2llb
+
+import (
+	"bytes"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/pkg/errors"
+)
+
+const (
+	keyCheck      = "check"
+	checkBuildArg = "BUILDKIT_DOCKERFILE_CHECK"
+)
+
+// LintConfig returns the configuration set with the check directive of the
+// Dockerfile, or with the BUILDKIT_DOCKERFILE_CHECK build argument that
+// replaces it. ok is false if neither of them is set.
+func LintConfig(dt []byte, buildArgs map[string]string) (cfg *linter.Config, ok bool, err error) {
+	if v, ok := buildArgs[checkBuildArg]; ok {
+		cfg, err := linter.ParseConfig(v)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "invalid %s build argument", checkBuildArg)
+		}
+		return cfg, true, nil
+	}
+	d, ok := ParseDirectives(bytes.NewReader(dt))[keyCheck]
+	if !ok {
+		return &linter.Config{}, false, nil
+	}
+	cfg, err = linter.ParseConfig(d.Value)
+	if err != nil {
+		return nil, false, parser.WithLocation(errors.Wrap(err, "invalid check directive"), d.Location)
+	}
+	return cfg, true, nil
+}
+
+// Lint checks the Dockerfile with the rules of the linter package. The
+// configuration returned by LintConfig selects the rules that are skipped and
+// whether the warnings should fail the build.
+func Lint(dt []byte, opt ConvertOpt) ([]linter.Warning, *linter.Config, error) {
+	cfg, _, err := LintConfig(dt, opt.BuildArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dockerfile, err := parser.Parse(bytes.NewReader(dt))
+	if err != nil {
+		return nil, nil, err
+	}
+	stages, metaArgs, err := instructions.Parse(dockerfile.AST)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lint(dockerfile, stages, metaArgs, *cfg, opt), cfg, nil
+}
+
+// lint runs the checks over the parsed Dockerfile. It needs to be called
+// before the stages are modified by the conversion.
+func lint(dockerfile *parser.Result, stages []instructions.Stage, metaArgs []instructions.ArgCommand, cfg linter.Config, opt ConvertOpt) []linter.Warning {
+	return linter.Check(stages, metaArgs, linter.Opt{
+		Config:      cfg,
+		BuildArgs:   opt.BuildArgs,
+		EscapeToken: dockerfile.EscapeToken,
+	})
+}
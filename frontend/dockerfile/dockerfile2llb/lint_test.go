@@ -0,0 +1,64 @@
+package dockerfile2llb
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+	"github.com/moby/buildkit/util/appcontext"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	df := `# check=skip=MaintainerDeprecated;error=true
+FROM scratch AS Base
+MAINTAINER me@example.com
+`
+	warnings, cfg, err := Lint([]byte(df), ConvertOpt{})
+	require.NoError(t, err)
+	require.True(t, cfg.Error)
+	require.Equal(t, 1, len(warnings))
+	require.Equal(t, linter.RuleStageNameCasing.Name, warnings[0].RuleName)
+	require.Equal(t, 2, warnings[0].Location[0].Start.Line)
+
+	_, _, err = Lint([]byte("# check=error=maybe\nFROM scratch\n"), ConvertOpt{})
+	require.Error(t, err)
+
+	_, ok, err := LintConfig([]byte("FROM scratch\n"), nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	cfg, ok, err = LintConfig([]byte("# check=error=true\nFROM scratch\n"), map[string]string{"BUILDKIT_DOCKERFILE_CHECK": "skip=UnusedBuildArg"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.False(t, cfg.Error)
+	require.Equal(t, []string{"UnusedBuildArg"}, cfg.SkipRules)
+}
+
+func TestConvertLint(t *testing.T) {
+	t.Parallel()
+
+	df := `FROM scratch AS Base
+MAINTAINER me@example.com
+`
+	var warnings []linter.Warning
+	_, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		Lint: func(w []linter.Warning) error {
+			warnings = w
+			return nil
+		},
+		LintConfig: linter.Config{SkipRules: []string{linter.RuleStageNameCasing.Name}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(warnings))
+	require.Equal(t, linter.RuleMaintainerDeprecated.Name, warnings[0].RuleName)
+
+	_, _, err = Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{
+		Lint: func(w []linter.Warning) error {
+			return errors.New("lint violation")
+		},
+	})
+	require.EqualError(t, err, "lint violation")
+}
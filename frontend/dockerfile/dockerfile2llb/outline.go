@@ -0,0 +1,367 @@
+package dockerfile2llb
+
+import (
+	"bytes"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+// Dockerfile2Outline returns the build args, secrets, SSH sockets and cache
+// mounts used by the target stage and the stages it depends on. The
+// Dockerfile is only parsed, no base images are resolved.
+func Dockerfile2Outline(dt []byte, opt ConvertOpt) (*subrequests.Outline, error) {
+	p, err := parseForInfo(dt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	target := len(p.stages) - 1
+	if opt.Target != "" {
+		i, ok := p.stageIndex(opt.Target)
+		if !ok {
+			return nil, errors.Errorf("target stage %s could not be found", opt.Target)
+		}
+		target = i
+	}
+
+	reachable := map[int]struct{}{}
+	var visit func(i int) error
+	visit = func(i int) error {
+		if _, ok := reachable[i]; ok {
+			return nil
+		}
+		reachable[i] = struct{}{}
+		deps, err := p.stageDeps(i)
+		if err != nil {
+			return err
+		}
+		for _, d := range deps {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(target); err != nil {
+		return nil, err
+	}
+
+	st := p.stages[target]
+	out := &subrequests.Outline{
+		Name:        st.Name,
+		Description: st.Comment,
+		Sources:     [][]byte{dt},
+	}
+
+	// meta args are only listed if a reachable stage uses them in FROM or
+	// declares them again
+	usedMeta := map[string]struct{}{}
+	var stageArgs []subrequests.OutlineArg
+	seenArgs := map[string]struct{}{}
+	seenSecrets := map[string]int{}
+	seenSSH := map[string]int{}
+	seenCache := map[string]struct{}{}
+
+	for i, st := range p.stages {
+		if _, ok := reachable[i]; !ok {
+			continue
+		}
+		for _, w := range []string{st.BaseName, st.Platform} {
+			_, unmatched, err := p.shlex.ProcessWordWithUnmatched(w, map[string]string{})
+			if err != nil {
+				return nil, parser.WithLocation(err, st.Location)
+			}
+			for k := range unmatched {
+				usedMeta[k] = struct{}{}
+			}
+		}
+
+		for _, cmd := range st.Commands {
+			switch c := cmd.(type) {
+			case *instructions.ArgCommand:
+				if c.Secret != "" {
+					if _, ok := seenSecrets[c.Secret]; !ok {
+						seenSecrets[c.Secret] = len(out.Secrets)
+						out.Secrets = append(out.Secrets, subrequests.OutlineSecret{
+							ID:       c.Secret,
+							Location: toSourceLocation(c.Location()),
+						})
+					}
+					continue
+				}
+				for _, a := range c.Args {
+					if _, ok := p.builtinArgs[a.Key]; ok {
+						continue
+					}
+					value := a.ValueString()
+					if _, ok := p.metaArgs[a.Key]; ok {
+						usedMeta[a.Key] = struct{}{}
+						if a.Value == nil {
+							// inherits the value and description of the
+							// global arg unless it is described again
+							if a.Comment == "" {
+								continue
+							}
+							value = p.metaDefaults[a.Key]
+						}
+					}
+					if _, ok := seenArgs[a.Key]; ok {
+						continue
+					}
+					seenArgs[a.Key] = struct{}{}
+					stageArgs = append(stageArgs, subrequests.OutlineArg{
+						Name:        a.Key,
+						Description: a.Comment,
+						Value:       value,
+						Location:    toSourceLocation(c.Location()),
+					})
+				}
+			case *instructions.RunCommand:
+				for _, m := range instructions.GetMounts(c) {
+					switch m.Type {
+					case instructions.MountTypeSecret:
+						id := secretID(m)
+						if j, ok := seenSecrets[id]; ok {
+							out.Secrets[j].Required = out.Secrets[j].Required || m.Required
+							continue
+						}
+						seenSecrets[id] = len(out.Secrets)
+						out.Secrets = append(out.Secrets, subrequests.OutlineSecret{
+							ID:       id,
+							Required: m.Required,
+							Location: toSourceLocation(c.Location()),
+						})
+					case instructions.MountTypeSSH:
+						id := m.CacheID
+						if id == "" {
+							id = "default"
+						}
+						if j, ok := seenSSH[id]; ok {
+							out.SSH[j].Required = out.SSH[j].Required || m.Required
+							continue
+						}
+						seenSSH[id] = len(out.SSH)
+						out.SSH = append(out.SSH, subrequests.OutlineSSH{
+							ID:       id,
+							Required: m.Required,
+							Location: toSourceLocation(c.Location()),
+						})
+					case instructions.MountTypeCache:
+						id := m.CacheID
+						if id == "" {
+							id = path.Clean(m.Target)
+						}
+						if _, ok := seenCache[id]; ok {
+							continue
+						}
+						seenCache[id] = struct{}{}
+						out.Cache = append(out.Cache, subrequests.OutlineCache{
+							ID:       id,
+							Target:   m.Target,
+							Location: toSourceLocation(c.Location()),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	for _, cmd := range p.metaArgCmds {
+		for _, a := range cmd.Args {
+			if _, ok := usedMeta[a.Key]; !ok {
+				continue
+			}
+			if _, ok := seenArgs[a.Key]; ok {
+				continue
+			}
+			seenArgs[a.Key] = struct{}{}
+			out.Args = append(out.Args, subrequests.OutlineArg{
+				Name:        a.Key,
+				Description: a.Comment,
+				Value:       p.metaDefaults[a.Key],
+				Location:    toSourceLocation(cmd.Location()),
+			})
+		}
+	}
+	out.Args = append(out.Args, stageArgs...)
+
+	return out, nil
+}
+
+// ListTargets returns all the stages of the Dockerfile that can be built with
+// the target option. The last stage is the default target.
+func ListTargets(dt []byte) (*subrequests.List, error) {
+	p, err := parseForInfo(dt, ConvertOpt{})
+	if err != nil {
+		return nil, err
+	}
+
+	l := &subrequests.List{
+		Targets: []subrequests.Target{},
+		Sources: [][]byte{dt},
+	}
+	for i, st := range p.stages {
+		base, err := p.shlex.ProcessWordWithMap(st.BaseName, p.metaDefaults)
+		if err != nil {
+			base = st.BaseName
+		}
+		l.Targets = append(l.Targets, subrequests.Target{
+			Name:        st.Name,
+			Default:     i == len(p.stages)-1,
+			Description: st.Comment,
+			Base:        base,
+			Platform:    st.Platform,
+			Location:    toSourceLocation(st.Location),
+		})
+	}
+	return l, nil
+}
+
+type parsedInfo struct {
+	stages       []instructions.Stage
+	metaArgCmds  []instructions.ArgCommand
+	shlex        *shell.Lex
+	metaArgs     map[string]struct{}
+	metaDefaults map[string]string
+	metaValues   map[string]string
+	builtinArgs  map[string]struct{}
+	stagesByName map[string]int
+}
+
+func parseForInfo(dt []byte, opt ConvertOpt) (*parsedInfo, error) {
+	if len(dt) == 0 {
+		return nil, errors.Errorf("the Dockerfile cannot be empty")
+	}
+	dockerfile, err := parser.Parse(bytes.NewReader(dt))
+	if err != nil {
+		return nil, err
+	}
+	stages, metaArgs, err := instructions.Parse(dockerfile.AST)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 {
+		return nil, errors.Errorf("the Dockerfile has no stages")
+	}
+
+	p := &parsedInfo{
+		stages:       stages,
+		metaArgCmds:  metaArgs,
+		shlex:        newShellLex(dockerfile.EscapeToken),
+		metaArgs:     map[string]struct{}{},
+		metaDefaults: map[string]string{},
+		metaValues:   map[string]string{},
+		builtinArgs:  map[string]struct{}{},
+		stagesByName: map[string]int{},
+	}
+
+	for _, kv := range getPlatformArgs(buildPlatformOpt(&opt)) {
+		p.builtinArgs[kv.Key] = struct{}{}
+		p.metaDefaults[kv.Key] = kv.ValueString()
+		kv = setKVValue(kv, opt.BuildArgs)
+		p.metaValues[kv.Key] = kv.ValueString()
+	}
+	for _, cmd := range metaArgs {
+		for _, a := range cmd.Args {
+			p.metaArgs[a.Key] = struct{}{}
+			v, _ := p.shlex.ProcessWordWithMap(a.ValueString(), p.metaDefaults)
+			p.metaDefaults[a.Key] = v
+			if bv, ok := opt.BuildArgs[a.Key]; ok {
+				v = bv
+			} else {
+				v, _ = p.shlex.ProcessWordWithMap(a.ValueString(), p.metaValues)
+			}
+			p.metaValues[a.Key] = v
+		}
+	}
+	for i, st := range stages {
+		if st.Name != "" {
+			p.stagesByName[strings.ToLower(st.Name)] = i
+		}
+	}
+	return p, nil
+}
+
+func (p *parsedInfo) stageIndex(name string) (int, bool) {
+	i, ok := p.stagesByName[strings.ToLower(name)]
+	return i, ok
+}
+
+// stageDeps returns the indexes of the stages that stage i uses as its base,
+// in COPY --from or in RUN --mount from.
+func (p *parsedInfo) stageDeps(i int) ([]int, error) {
+	st := p.stages[i]
+	var deps []int
+	base, err := p.shlex.ProcessWordWithMap(st.BaseName, p.metaValues)
+	if err != nil {
+		return nil, parser.WithLocation(err, st.Location)
+	}
+	if j, ok := p.stageIndex(base); ok && j < i {
+		deps = append(deps, j)
+	}
+	for _, cmd := range st.Commands {
+		switch c := cmd.(type) {
+		case *instructions.CopyCommand:
+			if c.From == "" {
+				continue
+			}
+			if j, err := strconv.Atoi(c.From); err == nil {
+				if j < 0 || j >= len(p.stages) {
+					return nil, parser.WithLocation(errors.Errorf("invalid stage index %d", j), c.Location())
+				}
+				deps = append(deps, j)
+			} else if j, ok := p.stageIndex(c.From); ok {
+				deps = append(deps, j)
+			}
+		case *instructions.RunCommand:
+			for _, m := range instructions.GetMounts(c) {
+				if m.From == "" {
+					continue
+				}
+				if j, ok := p.stageIndex(m.From); ok {
+					deps = append(deps, j)
+				}
+			}
+		}
+	}
+	return deps, nil
+}
+
+func secretID(m *instructions.Mount) string {
+	id := m.CacheID
+	if m.Source != "" {
+		id = m.Source
+	}
+	if id == "" {
+		id = path.Base(m.Target)
+	}
+	return id
+}
+
+func toSourceLocation(ranges []parser.Range) *subrequests.Location {
+	if len(ranges) == 0 {
+		return nil
+	}
+	loc := &subrequests.Location{}
+	for _, r := range ranges {
+		loc.Ranges = append(loc.Ranges, &pb.Range{
+			Start: pb.Position{
+				Line:      int32(r.Start.Line),
+				Character: int32(r.Start.Character),
+			},
+			End: pb.Position{
+				Line:      int32(r.End.Line),
+				Character: int32(r.End.Character),
+			},
+		})
+	}
+	return loc
+}
@@ -0,0 +1,92 @@
+package dockerfile2llb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerfileOutline(t *testing.T) {
+	t.Parallel()
+
+	df := `# BASE the base stage
+ARG BASE=scratch
+# UNUSED is never used
+ARG UNUSED=foo
+
+# build compiles the code
+FROM ${BASE} AS build
+# VERSION the version to build
+ARG VERSION=1.0
+RUN --mount=type=secret,id=token --mount=type=cache,target=/root/.cache true
+
+FROM scratch AS other
+ARG OTHER
+RUN --mount=type=ssh true
+
+# final is the release image
+FROM scratch AS final
+ARG TARGETPLATFORM
+COPY --from=build /out /
+RUN --mount=type=secret,id=token,required true
+`
+	o, err := Dockerfile2Outline([]byte(df), ConvertOpt{})
+	require.NoError(t, err)
+
+	require.Equal(t, "final", o.Name)
+	require.Equal(t, "is the release image", o.Description)
+
+	require.Equal(t, 2, len(o.Args))
+	require.Equal(t, "BASE", o.Args[0].Name)
+	require.Equal(t, "scratch", o.Args[0].Value)
+	require.Equal(t, "the base stage", o.Args[0].Description)
+	require.Equal(t, int32(2), o.Args[0].Location.Ranges[0].Start.Line)
+	require.Equal(t, "VERSION", o.Args[1].Name)
+	require.Equal(t, "1.0", o.Args[1].Value)
+	require.Equal(t, "the version to build", o.Args[1].Description)
+
+	require.Equal(t, 1, len(o.Secrets))
+	require.Equal(t, "token", o.Secrets[0].ID)
+	require.True(t, o.Secrets[0].Required)
+	require.Equal(t, int32(10), o.Secrets[0].Location.Ranges[0].Start.Line)
+
+	require.Equal(t, 0, len(o.SSH))
+
+	require.Equal(t, 1, len(o.Cache))
+	require.Equal(t, "/root/.cache", o.Cache[0].ID)
+
+	o, err = Dockerfile2Outline([]byte(df), ConvertOpt{Target: "other"})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(o.Args))
+	require.Equal(t, "OTHER", o.Args[0].Name)
+	require.Equal(t, 1, len(o.SSH))
+	require.Equal(t, "default", o.SSH[0].ID)
+	require.Equal(t, 0, len(o.Secrets))
+
+	_, err = Dockerfile2Outline([]byte(df), ConvertOpt{Target: "missing"})
+	require.Error(t, err)
+}
+
+func TestListTargets(t *testing.T) {
+	t.Parallel()
+
+	df := `ARG BASE=scratch
+# build compiles the code
+FROM ${BASE} AS build
+
+FROM --platform=$BUILDPLATFORM scratch
+`
+	l, err := ListTargets([]byte(df))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(l.Targets))
+
+	require.Equal(t, "build", l.Targets[0].Name)
+	require.Equal(t, "compiles the code", l.Targets[0].Description)
+	require.Equal(t, "scratch", l.Targets[0].Base)
+	require.False(t, l.Targets[0].Default)
+	require.Equal(t, int32(3), l.Targets[0].Location.Ranges[0].Start.Line)
+
+	require.Equal(t, "", l.Targets[1].Name)
+	require.True(t, l.Targets[1].Default)
+	require.Equal(t, "$BUILDPLATFORM", l.Targets[1].Platform)
+}
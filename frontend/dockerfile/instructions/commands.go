@@ -76,6 +76,12 @@ type SupportsSingleWordExpansion interface {
 	Expand(expander SingleWordExpander) error
 }
 
+// SupportsSingleWordExpansionRaw interface marks a command as supporting
+// variable expansion that preserves quotes, used for here-documents
+type SupportsSingleWordExpansionRaw interface {
+	ExpandRaw(expander SingleWordExpander) error
+}
+
 // PlatformSpecific adds platform checks to a command
 type PlatformSpecific interface {
 	CheckPlatform(platform string) error
@@ -117,7 +123,8 @@ func expandSliceInPlace(values []string, expander SingleWordExpander) error {
 // EnvCommand : ENV key1 value1 [keyN valueN...]
 type EnvCommand struct {
 	withNameAndCode
-	Env KeyValuePairs // kvp slice instead of map to preserve ordering
+	Env    KeyValuePairs // kvp slice instead of map to preserve ordering
+	Legacy bool          // uses the `ENV name value` form
 }
 
 // Expand variables
@@ -154,6 +161,7 @@ func NewLabelCommand(k string, v string, NoExp bool) *LabelCommand {
 type LabelCommand struct {
 	withNameAndCode
 	Labels   KeyValuePairs // kvp slice instead of map to preserve ordering
+	Legacy   bool          // uses the `LABEL name value` form
 	noExpand bool
 }
 
@@ -180,6 +188,27 @@ func (s SourcesAndDest) Dest() string {
 	return s[len(s)-1]
 }
 
+// SourceContent represents an inline source file defined by a here-document
+type SourceContent struct {
+	Path   string // name of the file
+	Data   string // content of the file
+	Expand bool   // whether variables in the content are expanded
+}
+
+func expandSourceContentsInPlace(contents []SourceContent, expander SingleWordExpander) error {
+	for i, c := range contents {
+		if !c.Expand {
+			continue
+		}
+		data, err := expander(c.Data)
+		if err != nil {
+			return err
+		}
+		contents[i].Data = data
+	}
+	return nil
+}
+
 // AddCommand : ADD foo /path
 //
 // Add the file 'foo' to '/path'. Tarball and Remote URL (http, https) handling
@@ -188,8 +217,12 @@ func (s SourcesAndDest) Dest() string {
 type AddCommand struct {
 	withNameAndCode
 	SourcesAndDest
-	Chown string
-	Chmod string
+	SourceContents []SourceContent
+	Chown          string
+	Chmod          string
+	Link           bool
+	Checksum       string
+	KeepGitDir     bool
 }
 
 // Expand variables
@@ -199,9 +232,20 @@ func (c *AddCommand) Expand(expander SingleWordExpander) error {
 		return err
 	}
 	c.Chown = expandedChown
+
+	expandedChecksum, err := expander(c.Checksum)
+	if err != nil {
+		return err
+	}
+	c.Checksum = expandedChecksum
 	return expandSliceInPlace(c.SourcesAndDest, expander)
 }
 
+// ExpandRaw expands variables in the here-document sources
+func (c *AddCommand) ExpandRaw(expander SingleWordExpander) error {
+	return expandSourceContentsInPlace(c.SourceContents, expander)
+}
+
 // CopyCommand : COPY foo /path
 //
 // Same as 'ADD' but without the tar and remote url handling.
@@ -209,9 +253,13 @@ func (c *AddCommand) Expand(expander SingleWordExpander) error {
 type CopyCommand struct {
 	withNameAndCode
 	SourcesAndDest
-	From  string
-	Chown string
-	Chmod string
+	SourceContents []SourceContent
+	From           string
+	Chown          string
+	Chmod          string
+	Link           bool
+	Parents        bool
+	Excludes       []string
 }
 
 // Expand variables
@@ -221,9 +269,17 @@ func (c *CopyCommand) Expand(expander SingleWordExpander) error {
 		return err
 	}
 	c.Chown = expandedChown
+	if err := expandSliceInPlace(c.Excludes, expander); err != nil {
+		return err
+	}
 	return expandSliceInPlace(c.SourcesAndDest, expander)
 }
 
+// ExpandRaw expands variables in the here-document sources
+func (c *CopyCommand) ExpandRaw(expander SingleWordExpander) error {
+	return expandSourceContentsInPlace(c.SourceContents, expander)
+}
+
 // OnbuildCommand : ONBUILD <some other command>
 type OnbuildCommand struct {
 	withNameAndCode
@@ -249,9 +305,17 @@ func (c *WorkdirCommand) Expand(expander SingleWordExpander) error {
 	return nil
 }
 
+// ShellInlineFile represents a here-document passed to a shell command
+type ShellInlineFile struct {
+	Name  string
+	Data  string
+	Chomp bool
+}
+
 // ShellDependantCmdLine represents a cmdline optionally prepended with the shell
 type ShellDependantCmdLine struct {
 	CmdLine      strslice.StrSlice
+	Files        []ShellInlineFile
 	PrependShell bool
 }
 
@@ -381,7 +445,12 @@ func (c *StopSignalCommand) CheckPlatform(platform string) error {
 // Dockerfile author may optionally set a default value of this variable.
 type ArgCommand struct {
 	withNameAndCode
+	withExternalData
 	Args []KeyValuePairOptional
+	// Secret is the ID of the secret that provides the value of the arg.
+	// The value is only set as an environment variable for RUN. Only set
+	// with the dfargsecret build tag.
+	Secret string
 }
 
 // Expand variables
@@ -415,6 +484,7 @@ type ShellCommand struct {
 // Stage represents a single stage in a multi-stage build
 type Stage struct {
 	Name       string
+	OrigName   string
 	Commands   []Command
 	BaseName   string
 	SourceCode string
@@ -0,0 +1,43 @@
+// +build dfargsecret
+
+package instructions
+
+import (
+	"github.com/pkg/errors"
+)
+
+var argSecretKey = "dockerfile/arg/secret"
+
+func init() {
+	parseArgPreHooks = append(parseArgPreHooks, argSecretPreHook)
+	parseArgPostHooks = append(parseArgPostHooks, argSecretPostHook)
+}
+
+func argSecretPreHook(cmd *ArgCommand, req parseRequest) error {
+	st := &argSecretState{}
+	st.flag = req.flags.AddString("secret", "")
+	cmd.setExternalValue(argSecretKey, st)
+	return nil
+}
+
+func argSecretPostHook(cmd *ArgCommand, req parseRequest) error {
+	st, ok := cmd.getExternalValue(argSecretKey).(*argSecretState)
+	if !ok || st == nil {
+		return errors.Errorf("no arg secret state")
+	}
+	if st.flag.Value == "" {
+		return nil
+	}
+	if len(cmd.Args) != 1 {
+		return errors.New("ARG --secret requires exactly one argument")
+	}
+	if cmd.Args[0].Value != nil {
+		return errors.Errorf("ARG --secret can't set a default value for %s", cmd.Args[0].Key)
+	}
+	cmd.Secret = st.flag.Value
+	return nil
+}
+
+type argSecretState struct {
+	flag *Flag
+}
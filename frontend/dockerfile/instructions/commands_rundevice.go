@@ -0,0 +1,90 @@
+// +build dfrundevice
+
+package instructions
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var devicesKey = "dockerfile/run/devices"
+
+func init() {
+	parseRunPreHooks = append(parseRunPreHooks, runDevicePreHook)
+	parseRunPostHooks = append(parseRunPostHooks, runDevicePostHook)
+}
+
+// Device is a host device exposed with RUN --device
+type Device struct {
+	Source      string
+	Target      string
+	Permissions string
+}
+
+func runDevicePreHook(cmd *RunCommand, req parseRequest) error {
+	st := &devicesState{}
+	st.flag = req.flags.AddStrings("device")
+	cmd.setExternalValue(devicesKey, st)
+	return nil
+}
+
+func runDevicePostHook(cmd *RunCommand, req parseRequest) error {
+	st, ok := cmd.getExternalValue(devicesKey).(*devicesState)
+	if !ok || st == nil {
+		return errors.Errorf("no devices state")
+	}
+	for _, v := range st.flag.StringValues {
+		d, err := ParseDevice(v)
+		if err != nil {
+			return err
+		}
+		st.devices = append(st.devices, d)
+	}
+	return nil
+}
+
+// ParseDevice parses a device in the source[:target][:permissions] format
+// used by docker run --device.
+func ParseDevice(value string) (*Device, error) {
+	parts := strings.Split(value, ":")
+	d := &Device{Source: parts[0]}
+	switch len(parts) {
+	case 1:
+	case 2:
+		if isDevicePermissions(parts[1]) {
+			d.Permissions = parts[1]
+		} else {
+			d.Target = parts[1]
+		}
+	case 3:
+		d.Target = parts[1]
+		d.Permissions = parts[2]
+	default:
+		return nil, errors.Errorf("invalid device %q", value)
+	}
+	if !path.IsAbs(d.Source) {
+		return nil, errors.Errorf("invalid device %q, path must be absolute", value)
+	}
+	if d.Target != "" && !path.IsAbs(d.Target) {
+		return nil, errors.Errorf("invalid device %q, target must be absolute", value)
+	}
+	if d.Permissions != "" && !isDevicePermissions(d.Permissions) {
+		return nil, errors.Errorf("invalid device permissions %q", d.Permissions)
+	}
+	return d, nil
+}
+
+func isDevicePermissions(s string) bool {
+	return s != "" && strings.Trim(s, "rwm") == ""
+}
+
+func GetDevices(cmd *RunCommand) []*Device {
+	return cmd.getExternalValue(devicesKey).(*devicesState).devices
+}
+
+type devicesState struct {
+	flag    *Flag
+	devices []*Device
+}
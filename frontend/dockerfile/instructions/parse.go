@@ -23,11 +23,15 @@ type parseRequest struct {
 	original   string
 	location   []parser.Range
 	comments   []string
+	heredocs   []parser.Heredoc
 }
 
 var parseRunPreHooks []func(*RunCommand, parseRequest) error
 var parseRunPostHooks []func(*RunCommand, parseRequest) error
 
+var parseArgPreHooks []func(*ArgCommand, parseRequest) error
+var parseArgPostHooks []func(*ArgCommand, parseRequest) error
+
 func nodeArgs(node *parser.Node) []string {
 	result := []string{}
 	for ; node.Next != nil; node = node.Next {
@@ -52,6 +56,7 @@ func newParseRequestFromNode(node *parser.Node) parseRequest {
 		flags:      NewBFlagsWithArgs(node.Flags),
 		location:   node.Location(),
 		comments:   node.PrevComment,
+		heredocs:   node.Heredocs,
 	}
 }
 
@@ -149,6 +154,9 @@ func Parse(ast *parser.Node) (stages []Stage, metaArgs []ArgCommand, err error)
 		if len(stages) == 0 {
 			// meta arg case
 			if a, isArg := cmd.(*ArgCommand); isArg {
+				if a.Secret != "" {
+					return nil, nil, parser.WithLocation(errors.New("ARG --secret can only be used in a build stage"), n.Location())
+				}
 				metaArgs = append(metaArgs, *a)
 				continue
 			}
@@ -201,6 +209,7 @@ func parseEnv(req parseRequest) (*EnvCommand, error) {
 	}
 	return &EnvCommand{
 		Env:             envs,
+		Legacy:          req.attributes["legacy"],
 		withNameAndCode: newWithNameAndCode(req),
 	}, nil
 }
@@ -232,24 +241,83 @@ func parseLabel(req parseRequest) (*LabelCommand, error) {
 
 	return &LabelCommand{
 		Labels:          labels,
+		Legacy:          req.attributes["legacy"],
 		withNameAndCode: newWithNameAndCode(req),
 	}, nil
 }
 
+// parseSourcesAndDest splits the arguments of ADD and COPY into the sources
+// read from a filesystem and the inline sources defined by here-documents.
+// Arguments that look like here-documents are paths if the parser did not
+// read a here-document for them, e.g. when here-documents are disabled.
+func parseSourcesAndDest(req parseRequest, command string) (SourcesAndDest, []SourceContent, error) {
+	heredocs := make(map[string]parser.Heredoc, len(req.heredocs))
+	for _, heredoc := range req.heredocs {
+		heredocs[heredoc.Name] = heredoc
+	}
+	parseHeredoc := func(arg string) (*parser.Heredoc, string) {
+		heredoc := parser.MustParseHeredoc(arg)
+		if heredoc == nil {
+			return nil, ""
+		}
+		doc, ok := heredocs[heredoc.Name]
+		if !ok {
+			return nil, ""
+		}
+		return heredoc, doc.Content
+	}
+
+	srcs := req.args[:len(req.args)-1]
+	dest := req.args[len(req.args)-1]
+	if heredoc, _ := parseHeredoc(dest); heredoc != nil {
+		return nil, nil, errBadHeredoc(command, "a destination")
+	}
+
+	var paths []string
+	var contents []SourceContent
+	for _, src := range srcs {
+		heredoc, data := parseHeredoc(src)
+		if heredoc == nil {
+			paths = append(paths, src)
+			continue
+		}
+		if heredoc.Chomp {
+			data = parser.ChompHeredocContent(data)
+		}
+		contents = append(contents, SourceContent{
+			Path:   heredoc.Name,
+			Data:   data,
+			Expand: heredoc.Expand,
+		})
+	}
+	return SourcesAndDest(append(paths, dest)), contents, nil
+}
+
 func parseAdd(req parseRequest) (*AddCommand, error) {
 	if len(req.args) < 2 {
 		return nil, errNoDestinationArgument("ADD")
 	}
 	flChown := req.flags.AddString("chown", "")
 	flChmod := req.flags.AddString("chmod", "")
+	flLink := req.flags.AddBool("link", false)
+	flChecksum := req.flags.AddString("checksum", "")
+	flKeepGitDir := req.flags.AddBool("keep-git-dir", false)
 	if err := req.flags.Parse(); err != nil {
 		return nil, err
 	}
+	sd, contents, err := parseSourcesAndDest(req, "ADD")
+	if err != nil {
+		return nil, err
+	}
 	return &AddCommand{
-		SourcesAndDest:  SourcesAndDest(req.args),
+		SourcesAndDest:  sd,
+		SourceContents:  contents,
 		withNameAndCode: newWithNameAndCode(req),
 		Chown:           flChown.Value,
 		Chmod:           flChmod.Value,
+		Link:            flLink.IsTrue(),
+		Checksum:        flChecksum.Value,
+		KeepGitDir:      flKeepGitDir.IsTrue(),
 	}, nil
 }
 
@@ -260,15 +328,26 @@ func parseCopy(req parseRequest) (*CopyCommand, error) {
 	flChown := req.flags.AddString("chown", "")
 	flFrom := req.flags.AddString("from", "")
 	flChmod := req.flags.AddString("chmod", "")
+	flLink := req.flags.AddBool("link", false)
+	flParents := req.flags.AddBool("parents", false)
+	flExcludes := req.flags.AddStrings("exclude")
 	if err := req.flags.Parse(); err != nil {
 		return nil, err
 	}
+	sd, contents, err := parseSourcesAndDest(req, "COPY")
+	if err != nil {
+		return nil, err
+	}
 	return &CopyCommand{
-		SourcesAndDest:  SourcesAndDest(req.args),
+		SourcesAndDest:  sd,
+		SourceContents:  contents,
 		From:            flFrom.Value,
 		withNameAndCode: newWithNameAndCode(req),
 		Chown:           flChown.Value,
 		Chmod:           flChmod.Value,
+		Link:            flLink.IsTrue(),
+		Parents:         flParents.IsTrue(),
+		Excludes:        flExcludes.StringValues,
 	}, nil
 }
 
@@ -283,10 +362,16 @@ func parseFrom(req parseRequest) (*Stage, error) {
 		return nil, err
 	}
 
+	var origName string
+	if stageName != "" {
+		origName = req.args[2]
+	}
+
 	code := strings.TrimSpace(req.original)
 	return &Stage{
 		BaseName:   req.args[0],
 		Name:       stageName,
+		OrigName:   origName,
 		SourceCode: code,
 		Commands:   []Command{},
 		Platform:   flPlatform.Value,
@@ -352,6 +437,14 @@ func parseWorkdir(req parseRequest) (*WorkdirCommand, error) {
 }
 
 func parseShellDependentCommand(req parseRequest, emptyAsNil bool) ShellDependantCmdLine {
+	var files []ShellInlineFile
+	for _, heredoc := range req.heredocs {
+		files = append(files, ShellInlineFile{
+			Name:  heredoc.Name,
+			Data:  heredoc.Content,
+			Chomp: heredoc.Chomp,
+		})
+	}
 	args := handleJSONArgs(req.args, req.attributes)
 	cmd := strslice.StrSlice(args)
 	if emptyAsNil && len(cmd) == 0 {
@@ -359,6 +452,7 @@ func parseShellDependentCommand(req parseRequest, emptyAsNil bool) ShellDependan
 	}
 	return ShellDependantCmdLine{
 		CmdLine:      cmd,
+		Files:        files,
 		PrependShell: !req.attributes["json"],
 	}
 }
@@ -586,6 +680,18 @@ func parseArg(req parseRequest) (*ArgCommand, error) {
 		return nil, errAtLeastOneArgument("ARG")
 	}
 
+	cmd := &ArgCommand{}
+
+	for _, fn := range parseArgPreHooks {
+		if err := fn(cmd, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := req.flags.Parse(); err != nil {
+		return nil, err
+	}
+
 	pairs := make([]KeyValuePairOptional, len(req.args))
 
 	for i, arg := range req.args {
@@ -611,10 +717,16 @@ func parseArg(req parseRequest) (*ArgCommand, error) {
 		pairs[i] = kvpo
 	}
 
-	return &ArgCommand{
-		Args:            pairs,
-		withNameAndCode: newWithNameAndCode(req),
-	}, nil
+	cmd.Args = pairs
+	cmd.withNameAndCode = newWithNameAndCode(req)
+
+	for _, fn := range parseArgPostHooks {
+		if err := fn(cmd, req); err != nil {
+			return nil, err
+		}
+	}
+
+	return cmd, nil
 }
 
 func parseShell(req parseRequest) (*ShellCommand, error) {
@@ -651,6 +763,10 @@ func errNoDestinationArgument(command string) error {
 	return errors.Errorf("%s requires at least two arguments, but only one was provided. Destination could not be determined.", command)
 }
 
+func errBadHeredoc(command string, option string) error {
+	return errors.Errorf("%s cannot accept a heredoc as %s", command, option)
+}
+
 func errBlankCommandNames(command string) error {
 	return errors.Errorf("%s names can not be blank", command)
 }
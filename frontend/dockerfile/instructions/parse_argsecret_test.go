@@ -0,0 +1,33 @@
+// +build dfargsecret
+
+package instructions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgSecret(t *testing.T) {
+	ast, err := parser.Parse(strings.NewReader("ARG --secret=token TOKEN"))
+	require.NoError(t, err)
+	cmd, err := ParseInstruction(ast.AST.Children[0])
+	require.NoError(t, err)
+	c, ok := cmd.(*ArgCommand)
+	require.True(t, ok)
+	require.Equal(t, "token", c.Secret)
+	require.Equal(t, "TOKEN", c.Args[0].Key)
+
+	for dockerfile, expectedError := range map[string]string{
+		"ARG --secret=token TOKEN=foo":   "ARG --secret can't set a default value for TOKEN",
+		"ARG --secret=token TOKEN OTHER": "ARG --secret requires exactly one argument",
+	} {
+		ast, err := parser.Parse(strings.NewReader(dockerfile))
+		require.NoError(t, err)
+		_, err = ParseInstruction(ast.AST.Children[0])
+		require.Error(t, err)
+		require.Contains(t, err.Error(), expectedError)
+	}
+}
@@ -0,0 +1,32 @@
+// +build dfheredoc
+
+package instructions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyHeredoc(t *testing.T) {
+	ast, err := parser.Parse(strings.NewReader("COPY <<EOF <<-'EOT' src /dest/\nhello $FOO\nEOF\n\tworld\n\tEOT\n"))
+	require.NoError(t, err)
+	cmd, err := ParseInstruction(ast.AST.Children[0])
+	require.NoError(t, err)
+
+	c, ok := cmd.(*CopyCommand)
+	require.True(t, ok)
+	require.Equal(t, []string{"src"}, c.Sources())
+	require.Equal(t, "/dest/", c.Dest())
+	require.Equal(t, []SourceContent{
+		{Path: "EOF", Data: "hello $FOO\n", Expand: true},
+		{Path: "EOT", Data: "world\n"},
+	}, c.SourceContents)
+
+	ast, err = parser.Parse(strings.NewReader("COPY src <<EOF\nhello\nEOF\n"))
+	require.NoError(t, err)
+	_, err = ParseInstruction(ast.AST.Children[0])
+	require.EqualError(t, err, errBadHeredoc("COPY", "a destination").Error())
+}
@@ -0,0 +1,33 @@
+// +build dfrundevice
+
+package instructions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDevice(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected *Device
+	}{
+		{"/dev/fuse", &Device{Source: "/dev/fuse"}},
+		{"/dev/fuse:rw", &Device{Source: "/dev/fuse", Permissions: "rw"}},
+		{"/dev/kvm:/dev/vm", &Device{Source: "/dev/kvm", Target: "/dev/vm"}},
+		{"/dev/kvm:/dev/vm:rwm", &Device{Source: "/dev/kvm", Target: "/dev/vm", Permissions: "rwm"}},
+		{"dev/fuse", nil},
+		{"/dev/kvm:vm", nil},
+		{"/dev/kvm:/dev/vm:rx", nil},
+		{"/dev/kvm:/dev/vm:rw:m", nil},
+	} {
+		d, err := ParseDevice(tc.value)
+		if tc.expected == nil {
+			require.Error(t, err, tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.expected, d, tc.value)
+	}
+}
@@ -0,0 +1,130 @@
+// Package linter checks parsed Dockerfiles for common problems that don't
+// prevent the build from running.
+package linter
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/pkg/errors"
+)
+
+// Warning is a problem found by a rule.
+type Warning struct {
+	RuleName    string
+	Description string
+	Detail      string
+	Location    []parser.Range
+}
+
+// Config is set with the check directive of a Dockerfile:
+//
+//	# check=skip=<rule>,<rule>;error=true
+type Config struct {
+	// SkipRules are the names of the rules that aren't checked
+	SkipRules []string
+	// Error makes the build fail if there are any warnings
+	Error bool
+}
+
+// Opt contains the options for Check.
+type Opt struct {
+	Config
+	// BuildArgs are the build arguments set for the build
+	BuildArgs map[string]string
+	// EscapeToken is the escape character of the Dockerfile
+	EscapeToken rune
+}
+
+// ParseConfig parses the value of the check directive.
+func ParseConfig(v string) (*Config, error) {
+	cfg := &Config{}
+	for _, part := range strings.Split(v, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid check option %q", part)
+		}
+		key, value := strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+		switch key {
+		case "skip":
+			for _, name := range strings.Split(value, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					cfg.SkipRules = append(cfg.SkipRules, name)
+				}
+			}
+		case "error":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid boolean value %s for check option error", value)
+			}
+			cfg.Error = b
+		default:
+			return nil, errors.Errorf("unknown check option %q", key)
+		}
+	}
+	return cfg, nil
+}
+
+// Check runs all the rules that aren't skipped over the stages and meta
+// arguments of a Dockerfile.
+func Check(stages []instructions.Stage, metaArgs []instructions.ArgCommand, opt Opt) []Warning {
+	skip := map[string]struct{}{}
+	for _, name := range opt.SkipRules {
+		skip[strings.ToLower(name)] = struct{}{}
+	}
+	escapeToken := opt.EscapeToken
+	if escapeToken == 0 {
+		escapeToken = parser.DefaultEscapeToken
+	}
+	c := &checker{
+		stages:    stages,
+		metaArgs:  metaArgs,
+		buildArgs: opt.BuildArgs,
+		shlex:     shell.NewLex(escapeToken),
+	}
+	// substitutions are always parsed so that the args they use are checked
+	c.shlex.Substitutions = true
+	for _, r := range rules {
+		if _, ok := skip[strings.ToLower(r.Name)]; ok {
+			continue
+		}
+		r.check(c, func(detail string, location []parser.Range) {
+			c.warnings = append(c.warnings, Warning{
+				RuleName:    r.Name,
+				Description: r.Description,
+				Detail:      detail,
+				Location:    location,
+			})
+		})
+	}
+	sort.SliceStable(c.warnings, func(i, j int) bool {
+		return line(c.warnings[i].Location) < line(c.warnings[j].Location)
+	})
+	return c.warnings
+}
+
+type checker struct {
+	stages    []instructions.Stage
+	metaArgs  []instructions.ArgCommand
+	buildArgs map[string]string
+	shlex     *shell.Lex
+	warnings  []Warning
+}
+
+// line returns the first line of a location. Warnings without a location are
+// sorted last.
+func line(location []parser.Range) int {
+	if len(location) == 0 {
+		return math.MaxInt32
+	}
+	return location[0].Start.Line
+}
@@ -0,0 +1,82 @@
+package linter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func check(t *testing.T, df string, opt Opt) []Warning {
+	ast, err := parser.Parse(strings.NewReader(df))
+	require.NoError(t, err)
+	stages, metaArgs, err := instructions.Parse(ast.AST)
+	require.NoError(t, err)
+	return Check(stages, metaArgs, opt)
+}
+
+func TestCheck(t *testing.T) {
+	df := `ARG BASE=alpine
+FROM ${BASE} AS Base
+MAINTAINER me@example.com
+ENV FOO bar
+LABEL foo=bar
+ARG USED
+CMD ["echo", "hello"
+FROM $BASE AS base
+FROM ${MISSING} AS other
+RUN ["echo"]
+`
+	warnings := check(t, df, Opt{
+		BuildArgs: map[string]string{
+			"USED":       "1",
+			"UNUSED":     "1",
+			"http_proxy": "http://proxy",
+		},
+	})
+
+	type result struct {
+		rule string
+		line int
+	}
+	var results []result
+	for _, w := range warnings {
+		results = append(results, result{w.RuleName, line(w.Location)})
+	}
+	require.Equal(t, []result{
+		{RuleStageNameCasing.Name, 2},
+		{RuleMaintainerDeprecated.Name, 3},
+		{RuleLegacyKeyValueFormat.Name, 4},
+		{RuleMalformedJSONArgs.Name, 7},
+		{RuleDuplicateStageName.Name, 8},
+		{RuleUndefinedArgInFrom.Name, 9},
+		{RuleUnusedBuildArg.Name, line(nil)},
+	}, results)
+	require.Equal(t, "FROM argument 'MISSING' is not declared", warnings[5].Detail)
+	require.Equal(t, "Build argument 'UNUSED' is not consumed by any ARG", warnings[6].Detail)
+
+	warnings = check(t, df, Opt{
+		Config: Config{
+			SkipRules: []string{"stagenamecasing", RuleDuplicateStageName.Name, RuleMaintainerDeprecated.Name, RuleLegacyKeyValueFormat.Name, RuleMalformedJSONArgs.Name},
+		},
+	})
+	require.Equal(t, 1, len(warnings))
+	require.Equal(t, RuleUndefinedArgInFrom.Name, warnings[0].RuleName)
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig("skip=StageNameCasing, UnusedBuildArg;error=true")
+	require.NoError(t, err)
+	require.Equal(t, &Config{
+		SkipRules: []string{"StageNameCasing", "UnusedBuildArg"},
+		Error:     true,
+	}, cfg)
+
+	_, err = ParseConfig("error=maybe")
+	require.Error(t, err)
+
+	_, err = ParseConfig("unknown=1")
+	require.Error(t, err)
+}
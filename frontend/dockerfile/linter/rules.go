@@ -0,0 +1,239 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+)
+
+type warnFunc func(detail string, location []parser.Range)
+
+// Rule is a single check run by the linter.
+type Rule struct {
+	Name        string
+	Description string
+	check       func(c *checker, warn warnFunc)
+}
+
+var (
+	RuleStageNameCasing = Rule{
+		Name:        "StageNameCasing",
+		Description: "Stage names should be lowercase",
+		check:       checkStageNameCasing,
+	}
+	RuleDuplicateStageName = Rule{
+		Name:        "DuplicateStageName",
+		Description: "Stage names should be unique",
+		check:       checkDuplicateStageName,
+	}
+	RuleUndefinedArgInFrom = Rule{
+		Name:        "UndefinedArgInFrom",
+		Description: "FROM command must use declared ARGs",
+		check:       checkUndefinedArgInFrom,
+	}
+	RuleMalformedJSONArgs = Rule{
+		Name:        "MalformedJSONArgs",
+		Description: "JSON arguments that can't be parsed are run with a shell",
+		check:       checkMalformedJSONArgs,
+	}
+	RuleMaintainerDeprecated = Rule{
+		Name:        "MaintainerDeprecated",
+		Description: "The MAINTAINER instruction is deprecated, use a label instead to define an image author",
+		check:       checkMaintainerDeprecated,
+	}
+	RuleUnusedBuildArg = Rule{
+		Name:        "UnusedBuildArg",
+		Description: "Build arguments should be declared with ARG",
+		check:       checkUnusedBuildArg,
+	}
+	RuleLegacyKeyValueFormat = Rule{
+		Name:        "LegacyKeyValueFormat",
+		Description: "The legacy key/value format with a whitespace separator should not be used",
+		check:       checkLegacyKeyValueFormat,
+	}
+)
+
+var rules = []Rule{
+	RuleStageNameCasing,
+	RuleDuplicateStageName,
+	RuleUndefinedArgInFrom,
+	RuleMalformedJSONArgs,
+	RuleMaintainerDeprecated,
+	RuleUnusedBuildArg,
+	RuleLegacyKeyValueFormat,
+}
+
+// Rules returns all the rules of the linter.
+func Rules() []Rule {
+	return append([]Rule{}, rules...)
+}
+
+// builtinArgs are set by the frontend without being declared.
+var builtinArgs = map[string]struct{}{
+	"BUILDPLATFORM":  {},
+	"BUILDOS":        {},
+	"BUILDARCH":      {},
+	"BUILDVARIANT":   {},
+	"TARGETPLATFORM": {},
+	"TARGETOS":       {},
+	"TARGETARCH":     {},
+	"TARGETVARIANT":  {},
+}
+
+// predefinedArgs can be passed to any build without being declared.
+var predefinedArgs = map[string]struct{}{
+	"HTTP_PROXY":  {},
+	"HTTPS_PROXY": {},
+	"FTP_PROXY":   {},
+	"NO_PROXY":    {},
+	"ALL_PROXY":   {},
+}
+
+func checkStageNameCasing(c *checker, warn warnFunc) {
+	for _, st := range c.stages {
+		if st.OrigName != "" && st.OrigName != strings.ToLower(st.OrigName) {
+			warn(fmt.Sprintf("Stage name '%s' should be lowercase", st.OrigName), st.Location)
+		}
+	}
+}
+
+func checkDuplicateStageName(c *checker, warn warnFunc) {
+	seen := map[string]struct{}{}
+	for _, st := range c.stages {
+		if st.Name == "" {
+			continue
+		}
+		if _, ok := seen[st.Name]; ok {
+			warn(fmt.Sprintf("Duplicate stage name '%s', stage names should be unique", st.OrigName), st.Location)
+			continue
+		}
+		seen[st.Name] = struct{}{}
+	}
+}
+
+func checkUndefinedArgInFrom(c *checker, warn warnFunc) {
+	declared := map[string]string{}
+	for _, cmd := range c.metaArgs {
+		for _, arg := range cmd.Args {
+			declared[arg.Key] = ""
+		}
+	}
+	for k := range builtinArgs {
+		declared[k] = ""
+	}
+	for _, st := range c.stages {
+		unmatched := map[string]struct{}{}
+		for _, word := range []string{st.BaseName, st.Platform} {
+			_, u, err := c.shlex.ProcessWordWithUnmatched(word, declared)
+			if err != nil {
+				// invalid substitutions are reported by the build
+				continue
+			}
+			for k := range u {
+				unmatched[k] = struct{}{}
+			}
+		}
+		for _, k := range sortedKeys(unmatched) {
+			warn(fmt.Sprintf("FROM argument '%s' is not declared", k), st.Location)
+		}
+	}
+}
+
+func checkMalformedJSONArgs(c *checker, warn warnFunc) {
+	for _, st := range c.stages {
+		for _, cmd := range st.Commands {
+			var cmdLine instructions.ShellDependantCmdLine
+			switch cmd := cmd.(type) {
+			case *instructions.RunCommand:
+				cmdLine = cmd.ShellDependantCmdLine
+			case *instructions.CmdCommand:
+				cmdLine = cmd.ShellDependantCmdLine
+			case *instructions.EntrypointCommand:
+				cmdLine = cmd.ShellDependantCmdLine
+			default:
+				continue
+			}
+			if cmdLine.PrependShell && len(cmdLine.CmdLine) == 1 && strings.HasPrefix(strings.TrimSpace(cmdLine.CmdLine[0]), "[") {
+				warn(fmt.Sprintf("%s arguments are not valid JSON and are run with a shell", strings.ToUpper(cmd.Name())), cmd.Location())
+			}
+		}
+	}
+}
+
+func checkMaintainerDeprecated(c *checker, warn warnFunc) {
+	for _, st := range c.stages {
+		for _, cmd := range st.Commands {
+			if _, ok := cmd.(*instructions.MaintainerCommand); ok {
+				warn("Maintainer instruction is deprecated in favor of using label", cmd.Location())
+			}
+		}
+	}
+}
+
+func checkUnusedBuildArg(c *checker, warn warnFunc) {
+	if len(c.buildArgs) == 0 {
+		return
+	}
+	declared := map[string]struct{}{}
+	for _, cmd := range c.metaArgs {
+		for _, arg := range cmd.Args {
+			declared[arg.Key] = struct{}{}
+		}
+	}
+	for _, st := range c.stages {
+		for _, cmd := range st.Commands {
+			if cmd, ok := cmd.(*instructions.ArgCommand); ok {
+				for _, arg := range cmd.Args {
+					declared[arg.Key] = struct{}{}
+				}
+			}
+		}
+	}
+	var unused []string
+	for k := range c.buildArgs {
+		if _, ok := declared[k]; ok {
+			continue
+		}
+		if _, ok := predefinedArgs[strings.ToUpper(k)]; ok {
+			continue
+		}
+		if strings.HasPrefix(k, "BUILDKIT_") {
+			// options of the frontend
+			continue
+		}
+		unused = append(unused, k)
+	}
+	sort.Strings(unused)
+	for _, k := range unused {
+		warn(fmt.Sprintf("Build argument '%s' is not consumed by any ARG", k), nil)
+	}
+}
+
+func checkLegacyKeyValueFormat(c *checker, warn warnFunc) {
+	for _, st := range c.stages {
+		for _, cmd := range st.Commands {
+			switch cmd := cmd.(type) {
+			case *instructions.EnvCommand:
+				if cmd.Legacy {
+					warn(`"ENV key=value" should be used instead of the legacy "ENV key value" format`, cmd.Location())
+				}
+			case *instructions.LabelCommand:
+				if cmd.Legacy {
+					warn(`"LABEL key=value" should be used instead of the legacy "LABEL key value" format`, cmd.Location())
+				}
+			}
+		}
+	}
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
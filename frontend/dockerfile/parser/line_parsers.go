@@ -195,12 +195,22 @@ func appendKeyValueNode(node, rootNode, prevNode *Node) (*Node, *Node) {
 
 func parseEnv(rest string, d *directives) (*Node, map[string]bool, error) {
 	node, err := parseNameVal(rest, "ENV", d)
-	return node, nil, err
+	return node, nameValAttributes(rest, d), err
 }
 
 func parseLabel(rest string, d *directives) (*Node, map[string]bool, error) {
 	node, err := parseNameVal(rest, commandLabel, d)
-	return node, nil, err
+	return node, nameValAttributes(rest, d), err
+}
+
+// nameValAttributes sets the "legacy" attribute for the old
+// `KEY name value` variant parsed by parseNameVal.
+func nameValAttributes(rest string, d *directives) map[string]bool {
+	words := parseWords(rest, d)
+	if len(words) > 0 && !strings.Contains(words[0], "=") {
+		return map[string]bool{"legacy": true}
+	}
+	return nil
 }
 
 // parses a statement containing one or more keyword definition(s) and/or
@@ -12,6 +12,7 @@ import (
 	"unicode"
 
 	"github.com/moby/buildkit/frontend/dockerfile/command"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
 	"github.com/pkg/errors"
 )
 
@@ -37,6 +38,16 @@ type Node struct {
 	StartLine   int             // the line in the original dockerfile where the node begins
 	EndLine     int             // the line in the original dockerfile where the node ends
 	PrevComment []string
+	Heredocs    []Heredoc // here-documents following the instruction, in order
+}
+
+// Heredoc represents a here-document (<<EOF) attached to an instruction
+type Heredoc struct {
+	Name           string // terminator of the here-document
+	FileDescriptor uint   // file descriptor the here-document is redirected to
+	Expand         bool   // whether variables in the content should be expanded
+	Chomp          bool   // whether leading tabs should be removed (<<-EOF)
+	Content        string // content, including the trailing newline
 }
 
 // Location return the location of node in source code
@@ -89,8 +100,15 @@ var (
 	reWhitespace = regexp.MustCompile(`[\t\v\f\r ]+`)
 	reDirectives = regexp.MustCompile(`^#\s*([a-zA-Z][a-zA-Z0-9]*)\s*=\s*(.+?)\s*$`)
 	reComment    = regexp.MustCompile(`^#.*$`)
+	reHeredoc    = regexp.MustCompile(`^(\d*)<<(-?)([^<]*)$`)
+	reLeadingTab = regexp.MustCompile(`(?m)^\t+`)
 )
 
+// heredocDirectives are the instructions that can be followed by
+// here-documents. Here-documents are experimental and only enabled with the
+// dfheredoc build tag.
+var heredocDirectives = map[string]bool{}
+
 // DefaultEscapeToken is the default escape token
 const DefaultEscapeToken = '\\'
 
@@ -312,6 +330,38 @@ func Parse(rwc io.Reader) (*Result, error) {
 		if err != nil {
 			return nil, withLocation(err, startLine, currentLine)
 		}
+
+		if child.canContainHeredoc() {
+			heredocs, err := heredocsFromLine(line)
+			if err != nil {
+				return nil, withLocation(err, startLine, currentLine)
+			}
+
+			for _, heredoc := range heredocs {
+				terminator := []byte(heredoc.Name)
+				terminated := false
+				for scanner.Scan() {
+					bytesRead := scanner.Bytes()
+					currentLine++
+
+					possibleTerminator := bytesRead
+					if heredoc.Chomp {
+						possibleTerminator = bytes.TrimLeft(possibleTerminator, "\t")
+					}
+					if bytes.Equal(bytes.TrimSuffix(possibleTerminator, []byte{'\r'}), terminator) {
+						terminated = true
+						break
+					}
+					heredoc.Content += string(bytesRead) + "\n"
+				}
+				if !terminated {
+					return nil, withLocation(errors.Errorf("unterminated heredoc %s", heredoc.Name), startLine, currentLine)
+				}
+
+				child.Heredocs = append(child.Heredocs, heredoc)
+			}
+		}
+
 		comments = nil
 		root.AddChild(child, startLine, currentLine)
 	}
@@ -331,6 +381,97 @@ func Parse(rwc io.Reader) (*Result, error) {
 	}, withLocation(handleScannerError(scanner.Err()), currentLine, 0)
 }
 
+func (node *Node) canContainHeredoc() bool {
+	if !heredocDirectives[node.Value] {
+		return false
+	}
+	return !node.Attributes["json"]
+}
+
+// ParseHeredoc parses a heredoc word (e.g. <<EOF, <<-"EOF" or 3<<EOF) and
+// returns nil if the word is not a heredoc. Content is expanded only if no
+// part of the terminator is quoted, following the shell rules.
+func ParseHeredoc(src string) (*Heredoc, error) {
+	match := reHeredoc.FindStringSubmatch(src)
+	if len(match) == 0 {
+		return nil, nil
+	}
+
+	fd, _ := strconv.ParseUint(match[1], 10, 0)
+	chomp := match[2] == "-"
+	rest := match[3]
+	if len(rest) == 0 {
+		return nil, nil
+	}
+
+	lex := shell.NewLex('\\')
+	lex.SkipUnsetEnv = true
+
+	// Lex the terminator both with and without quotes. If the number of
+	// quotes differs, part of the terminator was quoted and the content must
+	// not be expanded.
+	words, err := lex.ProcessWords(rest, []string{})
+	if err != nil {
+		return nil, err
+	}
+	if len(words) != 1 {
+		return nil, nil
+	}
+
+	lex.RawQuotes = true
+	wordsRaw, err := lex.ProcessWords(rest, []string{})
+	if err != nil {
+		return nil, err
+	}
+	if len(wordsRaw) != len(words) {
+		return nil, errors.Errorf("internal lexing of heredoc produced inconsistent results: %s", rest)
+	}
+
+	word := words[0]
+	wordRaw := wordsRaw[0]
+	quotes := strings.Count(word, `'`) + strings.Count(word, `"`)
+	quotesRaw := strings.Count(wordRaw, `'`) + strings.Count(wordRaw, `"`)
+
+	return &Heredoc{
+		Name:           word,
+		FileDescriptor: uint(fd),
+		Expand:         quotes == quotesRaw,
+		Chomp:          chomp,
+	}, nil
+}
+
+// MustParseHeredoc is like ParseHeredoc but returns nil if the word is not a
+// valid heredoc.
+func MustParseHeredoc(src string) *Heredoc {
+	heredoc, _ := ParseHeredoc(src)
+	return heredoc
+}
+
+func heredocsFromLine(line string) ([]Heredoc, error) {
+	lex := shell.NewLex('\\')
+	lex.RawQuotes = true
+	lex.RawEscapes = true
+	words, _ := lex.ProcessWords(line, []string{})
+
+	var docs []Heredoc
+	for _, word := range words {
+		heredoc, err := ParseHeredoc(word)
+		if err != nil {
+			return nil, err
+		}
+		if heredoc != nil {
+			docs = append(docs, *heredoc)
+		}
+	}
+	return docs, nil
+}
+
+// ChompHeredocContent removes the leading tabs from every line of a heredoc
+// content, as done by the shell for <<-EOF.
+func ChompHeredocContent(src string) string {
+	return reLeadingTab.ReplaceAllString(src, "")
+}
+
 func trimComments(src []byte) []byte {
 	return reComment.ReplaceAll(src, []byte{})
 }
@@ -0,0 +1,55 @@
+// +build dfheredoc
+
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeredoc(t *testing.T) {
+	dockerfile := bytes.NewBufferString(`
+FROM alpine:3.6
+RUN <<EOF
+echo hello
+echo world
+EOF
+COPY <<-"FILE1" <<FILE2 /dest/
+	foo $bar
+	FILE1
+baz
+FILE2
+RUN ["ls", "<<EOF"]
+`)
+	result, err := Parse(dockerfile)
+	require.NoError(t, err)
+
+	children := result.AST.Children
+	require.Equal(t, 4, len(children))
+
+	run := children[1]
+	require.Equal(t, []Heredoc{{Name: "EOF", Expand: true, Content: "echo hello\necho world\n"}}, run.Heredocs)
+	require.Equal(t, []int{3, 6}, []int{run.StartLine, run.EndLine})
+
+	cp := children[2]
+	require.Equal(t, []Heredoc{
+		{Name: "FILE1", Chomp: true, Content: "\tfoo $bar\n"},
+		{Name: "FILE2", Expand: true, Content: "baz\n"},
+	}, cp.Heredocs)
+	require.Equal(t, "foo $bar\n", ChompHeredocContent(cp.Heredocs[0].Content))
+
+	require.Nil(t, children[3].Heredocs)
+}
+
+func TestParseHeredocUnterminated(t *testing.T) {
+	dockerfile := bytes.NewBufferString(`
+FROM alpine:3.6
+RUN <<EOF
+echo hello
+`)
+	_, err := Parse(dockerfile)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unterminated heredoc")
+}
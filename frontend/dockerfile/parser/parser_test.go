@@ -175,3 +175,24 @@ func TestParseReturnsScannerErrors(t *testing.T) {
 	_, err := Parse(dockerfile)
 	require.EqualError(t, err, "dockerfile line greater than max allowed size of 65535")
 }
+
+func TestParseHeredocWord(t *testing.T) {
+	cases := []struct {
+		word     string
+		expected *Heredoc
+	}{
+		{"<<EOF", &Heredoc{Name: "EOF", Expand: true}},
+		{"<<-EOF", &Heredoc{Name: "EOF", Expand: true, Chomp: true}},
+		{"<<'EOF'", &Heredoc{Name: "EOF"}},
+		{`<<E"O"F`, &Heredoc{Name: "EOF"}},
+		{"3<<EOF", &Heredoc{Name: "EOF", Expand: true, FileDescriptor: 3}},
+		{"<<", nil},
+		{"EOF", nil},
+		{"<<EOF file", nil},
+	}
+	for _, c := range cases {
+		heredoc, err := ParseHeredoc(c.word)
+		require.NoError(t, err, c.word)
+		require.Equal(t, c.expected, heredoc, c.word)
+	}
+}
@@ -3,6 +3,8 @@ package shell
 import (
 	"bytes"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/scanner"
 	"unicode"
@@ -18,9 +20,18 @@ import (
 // It doesn't support all flavors of ${xx:...} formats but new ones can
 // be added by adding code to the "special ${} format processing" section
 type Lex struct {
-	escapeToken  rune
-	RawQuotes    bool
-	SkipUnsetEnv bool
+	escapeToken       rune
+	RawQuotes         bool
+	RawEscapes        bool
+	SkipProcessQuotes bool
+	SkipUnsetEnv      bool
+	// EscapeDollarOnly keeps escape tokens that don't precede '$', like in
+	// the content of unquoted here-documents
+	EscapeDollarOnly bool
+	// Substitutions enables the bash ${xx#pattern}, ${xx##pattern},
+	// ${xx%pattern}, ${xx%%pattern}, ${xx/pattern/replacement},
+	// ${xx//pattern/replacement} and ${xx:offset:length} formats
+	Substitutions bool
 }
 
 // NewLex creates a new Lex which uses escapeToken to escape quotes.
@@ -59,23 +70,47 @@ func (s *Lex) ProcessWordsWithMap(word string, env map[string]string) ([]string,
 	return words, err
 }
 
+// ProcessWordWithUnmatched works like ProcessWordWithMap and also returns
+// the names of the variables referenced in 'word' that are not set in 'env'.
+// References that provide a default value or an error message are not
+// reported.
+func (s *Lex) ProcessWordWithUnmatched(word string, env map[string]string) (string, map[string]struct{}, error) {
+	sw := s.newShellWord(word, env)
+	sw.unmatched = map[string]struct{}{}
+	word, _, err := sw.process(word)
+	return word, sw.unmatched, err
+}
+
 func (s *Lex) process(word string, env map[string]string) (string, []string, error) {
+	return s.newShellWord(word, env).process(word)
+}
+
+func (s *Lex) newShellWord(word string, env map[string]string) *shellWord {
 	sw := &shellWord{
-		envs:         env,
-		escapeToken:  s.escapeToken,
-		skipUnsetEnv: s.SkipUnsetEnv,
-		rawQuotes:    s.RawQuotes,
+		envs:              env,
+		escapeToken:       s.escapeToken,
+		skipUnsetEnv:      s.SkipUnsetEnv,
+		skipProcessQuotes: s.SkipProcessQuotes,
+		rawQuotes:         s.RawQuotes,
+		rawEscapes:        s.RawEscapes,
+		escapeDollarOnly:  s.EscapeDollarOnly,
+		substitutions:     s.Substitutions,
 	}
 	sw.scanner.Init(strings.NewReader(word))
-	return sw.process(word)
+	return sw
 }
 
 type shellWord struct {
-	scanner      scanner.Scanner
-	envs         map[string]string
-	escapeToken  rune
-	rawQuotes    bool
-	skipUnsetEnv bool
+	scanner           scanner.Scanner
+	envs              map[string]string
+	escapeToken       rune
+	rawQuotes         bool
+	rawEscapes        bool
+	skipUnsetEnv      bool
+	skipProcessQuotes bool
+	escapeDollarOnly  bool
+	substitutions     bool
+	unmatched         map[string]struct{}
 }
 
 func (sw *shellWord) process(source string) (string, []string, error) {
@@ -134,27 +169,36 @@ func (w *wordsStruct) getWords() []string {
 // Process the word, starting at 'pos', and stop when we get to the
 // end of the word or the 'stopChar' character
 func (sw *shellWord) processStopOn(stopChar rune) (string, []string, error) {
+	word, words, _, err := sw.processStopOnAny([]rune{stopChar})
+	return word, words, err
+}
+
+// processStopOnAny works like processStopOn but stops on any of the
+// 'stopChars' and also returns the one it stopped on
+func (sw *shellWord) processStopOnAny(stopChars []rune) (string, []string, rune, error) {
 	var result bytes.Buffer
 	var words wordsStruct
 
 	var charFuncMapping = map[rune]func() (string, error){
-		'\'': sw.processSingleQuote,
-		'"':  sw.processDoubleQuote,
-		'$':  sw.processDollar,
+		'$': sw.processDollar,
+	}
+	if !sw.skipProcessQuotes {
+		charFuncMapping['\''] = sw.processSingleQuote
+		charFuncMapping['"'] = sw.processDoubleQuote
 	}
 
 	for sw.scanner.Peek() != scanner.EOF {
 		ch := sw.scanner.Peek()
 
-		if stopChar != scanner.EOF && ch == stopChar {
+		if isStopChar(ch, stopChars) {
 			sw.scanner.Next()
-			return result.String(), words.getWords(), nil
+			return result.String(), words.getWords(), ch, nil
 		}
 		if fn, ok := charFuncMapping[ch]; ok {
 			// Call special processing func for certain chars
 			tmp, err := fn()
 			if err != nil {
-				return "", []string{}, err
+				return "", []string{}, scanner.EOF, err
 			}
 			result.WriteString(tmp)
 
@@ -168,6 +212,16 @@ func (sw *shellWord) processStopOn(stopChar rune) (string, []string, error) {
 			ch = sw.scanner.Next()
 
 			if ch == sw.escapeToken {
+				if sw.escapeDollarOnly && sw.scanner.Peek() != '$' {
+					words.addRawChar(ch)
+					result.WriteRune(ch)
+					continue
+				}
+				if sw.rawEscapes {
+					words.addRawChar(ch)
+					result.WriteRune(ch)
+				}
+
 				// '\' (default escape token, but ` allowed) escapes, except end of line
 				ch = sw.scanner.Next()
 
@@ -183,10 +237,31 @@ func (sw *shellWord) processStopOn(stopChar rune) (string, []string, error) {
 			result.WriteRune(ch)
 		}
 	}
-	if stopChar != scanner.EOF {
-		return "", []string{}, errors.Errorf("unexpected end of statement while looking for matching %s", string(stopChar))
+	if !isStopChar(scanner.EOF, stopChars) {
+		return "", []string{}, scanner.EOF, errors.Errorf("unexpected end of statement while looking for matching %s", string(stopChars[len(stopChars)-1]))
 	}
-	return result.String(), words.getWords(), nil
+	return result.String(), words.getWords(), scanner.EOF, nil
+}
+
+// processPattern works like processStopOnAny but keeps the escape tokens in
+// the pattern so that escaped wildcards are matched literally
+func (sw *shellWord) processPattern(stopChars ...rune) (string, rune, error) {
+	rawEscapes := sw.rawEscapes
+	sw.rawEscapes = true
+	defer func() {
+		sw.rawEscapes = rawEscapes
+	}()
+	pattern, _, stop, err := sw.processStopOnAny(stopChars)
+	return pattern, stop, err
+}
+
+func isStopChar(ch rune, stopChars []rune) bool {
+	for _, c := range stopChars {
+		if c == ch {
+			return true
+		}
+	}
+	return false
 }
 
 func (sw *shellWord) processSingleQuote() (string, error) {
@@ -287,8 +362,11 @@ func (sw *shellWord) processDollar() (string, error) {
 			return "$", nil
 		}
 		value, found := sw.getEnv(name)
-		if !found && sw.skipUnsetEnv {
-			return "$" + name, nil
+		if !found {
+			sw.addUnmatched(name)
+			if sw.skipUnsetEnv {
+				return "$" + name, nil
+			}
 		}
 		return value, nil
 	}
@@ -307,8 +385,11 @@ func (sw *shellWord) processDollar() (string, error) {
 	case '}':
 		// Normal ${xx} case
 		value, found := sw.getEnv(name)
-		if !found && sw.skipUnsetEnv {
-			return fmt.Sprintf("${%s}", name), nil
+		if !found {
+			sw.addUnmatched(name)
+			if sw.skipUnsetEnv {
+				return fmt.Sprintf("${%s}", name), nil
+			}
 		}
 		return value, nil
 	case '?':
@@ -334,6 +415,13 @@ func (sw *shellWord) processDollar() (string, error) {
 	case ':':
 		// Special ${xx:...} format processing
 		// Yes it allows for recursive $'s in the ... spot
+		if sw.substitutions {
+			switch sw.scanner.Peek() {
+			case '+', '-', '?':
+			default:
+				return sw.processSubstring(name)
+			}
+		}
 		modifier := sw.scanner.Next()
 
 		word, _, err := sw.processStopOn('}')
@@ -391,10 +479,142 @@ func (sw *shellWord) processDollar() (string, error) {
 		default:
 			return "", errors.Errorf("unsupported modifier (%c) in substitution", modifier)
 		}
+	case '#', '%':
+		if !sw.substitutions {
+			break
+		}
+		// ${xx#pattern} and ${xx%pattern} remove the shortest match of the
+		// pattern from the start or the end of the value, ${xx##pattern}
+		// and ${xx%%pattern} the longest one
+		longest := false
+		if sw.scanner.Peek() == ch {
+			sw.scanner.Next()
+			longest = true
+		}
+		pattern, _, err := sw.processPattern('}')
+		if err != nil {
+			return "", sw.missingBraceError(err)
+		}
+		value, found := sw.getEnv(name)
+		if !found {
+			sw.addUnmatched(name)
+			if sw.skipUnsetEnv {
+				op := string(ch)
+				if longest {
+					op += op
+				}
+				return fmt.Sprintf("${%s%s%s}", name, op, pattern), nil
+			}
+		}
+		if ch == '#' {
+			return trimPrefix(pattern, sw.escapeToken, value, longest)
+		}
+		return trimSuffix(pattern, sw.escapeToken, value, longest)
+	case '/':
+		if !sw.substitutions {
+			break
+		}
+		// ${xx/pattern/replacement} replaces the first longest match of the
+		// pattern, ${xx//pattern/replacement} all of them
+		all := false
+		if sw.scanner.Peek() == '/' {
+			sw.scanner.Next()
+			all = true
+		}
+		pattern, stop, err := sw.processPattern('/', '}')
+		if err != nil {
+			return "", sw.missingBraceError(err)
+		}
+		var replacement string
+		if stop == '/' {
+			replacement, _, err = sw.processStopOn('}')
+			if err != nil {
+				return "", sw.missingBraceError(err)
+			}
+		}
+		value, found := sw.getEnv(name)
+		if !found {
+			sw.addUnmatched(name)
+			if sw.skipUnsetEnv {
+				op := "/"
+				if all {
+					op = "//"
+				}
+				if stop == '/' {
+					return fmt.Sprintf("${%s%s%s/%s}", name, op, pattern, replacement), nil
+				}
+				return fmt.Sprintf("${%s%s%s}", name, op, pattern), nil
+			}
+		}
+		return replacePattern(pattern, sw.escapeToken, replacement, value, all)
 	}
 	return "", errors.Errorf("missing ':' in substitution")
 }
 
+// processSubstring handles the ${xx:offset} and ${xx:offset:length} formats.
+// A negative offset counts from the end of the value and a negative length
+// sets the end of the substring counting from the end of the value.
+func (sw *shellWord) processSubstring(name string) (string, error) {
+	word, _, err := sw.processStopOn('}')
+	if err != nil {
+		return "", sw.missingBraceError(err)
+	}
+	value, found := sw.getEnv(name)
+	if !found {
+		sw.addUnmatched(name)
+		if sw.skipUnsetEnv {
+			return fmt.Sprintf("${%s:%s}", name, word), nil
+		}
+	}
+
+	offsetStr, lengthStr := word, ""
+	hasLength := false
+	if i := strings.Index(word, ":"); i >= 0 {
+		offsetStr, lengthStr, hasLength = word[:i], word[i+1:], true
+	}
+	offset, err := strconv.Atoi(strings.TrimSpace(offsetStr))
+	if err != nil {
+		return "", errors.Errorf("invalid offset %q in substitution", offsetStr)
+	}
+
+	runes := []rune(value)
+	if offset < 0 {
+		offset += len(runes)
+		if offset < 0 {
+			return "", nil
+		}
+	}
+	if offset > len(runes) {
+		offset = len(runes)
+	}
+	end := len(runes)
+	if hasLength {
+		length := 0
+		if v := strings.TrimSpace(lengthStr); v != "" {
+			length, err = strconv.Atoi(v)
+			if err != nil {
+				return "", errors.Errorf("invalid length %q in substitution", lengthStr)
+			}
+		}
+		if length < 0 {
+			end += length
+			if end < offset {
+				return "", errors.Errorf("%s: substring expression < 0", name)
+			}
+		} else if offset+length < end {
+			end = offset + length
+		}
+	}
+	return string(runes[offset:end]), nil
+}
+
+func (sw *shellWord) missingBraceError(err error) error {
+	if sw.scanner.Peek() == scanner.EOF {
+		return errors.New("syntax error: missing '}'")
+	}
+	return err
+}
+
 func (sw *shellWord) processName() string {
 	// Read in a name (alphanumeric or _)
 	// If it starts with a numeric then just return $#
@@ -445,6 +665,12 @@ func (sw *shellWord) getEnv(name string) (string, bool) {
 	return "", false
 }
 
+func (sw *shellWord) addUnmatched(name string) {
+	if sw.unmatched != nil {
+		sw.unmatched[name] = struct{}{}
+	}
+}
+
 func BuildEnvs(env []string) map[string]string {
 	envs := map[string]string{}
 
@@ -464,3 +690,145 @@ func BuildEnvs(env []string) map[string]string {
 
 	return envs
 }
+
+// trimPrefix removes the shortest or the longest prefix of value matching
+// the shell pattern
+func trimPrefix(pattern string, escapeToken rune, value string, longest bool) (string, error) {
+	re, err := compileShellPattern(pattern, escapeToken, true)
+	if err != nil {
+		return "", err
+	}
+	offsets := runeOffsets(value)
+	for i := range offsets {
+		if longest {
+			i = len(offsets) - 1 - i
+		}
+		if re.MatchString(value[:offsets[i]]) {
+			return value[offsets[i]:], nil
+		}
+	}
+	return value, nil
+}
+
+// trimSuffix removes the shortest or the longest suffix of value matching
+// the shell pattern
+func trimSuffix(pattern string, escapeToken rune, value string, longest bool) (string, error) {
+	re, err := compileShellPattern(pattern, escapeToken, true)
+	if err != nil {
+		return "", err
+	}
+	offsets := runeOffsets(value)
+	for i := range offsets {
+		if !longest {
+			i = len(offsets) - 1 - i
+		}
+		if re.MatchString(value[offsets[i]:]) {
+			return value[:offsets[i]], nil
+		}
+	}
+	return value, nil
+}
+
+func replacePattern(pattern string, escapeToken rune, replacement, value string, all bool) (string, error) {
+	if pattern == "" {
+		return value, nil
+	}
+	re, err := compileShellPattern(pattern, escapeToken, false)
+	if err != nil {
+		return "", err
+	}
+	re.Longest()
+	if all {
+		return re.ReplaceAllLiteralString(value, replacement), nil
+	}
+	loc := re.FindStringIndex(value)
+	if loc == nil {
+		return value, nil
+	}
+	return value[:loc[0]] + replacement + value[loc[1]:], nil
+}
+
+// runeOffsets returns the byte offsets of every rune of s and len(s)
+func runeOffsets(s string) []int {
+	offsets := make([]int, 0, len(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	return append(offsets, len(s))
+}
+
+// compileShellPattern converts a shell pattern with *, ? and [...] to a
+// regular expression. A character after the escape token is matched
+// literally. If anchored is set, the expression only matches the whole
+// string.
+func compileShellPattern(pattern string, escapeToken rune, anchored bool) (*regexp.Regexp, error) {
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	}
+	b.WriteString("(?s:")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == escapeToken && i+1 < len(runes) {
+			i++
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+			continue
+		}
+		switch ch := runes[i]; ch {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := closingBracket(runes, i)
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := runes[i+1 : end]
+			b.WriteString("[")
+			if class[0] == '!' || class[0] == '^' {
+				b.WriteString("^")
+				class = class[1:]
+			}
+			for _, c := range class {
+				switch c {
+				case '\\', '[', ']':
+					b.WriteString(`\`)
+				}
+				b.WriteRune(c)
+			}
+			b.WriteString("]")
+			i = end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	b.WriteString(")")
+	if anchored {
+		b.WriteString("$")
+	}
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+	return re, nil
+}
+
+// closingBracket returns the index of the ] closing the bracket expression
+// starting at i or -1. A ] right after the opening [ or [! is a literal.
+func closingBracket(runes []rune, i int) int {
+	j := i + 1
+	if j < len(runes) && (runes[j] == '!' || runes[j] == '^') {
+		j++
+	}
+	if j < len(runes) && runes[j] == ']' {
+		j++
+	}
+	for ; j < len(runes); j++ {
+		if runes[j] == ']' {
+			return j
+		}
+	}
+	return -1
+}
@@ -225,3 +225,103 @@ func TestGetEnv(t *testing.T) {
 		t.Fatal("8 - 'car' should map to 'bike'")
 	}
 }
+
+func TestProcessWordWithUnmatched(t *testing.T) {
+	shlex := NewLex('\\')
+	res, unmatched, err := shlex.ProcessWordWithUnmatched("$FOO:${BAR}:${BAZ:-def}:${QUX}", map[string]string{"FOO": "foo"})
+	require.NoError(t, err)
+	require.Equal(t, "foo::def:", res)
+	require.Equal(t, map[string]struct{}{"BAR": {}, "QUX": {}}, unmatched)
+}
+
+func TestProcessWordEscapeDollarOnly(t *testing.T) {
+	shlex := NewLex('\\')
+	shlex.SkipProcessQuotes = true
+	shlex.EscapeDollarOnly = true
+	res, err := shlex.ProcessWordWithMap(`printf "%s\n" "$FOO" \$BAR C:\dir\`, map[string]string{"FOO": "foo"})
+	require.NoError(t, err)
+	require.Equal(t, `printf "%s\n" "foo" $BAR C:\dir\`, res)
+}
+
+func TestShellParserSubstitutions(t *testing.T) {
+	shlex := NewLex('\\')
+	shlex.Substitutions = true
+	env := map[string]string{
+		"VERSION": "1.21.3",
+		"PATH":    "/usr/local/bin/app.tar.gz",
+		"EMPTY":   "",
+		"GLOB":    "a*b?c[d]*",
+	}
+
+	for _, tc := range []struct {
+		word     string
+		expected string
+	}{
+		{"${VERSION%%.*}", "1"},
+		{"${VERSION%.*}", "1.21"},
+		{"${VERSION#*.}", "21.3"},
+		{"${VERSION##*.}", "3"},
+		{"${PATH##*/}", "app.tar.gz"},
+		{"${PATH%/*}", "/usr/local/bin"},
+		{"${PATH%.[gt]z}", "/usr/local/bin/app.tar"},
+		{"${PATH%.[!g]z}", "/usr/local/bin/app.tar.gz"},
+		{"${VERSION#nomatch}", "1.21.3"},
+		{"${VERSION/./-}", "1-21.3"},
+		{"${VERSION//./-}", "1-21-3"},
+		{"${VERSION//.}", "1213"},
+		{"${PATH/\\/usr\\/local/\\/opt}", "/opt/bin/app.tar.gz"},
+		{"${VERSION/2*/x}", "1.x"},
+		{"${VERSION:2}", "21.3"},
+		{"${VERSION:0:4}", "1.21"},
+		{"${VERSION: -1}", "3"},
+		{"${VERSION:2:-2}", "21"},
+		{"${VERSION:10}", ""},
+		{"${VERSION:1:}", ""},
+		{"${MISSING#*.}", ""},
+		{"${EMPTY:0:2}", ""},
+		{"${VERSION:-def}", "1.21.3"},
+		{"${VERSION:+set}", "set"},
+		{"${VERSION%${VERSION#*.}}", "1."},
+		{"${GLOB#*\\*}", "b?c[d]*"},
+		{"${GLOB%\\*}", "a*b?c[d]"},
+		{"${GLOB##*\\*}", ""},
+		{"${GLOB//\\*/-}", "a-b?c[d]-"},
+		{"${GLOB/\\?/-}", "a*b-c[d]*"},
+		{"${GLOB/\\[d\\]/-}", "a*b?c-*"},
+		{"${VERSION/\\*/x}", "1.21.3"},
+		{"${VERSION#\\?}", "1.21.3"},
+	} {
+		res, err := shlex.ProcessWordWithMap(tc.word, env)
+		require.NoError(t, err, tc.word)
+		require.Equal(t, tc.expected, res, tc.word)
+	}
+
+	for _, word := range []string{
+		"${VERSION:x}",
+		"${VERSION:1:-10}",
+		"${VERSION%.*",
+		"${VERSION/./-",
+	} {
+		_, err := shlex.ProcessWordWithMap(word, env)
+		require.Error(t, err, word)
+	}
+
+	shlex.SkipUnsetEnv = true
+	res, err := shlex.ProcessWordWithMap("${MISSING##*.}${MISSING//a/b}${MISSING:1:2}", env)
+	require.NoError(t, err)
+	require.Equal(t, "${MISSING##*.}${MISSING//a/b}${MISSING:1:2}", res)
+
+	// the escape token of the Dockerfile escapes wildcards
+	shlexBacktick := NewLex('`')
+	shlexBacktick.Substitutions = true
+	res, err = shlexBacktick.ProcessWordWithMap("${GLOB//`*/-}", env)
+	require.NoError(t, err)
+	require.Equal(t, "a-b?c[d]-", res)
+
+	// without substitutions these formats are still invalid
+	shlex = NewLex('\\')
+	for _, word := range []string{"${VERSION%.*}", "${VERSION#*.}", "${VERSION/./-}", "${VERSION:0:4}"} {
+		_, err := shlex.ProcessWordWithMap(word, env)
+		require.Error(t, err, word)
+	}
+}
@@ -17,8 +17,15 @@ type Frontend interface {
 type FrontendLLBBridge interface {
 	Solve(ctx context.Context, req SolveRequest, sid string) (*Result, error)
 	ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt) (digest.Digest, []byte, error)
+	ResolveSourceMetadata(ctx context.Context, op *pb.SourceOp, opt llb.ResolveSourceMetadataOpt) (*llb.SourceMetadata, error)
+	Warn(ctx context.Context, dgst digest.Digest, msg string, opts WarnOpts) error
+	Progress(ctx context.Context, req ProgressRequest) error
 }
 
 type SolveRequest = gw.SolveRequest
 
+type WarnOpts = gw.WarnOpts
+
+type ProgressRequest = gw.ProgressRequest
+
 type CacheOptionsEntry = gw.CacheOptionsEntry
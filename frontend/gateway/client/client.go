@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"io"
+	"syscall"
 
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/solver/pb"
@@ -15,9 +16,45 @@ import (
 type Client interface {
 	Solve(ctx context.Context, req SolveRequest) (*Result, error)
 	ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt) (digest.Digest, []byte, error)
+	// ResolveSourceMetadata resolves the immutable version of an image, git
+	// or http source, e.g. for pinning the inputs of a build. Image and git
+	// sources are resolved without loading their content. HTTP sources are
+	// downloaded to compute their checksum unless a cached download with the
+	// same ETag exists.
+	ResolveSourceMetadata(ctx context.Context, op *pb.SourceOp, opt llb.ResolveSourceMetadataOpt) (*llb.SourceMetadata, error)
 	BuildOpts() BuildOpts
 	Inputs(ctx context.Context) (map[string]llb.State, error)
 	NewContainer(ctx context.Context, req NewContainerRequest) (Container, error)
+	// Warn sends a warning to the progress stream of the build, attached to
+	// the vertex with digest dgst.
+	Warn(ctx context.Context, dgst digest.Digest, msg string, opts WarnOpts) error
+	// Progress reports a custom progress step of the frontend, shown in the
+	// progress stream of the build like the steps of the solver.
+	Progress(ctx context.Context, req ProgressRequest) error
+}
+
+// WarnOpts describes a warning sent by a frontend. SourceInfo and Range
+// point to the part of the source file that caused the warning.
+type WarnOpts struct {
+	Level      int
+	SourceInfo *pb.SourceInfo
+	Range      []*pb.Range
+	Detail     [][]byte
+	URL        string
+}
+
+// ProgressRequest updates a custom progress step of a frontend. The first
+// request for an ID starts the step and must set Name. Later requests add
+// logs to the step until one of them sets Completed.
+type ProgressRequest struct {
+	ID   string
+	Name string
+	// Stream is 1 for stdout and 2 for stderr logs in Data.
+	Stream    int
+	Data      []byte
+	Completed bool
+	// Error marks a completed step as failed.
+	Error string
 }
 
 // NewContainerRequest encapsulates the requirements for a client to define a
@@ -47,6 +84,11 @@ type Mount struct {
 // container resources when done.
 type Container interface {
 	Start(context.Context, StartRequest) (ContainerProcess, error)
+	// SnapshotMount returns the content of the writable mount at dest as a
+	// Reference that can be used in further solves or returned as a result.
+	// The container must not be running and can't start new processes
+	// afterwards.
+	SnapshotMount(ctx context.Context, dest string) (Reference, error)
 	Release(context.Context) error
 }
 
@@ -74,7 +116,7 @@ type WinSize struct {
 type ContainerProcess interface {
 	Wait() error
 	Resize(ctx context.Context, size WinSize) error
-	// TODO Signal(ctx context.Context, sig os.Signal)
+	Signal(ctx context.Context, sig syscall.Signal) error
 }
 
 type Reference interface {
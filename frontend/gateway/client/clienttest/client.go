@@ -0,0 +1,223 @@
+// Package clienttest provides an in-process fake of the gateway API for
+// testing frontends without a BuildKit daemon.
+//
+// The fake records the requests made by the frontend. Solve requests for a
+// definition are answered with references that read files from memory, and
+// solve requests for another frontend run the BuildFunc registered for it, so
+// subrequests between frontends can be tested. LLB is not executed.
+package clienttest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/docker/distribution/reference"
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// Opt configures the build served by a fake client.
+type Opt struct {
+	// Opts are the frontend options of the build.
+	Opts      map[string]string
+	SessionID string
+	// Workers default to a single worker for the platform of the test.
+	Workers []client.WorkerInfo
+	// Inputs are returned by Inputs.
+	Inputs map[string]llb.State
+	// Images are returned by ResolveImageConfig, by image reference.
+	// Normalized references match, e.g. "alpine" matches
+	// "docker.io/library/alpine:latest".
+	Images map[string]Image
+	// Sources are returned by ResolveSourceMetadata, by source identifier.
+	Sources map[string]*llb.SourceMetadata
+	// Frontends handle solve requests for other frontends, by name.
+	Frontends map[string]client.BuildFunc
+	// Solve handles solve requests for definitions. By default, the result
+	// has a reference to the definition without any files.
+	Solve func(ctx context.Context, req client.SolveRequest) (*client.Result, error)
+}
+
+// Image is the resolved config of an image.
+type Image struct {
+	Digest digest.Digest
+	Config []byte
+}
+
+// Warning is a warning sent by the frontend.
+type Warning struct {
+	Digest digest.Digest
+	Msg    string
+	Opts   client.WarnOpts
+}
+
+// Client is a fake gateway client.
+type Client struct {
+	opt Opt
+	rec *recorder
+}
+
+var _ client.Client = &Client{}
+
+type recorder struct {
+	mu       sync.Mutex
+	solves   []client.SolveRequest
+	warnings []Warning
+	progress []client.ProgressRequest
+}
+
+// New returns a fake gateway client for a build configured with opt.
+func New(opt Opt) *Client {
+	if opt.Opts == nil {
+		opt.Opts = map[string]string{}
+	}
+	if len(opt.Workers) == 0 {
+		opt.Workers = []client.WorkerInfo{{
+			ID:        "fake",
+			Platforms: []specs.Platform{platforms.DefaultSpec()},
+		}}
+	}
+	return &Client{opt: opt, rec: &recorder{}}
+}
+
+// Run calls the frontend f with a fake client for a build configured with
+// opt.
+func Run(ctx context.Context, opt Opt, f client.BuildFunc) (*client.Result, *Client, error) {
+	c := New(opt)
+	res, err := f(ctx, c)
+	return res, c, err
+}
+
+// Solves returns the solve requests made by the frontend, including the ones
+// made by frontends it called.
+func (c *Client) Solves() []client.SolveRequest {
+	c.rec.mu.Lock()
+	defer c.rec.mu.Unlock()
+	return append([]client.SolveRequest(nil), c.rec.solves...)
+}
+
+// Warnings returns the warnings sent by the frontend.
+func (c *Client) Warnings() []Warning {
+	c.rec.mu.Lock()
+	defer c.rec.mu.Unlock()
+	return append([]Warning(nil), c.rec.warnings...)
+}
+
+// ProgressRequests returns the custom progress updates sent by the frontend.
+func (c *Client) ProgressRequests() []client.ProgressRequest {
+	c.rec.mu.Lock()
+	defer c.rec.mu.Unlock()
+	return append([]client.ProgressRequest(nil), c.rec.progress...)
+}
+
+func (c *Client) Solve(ctx context.Context, req client.SolveRequest) (*client.Result, error) {
+	c.rec.mu.Lock()
+	c.rec.solves = append(c.rec.solves, req)
+	c.rec.mu.Unlock()
+
+	if req.Frontend != "" {
+		return c.solveFrontend(ctx, req)
+	}
+	if c.opt.Solve != nil {
+		return c.opt.Solve(ctx, req)
+	}
+	res := client.NewResult()
+	if req.Definition != nil && req.Definition.Def != nil {
+		res.SetRef(NewReference(req.Definition, nil))
+	}
+	return res, nil
+}
+
+func (c *Client) solveFrontend(ctx context.Context, req client.SolveRequest) (*client.Result, error) {
+	f, ok := c.opt.Frontends[req.Frontend]
+	if !ok {
+		return nil, errors.Errorf("frontend %s not found", req.Frontend)
+	}
+	inputs := map[string]llb.State{}
+	for k, def := range req.FrontendInputs {
+		op, err := llb.NewDefinitionOp(def)
+		if err != nil {
+			return nil, err
+		}
+		inputs[k] = llb.NewState(op)
+	}
+	opt := c.opt
+	opt.Opts = req.FrontendOpt
+	if opt.Opts == nil {
+		opt.Opts = map[string]string{}
+	}
+	opt.Inputs = inputs
+	return f(ctx, &Client{opt: opt, rec: c.rec})
+}
+
+func (c *Client) ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt) (digest.Digest, []byte, error) {
+	if img, ok := c.opt.Images[ref]; ok {
+		return img.Digest, img.Config, nil
+	}
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to parse %s", ref)
+	}
+	name := reference.TagNameOnly(named).String()
+	for k, img := range c.opt.Images {
+		n, err := reference.ParseNormalizedNamed(k)
+		if err != nil {
+			continue
+		}
+		if reference.TagNameOnly(n).String() == name {
+			return img.Digest, img.Config, nil
+		}
+	}
+	return "", nil, errors.Errorf("image %s not found", ref)
+}
+
+func (c *Client) ResolveSourceMetadata(ctx context.Context, op *pb.SourceOp, opt llb.ResolveSourceMetadataOpt) (*llb.SourceMetadata, error) {
+	md, ok := c.opt.Sources[op.Identifier]
+	if !ok {
+		return nil, errors.Errorf("source %s not found", op.Identifier)
+	}
+	return md, nil
+}
+
+func (c *Client) BuildOpts() client.BuildOpts {
+	return client.BuildOpts{
+		Opts:      c.opt.Opts,
+		SessionID: c.opt.SessionID,
+		Workers:   c.opt.Workers,
+		Product:   "clienttest",
+		LLBCaps:   pb.Caps.CapSet(pb.Caps.All()),
+		Caps:      gwpb.Caps.CapSet(gwpb.Caps.All()),
+	}
+}
+
+func (c *Client) Inputs(ctx context.Context) (map[string]llb.State, error) {
+	inputs := make(map[string]llb.State, len(c.opt.Inputs))
+	for k, v := range c.opt.Inputs {
+		inputs[k] = v
+	}
+	return inputs, nil
+}
+
+func (c *Client) NewContainer(ctx context.Context, req client.NewContainerRequest) (client.Container, error) {
+	return nil, errors.New("containers are not supported by the fake gateway")
+}
+
+func (c *Client) Warn(ctx context.Context, dgst digest.Digest, msg string, opts client.WarnOpts) error {
+	c.rec.mu.Lock()
+	c.rec.warnings = append(c.rec.warnings, Warning{Digest: dgst, Msg: msg, Opts: opts})
+	c.rec.mu.Unlock()
+	return nil
+}
+
+func (c *Client) Progress(ctx context.Context, req client.ProgressRequest) error {
+	c.rec.mu.Lock()
+	c.rec.progress = append(c.rec.progress, req)
+	c.rec.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,175 @@
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/pb"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// buildImage is a minimal frontend that builds the alpine image with its
+// config for every target platform.
+func buildImage(ctx context.Context, c client.Client) (*client.Result, error) {
+	return client.BuildPlatforms(ctx, c, func(ctx context.Context, platform *specs.Platform) (*client.PlatformResult, error) {
+		_, config, err := c.ResolveImageConfig(ctx, "alpine", llb.ResolveImageConfigOpt{Platform: platform})
+		if err != nil {
+			return nil, err
+		}
+		def, err := llb.Image("alpine").Marshal(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.Solve(ctx, client.SolveRequest{Definition: def.ToPB()})
+		if err != nil {
+			return nil, err
+		}
+		ref, err := res.SingleRef()
+		if err != nil {
+			return nil, err
+		}
+		return &client.PlatformResult{Ref: ref, Config: config}, nil
+	})
+}
+
+func TestBuildPlatforms(t *testing.T) {
+	ctx := context.TODO()
+	images := map[string]Image{
+		"docker.io/library/alpine:latest": {Config: []byte(`{"os":"linux"}`)},
+	}
+
+	res, c, err := Run(ctx, Opt{Images: images}, buildImage)
+	require.NoError(t, err)
+	require.NotNil(t, res.Ref)
+	require.Nil(t, res.Refs)
+	require.Equal(t, `{"os":"linux"}`, string(res.Attachments[""][0].Data))
+	require.Equal(t, exptypes.AttachmentImageConfig, res.Attachments[""][0].Type)
+	require.Len(t, c.Solves(), 1)
+
+	res, c, err = Run(ctx, Opt{
+		Opts:   map[string]string{client.OptTargetPlatform: "linux/amd64,linux/arm64"},
+		Images: images,
+	}, buildImage)
+	require.NoError(t, err)
+	require.Nil(t, res.Ref)
+	require.Len(t, res.Refs, 2)
+	require.Contains(t, res.Refs, "linux/amd64")
+	require.Contains(t, res.Refs, "linux/arm64")
+	require.Len(t, res.Attachments["linux/arm64"], 1)
+	require.Len(t, c.Solves(), 2)
+
+	var ps exptypes.Platforms
+	require.NoError(t, json.Unmarshal(res.Metadata[exptypes.ExporterPlatformsKey], &ps))
+	require.Equal(t, []exptypes.Platform{
+		{ID: "linux/amd64", Platform: platforms.MustParse("linux/amd64")},
+		{ID: "linux/arm64", Platform: platforms.MustParse("linux/arm64")},
+	}, ps.Platforms)
+
+	res, _, err = Run(ctx, Opt{
+		Opts:   map[string]string{client.OptMultiPlatform: "true"},
+		Images: images,
+	}, buildImage)
+	require.NoError(t, err)
+	require.Len(t, res.Refs, 1)
+	require.Contains(t, res.Refs, platforms.Format(platforms.DefaultSpec()))
+
+	_, _, err = Run(ctx, Opt{
+		Opts:   map[string]string{client.OptTargetPlatform: "linux/amd64,linux/arm64", client.OptMultiPlatform: "false"},
+		Images: images,
+	}, buildImage)
+	require.Error(t, err)
+
+	_, _, err = Run(ctx, Opt{}, buildImage)
+	require.Error(t, err)
+}
+
+func TestFrontendSolve(t *testing.T) {
+	ctx := context.TODO()
+
+	child := func(ctx context.Context, c client.Client) (*client.Result, error) {
+		inputs, err := c.Inputs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := inputs["context"]; !ok {
+			return nil, errors.New("no context input")
+		}
+		res := client.NewResult()
+		res.AddMeta("target", []byte(c.BuildOpts().Opts["target"]))
+		return res, nil
+	}
+
+	def, err := llb.Local("context").Marshal(ctx)
+	require.NoError(t, err)
+
+	res, c, err := Run(ctx, Opt{
+		Frontends: map[string]client.BuildFunc{"child.v0": child},
+	}, func(ctx context.Context, c client.Client) (*client.Result, error) {
+		return c.Solve(ctx, client.SolveRequest{
+			Frontend:       "child.v0",
+			FrontendOpt:    map[string]string{"target": "release"},
+			FrontendInputs: map[string]*pb.Definition{"context": def.ToPB()},
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, "release", string(res.Metadata["target"]))
+	require.Len(t, c.Solves(), 1)
+
+	_, _, err = Run(ctx, Opt{}, func(ctx context.Context, c client.Client) (*client.Result, error) {
+		return c.Solve(ctx, client.SolveRequest{Frontend: "unknown.v0"})
+	})
+	require.Error(t, err)
+}
+
+func TestReference(t *testing.T) {
+	ctx := context.TODO()
+
+	ref := NewReference(nil, map[string][]byte{
+		"package.json":     []byte(`{"name":"app"}`),
+		"/src/main.js":     []byte("main"),
+		"src/lib/util.js":  []byte("util"),
+		"src/lib/util.txt": []byte("text"),
+	})
+
+	dt, err := ref.ReadFile(ctx, client.ReadRequest{Filename: "/package.json"})
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"app"}`, string(dt))
+
+	dt, err = ref.ReadFile(ctx, client.ReadRequest{Filename: "src/main.js", Range: &client.FileRange{Offset: 1, Length: 2}})
+	require.NoError(t, err)
+	require.Equal(t, "ai", string(dt))
+
+	_, err = ref.ReadFile(ctx, client.ReadRequest{Filename: "missing"})
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	st, err := ref.StatFile(ctx, client.StatRequest{Path: "src/lib"})
+	require.NoError(t, err)
+	require.True(t, os.FileMode(st.Mode).IsDir())
+
+	st, err = ref.StatFile(ctx, client.StatRequest{Path: "src/main.js"})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), st.Size_)
+
+	stats, err := ref.ReadDir(ctx, client.ReadDirRequest{Path: "src"})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, "lib", stats[0].Path)
+	require.True(t, os.FileMode(stats[0].Mode).IsDir())
+	require.Equal(t, "main.js", stats[1].Path)
+
+	stats, err = ref.ReadDir(ctx, client.ReadDirRequest{Path: "src/lib", IncludePattern: "*.js"})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, "util.js", stats[0].Path)
+
+	_, err = ref.ReadDir(ctx, client.ReadDirRequest{Path: "missing"})
+	require.Error(t, err)
+}
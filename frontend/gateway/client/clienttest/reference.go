@@ -0,0 +1,147 @@
+package clienttest
+
+import (
+	"context"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+	fstypes "github.com/tonistiigi/fsutil/types"
+)
+
+// Reference is a fake reference that reads files from memory.
+type Reference struct {
+	def   *pb.Definition
+	files map[string][]byte
+}
+
+var _ client.Reference = &Reference{}
+
+// NewReference returns a reference to the result of def with the files in
+// files, by path. Parent directories of the files exist implicitly.
+func NewReference(def *pb.Definition, files map[string][]byte) *Reference {
+	r := &Reference{def: def, files: map[string][]byte{}}
+	for k, v := range files {
+		r.files[cleanPath(k)] = v
+	}
+	return r
+}
+
+func (r *Reference) ToState() (llb.State, error) {
+	if r.def == nil || r.def.Def == nil {
+		return llb.Scratch(), nil
+	}
+	op, err := llb.NewDefinitionOp(r.def)
+	if err != nil {
+		return llb.State{}, err
+	}
+	return llb.NewState(op), nil
+}
+
+func (r *Reference) ReadFile(ctx context.Context, req client.ReadRequest) ([]byte, error) {
+	p := cleanPath(req.Filename)
+	dt, ok := r.files[p]
+	if !ok {
+		return nil, notExist(p)
+	}
+	if rng := req.Range; rng != nil {
+		if rng.Offset >= len(dt) {
+			return []byte{}, nil
+		}
+		dt = dt[rng.Offset:]
+		if rng.Length < len(dt) {
+			dt = dt[:rng.Length]
+		}
+	}
+	return dt, nil
+}
+
+func (r *Reference) StatFile(ctx context.Context, req client.StatRequest) (*fstypes.Stat, error) {
+	p := cleanPath(req.Path)
+	if dt, ok := r.files[p]; ok {
+		return fileStat(p, dt), nil
+	}
+	if r.isDir(p) {
+		return dirStat(p), nil
+	}
+	return nil, notExist(p)
+}
+
+func (r *Reference) ReadDir(ctx context.Context, req client.ReadDirRequest) ([]*fstypes.Stat, error) {
+	dir := cleanPath(req.Path)
+	if !r.isDir(dir) {
+		return nil, notExist(dir)
+	}
+	entries := map[string]*fstypes.Stat{}
+	for p, dt := range r.files {
+		rel := p
+		if dir != "" {
+			if !strings.HasPrefix(p, dir+"/") {
+				continue
+			}
+			rel = strings.TrimPrefix(p, dir+"/")
+		}
+		name := rel
+		isDir := false
+		if i := strings.Index(rel, "/"); i >= 0 {
+			name = rel[:i]
+			isDir = true
+		}
+		if req.IncludePattern != "" {
+			if ok, err := path.Match(req.IncludePattern, name); err != nil {
+				return nil, errors.WithStack(err)
+			} else if !ok {
+				continue
+			}
+		}
+		if isDir {
+			entries[name] = dirStat(name)
+		} else {
+			entries[name] = fileStat(name, dt)
+		}
+	}
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	stats := make([]*fstypes.Stat, 0, len(names))
+	for _, name := range names {
+		stats = append(stats, entries[name])
+	}
+	return stats, nil
+}
+
+func (r *Reference) isDir(p string) bool {
+	if p == "" {
+		return true
+	}
+	for k := range r.files {
+		if strings.HasPrefix(k, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func cleanPath(p string) string {
+	p = path.Clean("/" + p)
+	return strings.TrimPrefix(p, "/")
+}
+
+func fileStat(p string, dt []byte) *fstypes.Stat {
+	return &fstypes.Stat{Path: p, Mode: 0644, Size_: int64(len(dt))}
+}
+
+func dirStat(p string) *fstypes.Stat {
+	return &fstypes.Stat{Path: p, Mode: uint32(os.ModeDir | 0755)}
+}
+
+func notExist(p string) error {
+	return errors.WithStack(&os.PathError{Op: "open", Path: "/" + p, Err: os.ErrNotExist})
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/solver/pb"
+)
+
+// GitPaths solves only the specified paths of a git repository and returns a
+// reference that the files can be read from with ReadFile, ReadDir and
+// StatFile. Blobs of other paths are not fetched, so this is much cheaper than
+// solving the whole repository when a frontend needs a few files, e.g.
+// package.json for resolving dependencies. Paths that don't exist in the
+// commit are ignored. Submodules are not checked out: a submodule is an empty
+// directory and paths inside it are ignored. Daemons without sparse git
+// support return a full checkout.
+func GitPaths(ctx context.Context, c Client, remote, ref string, paths []string, opts ...llb.GitOption) (Reference, error) {
+	caps := c.BuildOpts().LLBCaps
+	if err := caps.Supports(pb.CapSourceGitSparsePaths); err == nil {
+		opts = append(opts, llb.GitSparsePaths(paths))
+	}
+	return solveSingleRef(ctx, c, llb.Git(remote, ref, opts...))
+}
+
+// LocalPaths transfers only the specified paths of a local directory sent by
+// the client and returns a reference that the files can be read from with
+// ReadFile, ReadDir and StatFile. Paths are include patterns of the local
+// source, so a directory includes all its contents.
+func LocalPaths(ctx context.Context, c Client, name string, paths []string, opts ...llb.LocalOption) (Reference, error) {
+	opts = append([]llb.LocalOption{
+		llb.SessionID(c.BuildOpts().SessionID),
+		llb.SharedKeyHint(name + "-paths:" + strings.Join(paths, ",")),
+	}, opts...)
+	opts = append(opts, llb.IncludePatterns(paths), llb.FollowPaths(paths))
+	return solveSingleRef(ctx, c, llb.Local(name, opts...))
+}
+
+func solveSingleRef(ctx context.Context, c Client, st llb.State) (Reference, error) {
+	def, err := st.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Solve(ctx, SolveRequest{
+		Definition: def.ToPB(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.SingleRef()
+}
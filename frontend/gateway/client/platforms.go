@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// OptTargetPlatform is the frontend option with the comma separated list
+	// of platforms to build for.
+	OptTargetPlatform = "platform"
+	// OptMultiPlatform is the frontend option that forces returning a
+	// result per platform even when building for a single platform.
+	OptMultiPlatform = "multi-platform"
+)
+
+// PlatformBuildFunc builds the result for a single target platform. platform
+// is nil if the client didn't request a specific platform.
+type PlatformBuildFunc func(ctx context.Context, platform *specs.Platform) (*PlatformResult, error)
+
+// PlatformResult is the result of building a single target platform.
+type PlatformResult struct {
+	Ref Reference
+	// Config is the JSON encoded image config of the platform. It is passed
+	// to the image exporter with the result if set.
+	Config []byte
+	// Attachments are additional data for the ref of the platform.
+	Attachments []exptypes.Attachment
+}
+
+// TargetPlatforms returns the target platforms requested with the frontend
+// options of the build and if the result must be returned per platform. The
+// platforms are a single nil platform if none were requested.
+func TargetPlatforms(opts map[string]string) ([]*specs.Platform, bool, error) {
+	targetPlatforms := []*specs.Platform{nil}
+	if v := opts[OptTargetPlatform]; v != "" {
+		targetPlatforms = nil
+		for _, v := range strings.Split(v, ",") {
+			p, err := platforms.Parse(v)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "failed to parse target platform %s", v)
+			}
+			p = platforms.Normalize(p)
+			targetPlatforms = append(targetPlatforms, &p)
+		}
+	}
+
+	multiPlatform := len(targetPlatforms) > 1
+	if v := opts[OptMultiPlatform]; v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, false, errors.Errorf("invalid boolean value %s", v)
+		}
+		if !b && multiPlatform {
+			return nil, false, errors.Errorf("returning multiple target plaforms is not allowed")
+		}
+		multiPlatform = b
+	}
+	return targetPlatforms, multiPlatform, nil
+}
+
+// BuildPlatforms calls f concurrently for every target platform requested by
+// the client and assembles the results. With multiple platforms, the refs of
+// the result are keyed by platform and the platforms are described in the
+// metadata for the exporters, otherwise the result has a single ref.
+func BuildPlatforms(ctx context.Context, c Client, f PlatformBuildFunc) (*Result, error) {
+	targetPlatforms, multiPlatform, err := TargetPlatforms(c.BuildOpts().Opts)
+	if err != nil {
+		return nil, err
+	}
+
+	expPlatforms := &exptypes.Platforms{
+		Platforms: make([]exptypes.Platform, len(targetPlatforms)),
+	}
+	res := NewResult()
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, tp := range targetPlatforms {
+		i, tp := i, tp
+		eg.Go(func() error {
+			r, err := f(ctx, tp)
+			if err != nil {
+				return err
+			}
+			if r == nil {
+				r = &PlatformResult{}
+			}
+
+			if !multiPlatform {
+				res.addPlatformAttachments("", r)
+				res.SetRef(r.Ref)
+				return nil
+			}
+
+			p := platforms.DefaultSpec()
+			if tp != nil {
+				p = *tp
+			}
+			k := platforms.Format(p)
+			res.addPlatformAttachments(k, r)
+			res.AddRef(k, r.Ref)
+			expPlatforms.Platforms[i] = exptypes.Platform{
+				ID:       k,
+				Platform: p,
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	if multiPlatform {
+		dt, err := json.Marshal(expPlatforms)
+		if err != nil {
+			return nil, err
+		}
+		res.AddMeta(exptypes.ExporterPlatformsKey, dt)
+	}
+	return res, nil
+}
+
+func (r *Result) addPlatformAttachments(k string, pr *PlatformResult) {
+	if pr.Config != nil {
+		r.AddAttachment(k, exptypes.Attachment{Type: exptypes.AttachmentImageConfig, Data: pr.Config})
+	}
+	for _, att := range pr.Attachments {
+		r.AddAttachment(k, att)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/pkg/errors"
 )
 
@@ -14,6 +15,10 @@ type Result struct {
 	Ref      Reference
 	Refs     map[string]Reference
 	Metadata map[string][]byte
+	// Attachments holds typed data for the refs of the result, like the
+	// image config of every platform, by ref key. The attachments of Ref use
+	// the empty key.
+	Attachments exptypes.Attachments
 }
 
 func NewResult() *Result {
@@ -29,6 +34,17 @@ func (r *Result) AddMeta(k string, v []byte) {
 	r.mu.Unlock()
 }
 
+// AddAttachment attaches data to the ref with key k, the empty key for the
+// default ref.
+func (r *Result) AddAttachment(k string, att exptypes.Attachment) {
+	r.mu.Lock()
+	if r.Attachments == nil {
+		r.Attachments = exptypes.Attachments{}
+	}
+	r.Attachments[k] = append(r.Attachments[k], att)
+	r.mu.Unlock()
+}
+
 func (r *Result) AddRef(k string, ref Reference) {
 	r.mu.Lock()
 	if r.Refs == nil {
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"syscall"
 
 	"github.com/moby/buildkit/cache"
 	"github.com/moby/buildkit/executor"
@@ -29,6 +30,10 @@ type NewContainerRequest struct {
 	Mounts      []Mount
 	Platform    *opspb.Platform
 	Constraints *opspb.WorkerConstraints
+	// LoadSnapshot registers a snapshot of a mount of the container with the
+	// build and returns the reference for it. It is required for
+	// SnapshotMount.
+	LoadSnapshot func(ctx context.Context, ref cache.ImmutableRef, dest string) (client.Reference, error)
 }
 
 // Mount used for the gateway.Container is nearly identical to the client.Mount
@@ -57,6 +62,10 @@ func NewContainer(ctx context.Context, w worker.Worker, sm *session.Manager, g s
 		errGroup: eg,
 		ctx:      ctx,
 		cancel:   cancel,
+		exited:   make(chan struct{}),
+		group:    g,
+
+		loadSnapshot: req.LoadSnapshot,
 	}
 
 	var (
@@ -96,9 +105,9 @@ func NewContainer(ctx context.Context, w worker.Worker, sm *session.Manager, g s
 	ctr.mounts = p.Mounts
 
 	for _, o := range p.OutputRefs {
-		o := o
-		ctr.cleanup = append(ctr.cleanup, func() error {
-			return o.Ref.Release(context.TODO())
+		ctr.outputs = append(ctr.outputs, containerOutput{
+			dest: mnts[o.MountIndex].Dest,
+			ref:  o.Ref,
 		})
 	}
 	for _, active := range p.Actives {
@@ -278,12 +287,26 @@ type gatewayContainer struct {
 	errGroup *errgroup.Group
 	mu       sync.Mutex
 	cleanup  []func() error
+	outputs  []containerOutput
+	exited   chan struct{}
 	ctx      context.Context
 	cancel   func()
+	// snapshotted is set once a mount was committed, the container can't
+	// run new processes after that
+	snapshotted  bool
+	group        session.Group
+	loadSnapshot func(ctx context.Context, ref cache.ImmutableRef, dest string) (client.Reference, error)
+}
+
+// containerOutput is a mount of the container that can be snapshotted.
+type containerOutput struct {
+	dest string
+	ref  cache.Ref
 }
 
 func (gwCtr *gatewayContainer) Start(ctx context.Context, req client.StartRequest) (client.ContainerProcess, error) {
 	resize := make(chan executor.WinSize)
+	signal := make(chan syscall.Signal)
 	procInfo := executor.ProcessInfo{
 		Meta: executor.Meta{
 			Args:         req.Args,
@@ -298,6 +321,7 @@ func (gwCtr *gatewayContainer) Start(ctx context.Context, req client.StartReques
 		Stdout: req.Stdout,
 		Stderr: req.Stderr,
 		Resize: resize,
+		Signal: signal,
 	}
 	if procInfo.Meta.Cwd == "" {
 		procInfo.Meta.Cwd = "/"
@@ -310,6 +334,10 @@ func (gwCtr *gatewayContainer) Start(ctx context.Context, req client.StartReques
 	// mark that we have started on the first call to execProcess for this
 	// container, so that future calls will call Exec rather than Run
 	gwCtr.mu.Lock()
+	if gwCtr.snapshotted {
+		gwCtr.mu.Unlock()
+		return nil, errors.Errorf("container %s can't be started after a mount snapshot", gwCtr.id)
+	}
 	started := gwCtr.started
 	gwCtr.started = true
 	gwCtr.mu.Unlock()
@@ -317,6 +345,7 @@ func (gwCtr *gatewayContainer) Start(ctx context.Context, req client.StartReques
 	eg, ctx := errgroup.WithContext(gwCtr.ctx)
 	gwProc := &gatewayContainerProcess{
 		resize:   resize,
+		signal:   signal,
 		errGroup: eg,
 		groupCtx: ctx,
 	}
@@ -324,6 +353,7 @@ func (gwCtr *gatewayContainer) Start(ctx context.Context, req client.StartReques
 	if !started {
 		startedCh := make(chan struct{})
 		gwProc.errGroup.Go(func() error {
+			defer close(gwCtr.exited)
 			logrus.Debugf("Starting new container for %s with args: %q", gwCtr.id, procInfo.Meta.Args)
 			err := gwCtr.executor.Run(ctx, gwCtr.id, gwCtr.rootFS, gwCtr.mounts, procInfo, startedCh)
 			return stack.Enable(err)
@@ -356,6 +386,15 @@ func (gwCtr *gatewayContainer) Release(ctx context.Context) error {
 			err2 = err
 		}
 	}
+	gwCtr.mu.Lock()
+	for _, o := range gwCtr.outputs {
+		err := o.ref.Release(context.TODO())
+		if err2 == nil {
+			err2 = err
+		}
+	}
+	gwCtr.outputs = nil
+	gwCtr.mu.Unlock()
 
 	if err1 != nil {
 		return stack.Enable(err1)
@@ -367,6 +406,7 @@ type gatewayContainerProcess struct {
 	errGroup *errgroup.Group
 	groupCtx context.Context
 	resize   chan<- executor.WinSize
+	signal   chan<- syscall.Signal
 	mu       sync.Mutex
 }
 
@@ -375,6 +415,7 @@ func (gwProc *gatewayContainerProcess) Wait() error {
 	gwProc.mu.Lock()
 	defer gwProc.mu.Unlock()
 	close(gwProc.resize)
+	close(gwProc.signal)
 	return err
 }
 
@@ -403,6 +444,27 @@ func (gwProc *gatewayContainerProcess) Resize(ctx context.Context, size client.W
 	return nil
 }
 
+func (gwProc *gatewayContainerProcess) Signal(ctx context.Context, sig syscall.Signal) error {
+	gwProc.mu.Lock()
+	defer gwProc.mu.Unlock()
+
+	// same as Resize, don't block on a process that is already done
+	select {
+	case <-gwProc.groupCtx.Done():
+		return nil
+	case <-ctx.Done():
+		return nil
+	default:
+	}
+
+	select {
+	case <-gwProc.groupCtx.Done():
+	case <-ctx.Done():
+	case gwProc.signal <- sig:
+	}
+	return nil
+}
+
 func addDefaultEnvvar(env []string, k, v string) []string {
 	for _, e := range env {
 		if strings.HasPrefix(e, k+"=") {
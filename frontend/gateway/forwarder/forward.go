@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/moby/buildkit/cache"
 	cacheutil "github.com/moby/buildkit/cache/util"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/frontend"
@@ -83,6 +84,7 @@ func (c *bridgeClient) Solve(ctx context.Context, req client.SolveRequest) (*cli
 	}
 	c.mu.Unlock()
 	cRes.Metadata = res.Metadata
+	cRes.Attachments = res.Attachments
 
 	return cRes, nil
 }
@@ -198,6 +200,7 @@ func (c *bridgeClient) toFrontendResult(r *client.Result) (*frontend.Result, err
 		res.Ref = rr.ResultProxy
 	}
 	res.Metadata = r.Metadata
+	res.Attachments = r.Attachments
 
 	return res, nil
 }
@@ -277,6 +280,10 @@ func (c *bridgeClient) NewContainer(ctx context.Context, req client.NewContainer
 		return nil, err
 	}
 
+	ctrReq.LoadSnapshot = func(ctx context.Context, iref cache.ImmutableRef, dest string) (client.Reference, error) {
+		return c.loadSnapshot(ctx, w, iref, dest)
+	}
+
 	group := session.NewGroup(c.sid)
 	ctr, err := gateway.NewContainer(ctx, w, c.sm, group, ctrReq)
 	if err != nil {
@@ -285,6 +292,40 @@ func (c *bridgeClient) NewContainer(ctx context.Context, req client.NewContainer
 	return ctr, nil
 }
 
+// loadSnapshot keeps the mount snapshot ref alive until the build finishes
+// and returns it as a reference of the build.
+func (c *bridgeClient) loadSnapshot(ctx context.Context, w worker.Worker, iref cache.ImmutableRef, dest string) (client.Reference, error) {
+	workerRef := &worker.WorkerRef{ImmutableRef: iref, Worker: w}
+	c.mu.Lock()
+	if existing, ok := c.workerRefByID[workerRef.ID()]; ok {
+		// the same snapshot was loaded before, keep the registered ref
+		iref.Release(context.TODO())
+		iref = existing.ImmutableRef
+	} else {
+		c.workerRefByID[workerRef.ID()] = workerRef
+	}
+	c.mu.Unlock()
+
+	def, err := gateway.MountSnapshotDefinition(ctx, iref, dest)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.FrontendLLBBridge.Solve(ctx, frontend.SolveRequest{
+		Definition: def,
+	}, c.sid)
+	if err != nil {
+		return nil, c.wrapSolveError(err)
+	}
+	rr, err := c.newRef(res.Ref, session.NewGroup(c.sid))
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.refs = append(c.refs, rr)
+	c.mu.Unlock()
+	return rr, nil
+}
+
 type ref struct {
 	solver.ResultProxy
 	session session.Group
@@ -10,9 +10,11 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/pkg/signal"
 	"github.com/gogo/googleapis/google/rpc"
 	gogotypes "github.com/gogo/protobuf/types"
 	"github.com/golang/protobuf/ptypes/any"
@@ -56,14 +58,18 @@ const (
 	keyDevel  = "gateway-devel"
 )
 
-func NewGatewayFrontend(w worker.Infos) frontend.Frontend {
+// NewGatewayFrontend returns the frontend that runs frontend images. policy
+// restricts which images can be run and may be nil.
+func NewGatewayFrontend(w worker.Infos, policy *Policy) frontend.Frontend {
 	return &gatewayFrontend{
 		workers: w,
+		policy:  policy,
 	}
 }
 
 type gatewayFrontend struct {
 	workers worker.Infos
+	policy  *Policy
 }
 
 func filterPrefix(opts map[string]string, pfx string) map[string]string {
@@ -89,6 +95,9 @@ func (gf *gatewayFrontend) Solve(ctx context.Context, llbBridge frontend.Fronten
 	var readonly bool // TODO: try to switch to read-only by default.
 
 	if isDevel {
+		if err := gf.policy.checkDevel(); err != nil {
+			return nil, err
+		}
 		devRes, err := llbBridge.Solve(ctx,
 			frontend.SolveRequest{
 				Frontend:       source,
@@ -120,7 +129,8 @@ func (gf *gatewayFrontend) Solve(ctx context.Context, llbBridge frontend.Fronten
 			return nil, err
 		}
 		defer rootFS.Release(context.TODO())
-		config, ok := devRes.Metadata[exptypes.ExporterImageConfigKey]
+		atts := exptypes.AttachmentsFromMetadata(devRes.Metadata, devRes.Attachments)
+		config, ok := atts.Get("", exptypes.AttachmentImageConfig)
 		if ok {
 			if err := json.Unmarshal(config, &img); err != nil {
 				return nil, err
@@ -131,6 +141,9 @@ func (gf *gatewayFrontend) Solve(ctx context.Context, llbBridge frontend.Fronten
 		if err != nil {
 			return nil, err
 		}
+		if err := gf.policy.checkReference(source, sourceRef); err != nil {
+			return nil, err
+		}
 
 		dgst, config, err := llbBridge.ResolveImageConfig(ctx, reference.TagNameOnly(sourceRef).String(), llb.ResolveImageConfigOpt{})
 		if err != nil {
@@ -138,6 +151,10 @@ func (gf *gatewayFrontend) Solve(ctx context.Context, llbBridge frontend.Fronten
 		}
 		mfstDigest = dgst
 
+		if err := gf.policy.verifySignature(ctx, source, sourceRef, dgst, sm, session.NewGroup(sid)); err != nil {
+			return nil, err
+		}
+
 		if err := json.Unmarshal(config, &img); err != nil {
 			return nil, err
 		}
@@ -470,9 +487,14 @@ func (lbf *llbBridgeForwarder) ResolveImageConfig(ctx context.Context, req *pb.R
 		}
 	}
 	dgst, dt, err := lbf.llbBridge.ResolveImageConfig(ctx, req.Ref, llb.ResolveImageConfigOpt{
-		Platform:    platform,
-		ResolveMode: req.ResolveMode,
-		LogName:     req.LogName,
+		ResolverType: llb.ResolverType(req.ResolverType),
+		Platform:     platform,
+		ResolveMode:  req.ResolveMode,
+		LogName:      req.LogName,
+		Store: llb.ResolveImageConfigOptStore{
+			SessionID: req.SessionID,
+			StoreID:   req.StoreID,
+		},
 	})
 	if err != nil {
 		return nil, err
@@ -483,6 +505,81 @@ func (lbf *llbBridgeForwarder) ResolveImageConfig(ctx context.Context, req *pb.R
 	}, nil
 }
 
+func (lbf *llbBridgeForwarder) ResolveSourceMetadata(ctx context.Context, req *pb.ResolveSourceMetadataRequest) (*pb.ResolveSourceMetadataResponse, error) {
+	ctx = tracing.ContextWithSpanFromContext(ctx, lbf.callCtx)
+	if req.Source == nil {
+		return nil, errors.New("source is required")
+	}
+	var platform *specs.Platform
+	if p := req.Platform; p != nil {
+		platform = &specs.Platform{
+			OS:           p.OS,
+			Architecture: p.Architecture,
+			Variant:      p.Variant,
+			OSVersion:    p.OSVersion,
+			OSFeatures:   p.OSFeatures,
+		}
+	}
+	md, err := lbf.llbBridge.ResolveSourceMetadata(ctx, req.Source, llb.ResolveSourceMetadataOpt{
+		Platform: platform,
+		LogName:  req.LogName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ResolveSourceMetadataResponse{}
+	if md.Image != nil {
+		resp.Image = &pb.ResolveSourceImageResponse{
+			Digest: md.Image.Digest,
+			Config: md.Image.Config,
+		}
+	}
+	if md.Git != nil {
+		resp.Git = &pb.ResolveSourceGitResponse{
+			Ref:    md.Git.Ref,
+			Commit: md.Git.Commit,
+		}
+	}
+	if md.HTTP != nil {
+		resp.HTTP = &pb.ResolveSourceHTTPResponse{
+			Checksum: md.HTTP.Checksum,
+			ETag:     md.HTTP.ETag,
+		}
+	}
+	return resp, nil
+}
+
+func (lbf *llbBridgeForwarder) Warn(ctx context.Context, in *pb.WarnRequest) (*pb.WarnResponse, error) {
+	ctx = tracing.ContextWithSpanFromContext(ctx, lbf.callCtx)
+	err := lbf.llbBridge.Warn(ctx, in.Digest, string(in.Short), frontend.WarnOpts{
+		Level:      int(in.Level),
+		SourceInfo: in.Info,
+		Range:      in.Ranges,
+		Detail:     in.Detail,
+		URL:        in.Url,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.WarnResponse{}, nil
+}
+
+func (lbf *llbBridgeForwarder) Progress(ctx context.Context, in *pb.ProgressRequest) (*pb.ProgressResponse, error) {
+	ctx = tracing.ContextWithSpanFromContext(ctx, lbf.callCtx)
+	err := lbf.llbBridge.Progress(ctx, frontend.ProgressRequest{
+		ID:        in.ID,
+		Name:      in.Name,
+		Stream:    int(in.Stream),
+		Data:      in.Data,
+		Completed: in.Completed,
+		Error:     in.Error,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ProgressResponse{}, nil
+}
+
 func translateLegacySolveRequest(req *pb.SolveRequest) error {
 	// translates ImportCacheRefs to new CacheImports (v0.4.0)
 	for _, legacyImportRef := range req.ImportCacheRefsDeprecated {
@@ -581,7 +678,18 @@ func (lbf *llbBridgeForwarder) Solve(ctx context.Context, req *pb.SolveRequest)
 	}
 
 	pbRes := &pb.Result{
-		Metadata: res.Metadata,
+		Metadata:    res.Metadata,
+		Attachments: pb.AttachmentsToPB(res.Attachments),
+	}
+	if len(res.Attachments) > 0 {
+		// clients without attachments support forward the metadata keys
+		pbRes.Metadata = make(map[string][]byte, len(res.Metadata))
+		for k, v := range exptypes.MetadataFromAttachments(res.Attachments) {
+			pbRes.Metadata[k] = v
+		}
+		for k, v := range res.Metadata {
+			pbRes.Metadata[k] = v
+		}
 	}
 	var defaultID string
 
@@ -643,8 +751,9 @@ func (lbf *llbBridgeForwarder) Solve(ctx context.Context, req *pb.SolveRequest)
 
 		lbf.mu.Lock()
 		lbf.result = &frontend.Result{
-			Ref:      lbf.refs[defaultID],
-			Metadata: exp,
+			Ref:         lbf.refs[defaultID],
+			Metadata:    exp,
+			Attachments: res.Attachments,
 		}
 		lbf.mu.Unlock()
 	}
@@ -786,7 +895,8 @@ func (lbf *llbBridgeForwarder) Return(ctx context.Context, in *pb.ReturnRequest)
 		})))
 	}
 	r := &frontend.Result{
-		Metadata: in.Result.Metadata,
+		Metadata:    in.Result.Metadata,
+		Attachments: pb.AttachmentsFromPB(in.Result.Attachments),
 	}
 
 	switch res := in.Result.Result.(type) {
@@ -839,6 +949,13 @@ func (lbf *llbBridgeForwarder) NewContainer(ctx context.Context, in *pb.NewConta
 		NetMode:     in.Network,
 		Platform:    in.Platform,
 		Constraints: in.Constraints,
+		LoadSnapshot: func(ctx context.Context, ref cache.ImmutableRef, dest string) (gwclient.Reference, error) {
+			id, def, err := lbf.loadSnapshot(ctx, ref, dest)
+			if err != nil {
+				return nil, err
+			}
+			return &forwarderRef{lbf: lbf, id: id, def: def}, nil
+		},
 	}
 
 	for _, m := range in.Mounts {
@@ -920,10 +1037,70 @@ func (lbf *llbBridgeForwarder) ReleaseContainer(ctx context.Context, in *pb.Rele
 	return &pb.ReleaseContainerResponse{}, stack.Enable(err)
 }
 
+func (lbf *llbBridgeForwarder) SnapshotMount(ctx context.Context, in *pb.SnapshotMountRequest) (*pb.SnapshotMountResponse, error) {
+	logrus.Debugf("|<--- SnapshotMount %s %s", in.ContainerID, in.Dest)
+	lbf.ctrsMu.Lock()
+	ctr, ok := lbf.ctrs[in.ContainerID]
+	lbf.ctrsMu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("container details for %s not found", in.ContainerID)
+	}
+
+	ref, err := SnapshotMount(ctx, ctr, in.Dest)
+	if err != nil {
+		return nil, stack.Enable(err)
+	}
+	id, def, err := lbf.loadSnapshot(ctx, ref, in.Dest)
+	if err != nil {
+		return nil, stack.Enable(err)
+	}
+	return &pb.SnapshotMountResponse{
+		Ref: &pb.Ref{Id: id, Def: def},
+	}, nil
+}
+
+// loadSnapshot keeps the mount snapshot ref alive until the build finishes
+// and registers it as a ref of the build.
+func (lbf *llbBridgeForwarder) loadSnapshot(ctx context.Context, ref cache.ImmutableRef, dest string) (string, *opspb.Definition, error) {
+	w, err := lbf.workers.GetDefault()
+	if err != nil {
+		ref.Release(context.TODO())
+		return "", nil, err
+	}
+	workerRef := &worker.WorkerRef{ImmutableRef: ref, Worker: w}
+	lbf.mu.Lock()
+	if existing, ok := lbf.workerRefByID[workerRef.ID()]; ok {
+		// the same snapshot was loaded before, keep the registered ref
+		ref.Release(context.TODO())
+		ref = existing.ImmutableRef
+	} else {
+		lbf.workerRefByID[workerRef.ID()] = workerRef
+	}
+	lbf.mu.Unlock()
+
+	def, err := MountSnapshotDefinition(ctx, ref, dest)
+	if err != nil {
+		return "", nil, err
+	}
+	res, err := lbf.llbBridge.Solve(ctx, frontend.SolveRequest{
+		Definition: def,
+	}, lbf.sid)
+	if err != nil {
+		return "", nil, err
+	}
+
+	id := identity.NewID()
+	lbf.mu.Lock()
+	lbf.refs[id] = res.Ref
+	lbf.mu.Unlock()
+	return id, def, nil
+}
+
 type processIO struct {
 	id       string
 	mu       sync.Mutex
 	resize   func(context.Context, gwclient.WinSize) error
+	signal   func(context.Context, syscall.Signal) error
 	done     chan struct{}
 	doneOnce sync.Once
 	// these track the process side of the io pipe for
@@ -1062,6 +1239,8 @@ func (lbf *llbBridgeForwarder) ExecProcess(srv pb.LLBBridge_ExecProcessServer) e
 				}
 			case *pb.ExecMessage_Resize:
 				logrus.Debugf("|<--- Resize Message %s", execMsg.ProcessID)
+			case *pb.ExecMessage_Signal:
+				logrus.Debugf("|<--- Signal Message %s: %s", execMsg.ProcessID, m.Signal.Name)
 			}
 			select {
 			case <-ctx.Done():
@@ -1114,6 +1293,15 @@ func (lbf *llbBridgeForwarder) ExecProcess(srv pb.LLBBridge_ExecProcessServer) e
 					Cols: resize.Cols,
 					Rows: resize.Rows,
 				})
+			} else if sig := execMsg.GetSignal(); sig != nil {
+				if !pioFound {
+					return stack.Enable(status.Errorf(codes.NotFound, "IO for process %q not found", pid))
+				}
+				syscallSignal, ok := signal.SignalMap[sig.Name]
+				if !ok {
+					return stack.Enable(status.Errorf(codes.InvalidArgument, "unknown signal %s", sig.Name))
+				}
+				pio.signal(ctx, syscallSignal)
 			} else if init := execMsg.GetInit(); init != nil {
 				if pioFound {
 					return stack.Enable(status.Errorf(codes.AlreadyExists, "Process %s already exists", pid))
@@ -1146,6 +1334,7 @@ func (lbf *llbBridgeForwarder) ExecProcess(srv pb.LLBBridge_ExecProcessServer) e
 					return stack.Enable(err)
 				}
 				pio.resize = proc.Resize
+				pio.signal = proc.Signal
 
 				eg.Go(func() error {
 					<-pio.done
@@ -9,12 +9,15 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	"github.com/docker/docker/pkg/signal"
 	"github.com/gogo/googleapis/google/rpc"
 	gogotypes "github.com/gogo/protobuf/types"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/frontend/gateway/client"
 	pb "github.com/moby/buildkit/frontend/gateway/pb"
 	"github.com/moby/buildkit/identity"
@@ -93,6 +96,14 @@ func convertRef(ref client.Reference) (*pb.Ref, error) {
 	return &pb.Ref{Id: r.id, Def: r.def}, nil
 }
 
+// RunFromEnvironment runs f as a frontend started by the gateway frontend of
+// BuildKit and returns the result to the daemon. It is the entrypoint of
+// frontend images. The gateway API is served over stdin and stdout of the
+// process and the build is described by environment variables:
+// BUILDKIT_FRONTEND_OPT_<n> holds the frontend options as key=value,
+// BUILDKIT_SESSION_ID the client session, BUILDKIT_WORKERS the JSON encoded
+// workers and BUILDKIT_EXPORTEDPRODUCT the name of the daemon. Frontends
+// should not write to stdout.
 func RunFromEnvironment(ctx context.Context, f client.BuildFunc) error {
 	client, err := current()
 	if err != nil {
@@ -115,8 +126,10 @@ func (c *grpcClient) Run(ctx context.Context, f client.BuildFunc) (retError erro
 				if res == nil {
 					res = &client.Result{}
 				}
+				md, atts := c.resultMetadata(res)
 				pbRes := &pb.Result{
-					Metadata: res.Metadata,
+					Metadata:    md,
+					Attachments: atts,
 				}
 				if res.Refs != nil {
 					if c.caps.Supports(pb.CapProtoRefArray) == nil {
@@ -197,7 +210,8 @@ func (c *grpcClient) Run(ctx context.Context, f client.BuildFunc) (retError erro
 	}
 
 	if !export {
-		exportedAttrBytes, err := json.Marshal(res.Metadata)
+		md, _ := c.resultMetadata(res)
+		exportedAttrBytes, err := json.Marshal(md)
 		if err != nil {
 			return errors.Wrapf(err, "failed to marshal return metadata")
 		}
@@ -390,6 +404,7 @@ func (c *grpcClient) Solve(ctx context.Context, creq client.SolveRequest) (res *
 		res.SetRef(&reference{id: resp.Ref, c: c})
 	} else {
 		res.Metadata = resp.Result.Metadata
+		res.Attachments = pb.AttachmentsFromPB(resp.Result.Attachments)
 		switch pbRes := resp.Result.Result.(type) {
 		case *pb.Result_RefDeprecated:
 			if id := pbRes.RefDeprecated; id != "" {
@@ -428,6 +443,26 @@ func (c *grpcClient) Solve(ctx context.Context, creq client.SolveRequest) (res *
 	return res, nil
 }
 
+// resultMetadata returns the metadata and attachments to return for res. If
+// the gateway doesn't support attachments they are passed as the metadata keys
+// that older daemons expect instead.
+func (c *grpcClient) resultMetadata(res *client.Result) (map[string][]byte, map[string]*pb.Attachments) {
+	if len(res.Attachments) == 0 {
+		return res.Metadata, nil
+	}
+	if c.caps.Supports(pb.CapGatewayResultAttachments) == nil {
+		return res.Metadata, pb.AttachmentsToPB(res.Attachments)
+	}
+	md := make(map[string][]byte, len(res.Metadata))
+	for k, v := range res.Metadata {
+		md[k] = v
+	}
+	for k, v := range exptypes.MetadataFromAttachments(res.Attachments) {
+		md[k] = v
+	}
+	return md, nil
+}
+
 func (c *grpcClient) ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt) (digest.Digest, []byte, error) {
 	var p *opspb.Platform
 	if platform := opt.Platform; platform != nil {
@@ -439,13 +474,71 @@ func (c *grpcClient) ResolveImageConfig(ctx context.Context, ref string, opt llb
 			OSFeatures:   platform.OSFeatures,
 		}
 	}
-	resp, err := c.client.ResolveImageConfig(ctx, &pb.ResolveImageConfigRequest{Ref: ref, Platform: p, ResolveMode: opt.ResolveMode, LogName: opt.LogName})
+	req := &pb.ResolveImageConfigRequest{
+		ResolverType: int32(opt.ResolverType),
+		Ref:          ref,
+		Platform:     p,
+		ResolveMode:  opt.ResolveMode,
+		LogName:      opt.LogName,
+		SessionID:    opt.Store.SessionID,
+		StoreID:      opt.Store.StoreID,
+	}
+	if opt.ResolverType == llb.ResolverTypeOCILayout {
+		if err := c.caps.Supports(pb.CapSourceMetaResolverOCILayout); err != nil {
+			return "", nil, err
+		}
+	}
+	resp, err := c.client.ResolveImageConfig(ctx, req)
 	if err != nil {
 		return "", nil, err
 	}
 	return resp.Digest, resp.Config, nil
 }
 
+func (c *grpcClient) ResolveSourceMetadata(ctx context.Context, op *opspb.SourceOp, opt llb.ResolveSourceMetadataOpt) (*llb.SourceMetadata, error) {
+	if err := c.caps.Supports(pb.CapGatewayResolveSourceMetadata); err != nil {
+		return nil, err
+	}
+	var p *opspb.Platform
+	if platform := opt.Platform; platform != nil {
+		p = &opspb.Platform{
+			OS:           platform.OS,
+			Architecture: platform.Architecture,
+			Variant:      platform.Variant,
+			OSVersion:    platform.OSVersion,
+			OSFeatures:   platform.OSFeatures,
+		}
+	}
+	resp, err := c.client.ResolveSourceMetadata(ctx, &pb.ResolveSourceMetadataRequest{
+		Source:   op,
+		Platform: p,
+		LogName:  opt.LogName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	md := &llb.SourceMetadata{}
+	if resp.Image != nil {
+		md.Image = &llb.ImageSourceMetadata{
+			Digest: resp.Image.Digest,
+			Config: resp.Image.Config,
+		}
+	}
+	if resp.Git != nil {
+		md.Git = &llb.GitSourceMetadata{
+			Ref:    resp.Git.Ref,
+			Commit: resp.Git.Commit,
+		}
+	}
+	if resp.HTTP != nil {
+		md.HTTP = &llb.HTTPSourceMetadata{
+			Checksum: resp.HTTP.Checksum,
+			ETag:     resp.HTTP.ETag,
+		}
+	}
+	return md, nil
+}
+
 func (c *grpcClient) BuildOpts() client.BuildOpts {
 	return client.BuildOpts{
 		Opts:      c.opts,
@@ -479,6 +572,37 @@ func (c *grpcClient) Inputs(ctx context.Context) (map[string]llb.State, error) {
 	return inputs, nil
 }
 
+func (c *grpcClient) Warn(ctx context.Context, dgst digest.Digest, msg string, opts client.WarnOpts) error {
+	if err := c.caps.Supports(pb.CapGatewayWarnings); err != nil {
+		return err
+	}
+	_, err := c.client.Warn(ctx, &pb.WarnRequest{
+		Digest: dgst,
+		Level:  int64(opts.Level),
+		Short:  []byte(msg),
+		Detail: opts.Detail,
+		Url:    opts.URL,
+		Info:   opts.SourceInfo,
+		Ranges: opts.Range,
+	})
+	return err
+}
+
+func (c *grpcClient) Progress(ctx context.Context, req client.ProgressRequest) error {
+	if err := c.caps.Supports(pb.CapGatewayProgress); err != nil {
+		return err
+	}
+	_, err := c.client.Progress(ctx, &pb.ProgressRequest{
+		ID:        req.ID,
+		Name:      req.Name,
+		Stream:    int64(req.Stream),
+		Data:      req.Data,
+		Completed: req.Completed,
+		Error:     req.Error,
+	})
+	return err
+}
+
 // procMessageForwarder is created per container process to act as the
 // communication channel between the process and the ExecProcess message
 // stream.
@@ -608,6 +732,8 @@ func debugMessage(msg *pb.ExecMessage) string {
 		return fmt.Sprintf("File Message %s, fd=%d, %d bytes", msg.ProcessID, m.File.Fd, len(m.File.Data))
 	case *pb.ExecMessage_Resize:
 		return fmt.Sprintf("Resize Message %s", msg.ProcessID)
+	case *pb.ExecMessage_Signal:
+		return fmt.Sprintf("Signal Message %s: %s", msg.ProcessID, m.Signal.Name)
 	case *pb.ExecMessage_Started:
 		return fmt.Sprintf("Started Message %s", msg.ProcessID)
 	case *pb.ExecMessage_Exit:
@@ -721,14 +847,18 @@ func (c *grpcClient) NewContainer(ctx context.Context, req client.NewContainerRe
 	}
 
 	return &container{
+		c:        c,
 		client:   c.client,
+		caps:     &c.caps,
 		id:       id,
 		execMsgs: c.execMsgs,
 	}, nil
 }
 
 type container struct {
+	c        *grpcClient
 	client   pb.LLBBridgeClient
+	caps     *apicaps.CapSet
 	id       string
 	execMsgs *messageForwarder
 }
@@ -782,6 +912,7 @@ func (ctr *container) Start(ctx context.Context, req client.StartRequest) (clien
 
 	ctrProc := &containerProcess{
 		execMsgs: ctr.execMsgs,
+		caps:     ctr.caps,
 		id:       pid,
 		eg:       eg,
 	}
@@ -896,6 +1027,21 @@ func (ctr *container) Start(ctx context.Context, req client.StartRequest) (clien
 	return ctrProc, nil
 }
 
+func (ctr *container) SnapshotMount(ctx context.Context, dest string) (client.Reference, error) {
+	if err := ctr.caps.Supports(pb.CapGatewayMountSnapshot); err != nil {
+		return nil, err
+	}
+	logrus.Debugf("|---> SnapshotMount %s %s", ctr.id, dest)
+	resp, err := ctr.client.SnapshotMount(ctx, &pb.SnapshotMountRequest{
+		ContainerID: ctr.id,
+		Dest:        dest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newReference(ctr.c, resp.Ref)
+}
+
 func (ctr *container) Release(ctx context.Context) error {
 	logrus.Debugf("|---> ReleaseContainer %s", ctr.id)
 	_, err := ctr.client.ReleaseContainer(ctx, &pb.ReleaseContainerRequest{
@@ -906,6 +1052,7 @@ func (ctr *container) Release(ctx context.Context) error {
 
 type containerProcess struct {
 	execMsgs *messageForwarder
+	caps     *apicaps.CapSet
 	id       string
 	eg       *errgroup.Group
 }
@@ -927,6 +1074,36 @@ func (ctrProc *containerProcess) Resize(_ context.Context, size client.WinSize)
 	})
 }
 
+func (ctrProc *containerProcess) Signal(_ context.Context, sig syscall.Signal) error {
+	if err := ctrProc.caps.Supports(pb.CapGatewayExecSignals); err != nil {
+		return err
+	}
+	name := sigToName[sig]
+	if name == "" {
+		return errors.Errorf("unsupported signal %d", sig)
+	}
+	return ctrProc.execMsgs.Send(&pb.ExecMessage{
+		ProcessID: ctrProc.id,
+		Input: &pb.ExecMessage_Signal{
+			Signal: &pb.SignalMessage{
+				Name: name,
+			},
+		},
+	})
+}
+
+// sigToName maps signals to the names sent to the gateway. Aliases map to
+// the first name in alphabetical order.
+var sigToName = func() map[syscall.Signal]string {
+	m := make(map[syscall.Signal]string, len(signal.SignalMap))
+	for name, sig := range signal.SignalMap {
+		if n, ok := m[sig]; !ok || name < n {
+			m[sig] = name
+		}
+	}
+	return m
+}()
+
 type reference struct {
 	c   *grpcClient
 	id  string
@@ -0,0 +1,47 @@
+package moby_buildkit_v1_frontend //nolint:golint
+
+import "github.com/moby/buildkit/exporter/containerimage/exptypes"
+
+// AttachmentsToPB converts the attachments of a result to their wire format.
+func AttachmentsToPB(atts exptypes.Attachments) map[string]*Attachments {
+	if len(atts) == 0 {
+		return nil
+	}
+	out := make(map[string]*Attachments, len(atts))
+	for k, refAtts := range atts {
+		pbAtts := &Attachments{}
+		for _, att := range refAtts {
+			pbAtts.Attachments = append(pbAtts.Attachments, &Attachment{
+				Type: string(att.Type),
+				Name: att.Name,
+				Data: att.Data,
+			})
+		}
+		out[k] = pbAtts
+	}
+	return out
+}
+
+// AttachmentsFromPB converts the attachments of a result from their wire
+// format.
+func AttachmentsFromPB(atts map[string]*Attachments) exptypes.Attachments {
+	if len(atts) == 0 {
+		return nil
+	}
+	out := make(exptypes.Attachments, len(atts))
+	for k, pbAtts := range atts {
+		if pbAtts == nil {
+			continue
+		}
+		refAtts := make([]exptypes.Attachment, 0, len(pbAtts.Attachments))
+		for _, att := range pbAtts.Attachments {
+			refAtts = append(refAtts, exptypes.Attachment{
+				Type: exptypes.AttachmentType(att.Type),
+				Name: att.Name,
+				Data: att.Data,
+			})
+		}
+		out[k] = refAtts
+	}
+	return out
+}
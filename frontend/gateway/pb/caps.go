@@ -47,6 +47,35 @@ const (
 	// results. This is generally used by the client to return and handle solve
 	// errors.
 	CapGatewayEvaluateSolve apicaps.CapID = "gateway.solve.evaluate"
+
+	// CapGatewayExecSignals can be used to check if the gateway supports
+	// sending signals to a process started with ExecProcess.
+	CapGatewayExecSignals apicaps.CapID = "gateway.exec.signals"
+
+	// CapGatewayMountSnapshot can be used to check if the gateway supports
+	// snapshotting a container mount into a ref.
+	CapGatewayMountSnapshot apicaps.CapID = "gateway.exec.snapshot"
+
+	// CapGatewayWarnings can be used to check if the gateway supports sending
+	// warnings to the progress stream of the build.
+	CapGatewayWarnings apicaps.CapID = "gateway.warnings"
+
+	// CapGatewayProgress can be used to check if the gateway supports
+	// reporting custom progress steps of the frontend.
+	CapGatewayProgress apicaps.CapID = "gateway.progress"
+
+	// CapGatewayResultAttachments can be used to check if the gateway
+	// supports typed attachments for the refs of a result.
+	CapGatewayResultAttachments apicaps.CapID = "gateway.result.attachments"
+
+	// CapSourceMetaResolverOCILayout can be used to check if ResolveImageConfig
+	// supports reading from oci-layout content stores of the client.
+	CapSourceMetaResolverOCILayout apicaps.CapID = "source.metaresolver.ocilayout"
+
+	// CapGatewayResolveSourceMetadata can be used to check if the gateway
+	// supports resolving the immutable version of image, git and http
+	// sources.
+	CapGatewayResolveSourceMetadata apicaps.CapID = "gateway.resolvesourcemetadata"
 )
 
 func init() {
@@ -169,4 +198,53 @@ func init() {
 		Enabled: true,
 		Status:  apicaps.CapStatusExperimental,
 	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapGatewayExecSignals,
+		Name:    "gateway exec signals",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapGatewayMountSnapshot,
+		Name:    "gateway mount snapshot",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapGatewayWarnings,
+		Name:    "gateway warnings",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapGatewayProgress,
+		Name:    "gateway progress",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapGatewayResultAttachments,
+		Name:    "gateway result attachments",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapSourceMetaResolverOCILayout,
+		Name:    "source meta resolver oci-layout",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapGatewayResolveSourceMetadata,
+		Name:    "resolve source metadata",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
 }
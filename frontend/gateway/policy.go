@@ -0,0 +1,276 @@
+package gateway
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/distribution/reference"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver/errdefs"
+	"github.com/moby/buildkit/util/resolver"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// signatureAnnotation is the layer annotation that holds the base64
+	// encoded signature of the layer payload in cosign signature manifests.
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+	// maxSignatureBlobSize limits the size of signature manifests and
+	// payloads read from the registry.
+	maxSignatureBlobSize = 4 << 20
+)
+
+// PolicyOpt configures which frontend images the gateway frontend runs.
+type PolicyOpt struct {
+	// AllowedRepositories lists the repositories frontend images can be
+	// loaded from. A trailing "/*" allows all repositories under a prefix.
+	// All repositories are allowed if empty.
+	AllowedRepositories []string
+	// RequireDigest denies frontend images that are not pinned by digest.
+	RequireDigest bool
+	// PublicKeys are PEM encoded public keys. If set, frontend images must
+	// have a cosign compatible signature made with one of the keys.
+	PublicKeys [][]byte
+	// RegistryHosts is used for pulling the signatures.
+	RegistryHosts docker.RegistryHosts
+}
+
+// Policy is the frontend policy of the daemon. A nil Policy allows all
+// frontends.
+type Policy struct {
+	repos         []string
+	prefixes      []string
+	requireDigest bool
+	keys          []crypto.PublicKey
+	hosts         docker.RegistryHosts
+}
+
+func NewPolicy(opt PolicyOpt) (*Policy, error) {
+	p := &Policy{
+		requireDigest: opt.RequireDigest,
+		hosts:         opt.RegistryHosts,
+	}
+	for _, r := range opt.AllowedRepositories {
+		prefix := strings.HasSuffix(r, "/*")
+		named, err := reference.ParseNormalizedNamed(strings.TrimSuffix(r, "/*"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid allowed frontend repository %s", r)
+		}
+		if !reference.IsNameOnly(named) {
+			return nil, errors.Errorf("allowed frontend repository %s can't have a tag or digest", r)
+		}
+		if prefix {
+			p.prefixes = append(p.prefixes, named.Name()+"/")
+		} else {
+			p.repos = append(p.repos, named.Name())
+		}
+	}
+	for i, dt := range opt.PublicKeys {
+		k, err := parsePublicKey(dt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid frontend public key %d", i)
+		}
+		p.keys = append(p.keys, k)
+	}
+	return p, nil
+}
+
+// checkReference validates the frontend reference before it is resolved.
+func (p *Policy) checkReference(source string, named reference.Named) error {
+	if p == nil {
+		return nil
+	}
+	if !p.allowed(named.Name()) {
+		return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyNotAllowed, errors.Errorf("repository %s is not allowed", named.Name()))
+	}
+	if _, ok := named.(reference.Digested); p.requireDigest && !ok {
+		return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyDigestRequired, errors.New("frontend image must be pinned by digest"))
+	}
+	return nil
+}
+
+// checkDevel validates the use of a development frontend.
+func (p *Policy) checkDevel() error {
+	if p == nil || (len(p.repos) == 0 && len(p.prefixes) == 0 && !p.requireDigest && len(p.keys) == 0) {
+		return nil
+	}
+	return errdefs.NewFrontendPolicyError("", errdefs.FrontendPolicyDevel, errors.New("development frontends are not allowed by frontend policy"))
+}
+
+func (p *Policy) allowed(name string) bool {
+	if len(p.repos) == 0 && len(p.prefixes) == 0 {
+		return true
+	}
+	for _, r := range p.repos {
+		if r == name {
+			return true
+		}
+	}
+	for _, pfx := range p.prefixes {
+		if strings.HasPrefix(name, pfx) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySignature checks that the manifest dgst of the frontend image has a
+// signature made with one of the trusted keys. Signatures are looked up with
+// the cosign convention of a <algorithm>-<hex>.sig tag in the repository of
+// the image.
+func (p *Policy) verifySignature(ctx context.Context, source string, named reference.Named, dgst digest.Digest, sm *session.Manager, g session.Group) error {
+	if p == nil || len(p.keys) == 0 {
+		return nil
+	}
+	if dgst == "" {
+		return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyInvalidSignature, errors.New("could not resolve frontend manifest digest"))
+	}
+	sigRef := named.Name() + ":" + dgst.Algorithm().String() + "-" + dgst.Hex() + ".sig"
+
+	r := resolver.DefaultPool.GetResolver(p.hosts, sigRef, "pull", sm, g)
+	_, desc, err := r.Resolve(ctx, sigRef)
+	if err != nil {
+		return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyInvalidSignature, errors.Wrapf(err, "failed to resolve signature %s", sigRef))
+	}
+	fetcher, err := r.Fetcher(ctx, sigRef)
+	if err != nil {
+		return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyInvalidSignature, errors.Wrapf(err, "failed to fetch signature %s", sigRef))
+	}
+	dt, err := fetchBlob(ctx, fetcher, desc)
+	if err != nil {
+		return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyInvalidSignature, errors.Wrapf(err, "failed to fetch signature %s", sigRef))
+	}
+	var mfst specs.Manifest
+	if err := json.Unmarshal(dt, &mfst); err != nil {
+		return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyInvalidSignature, errors.Wrapf(err, "failed to parse signature manifest %s", sigRef))
+	}
+
+	for _, l := range mfst.Layers {
+		sig, ok := l.Annotations[signatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, err := fetchBlob(ctx, fetcher, l)
+		if err != nil {
+			return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyInvalidSignature, errors.Wrapf(err, "failed to fetch signature payload %s", l.Digest))
+		}
+		if p.verifyPayload(payload, sig, dgst) == nil {
+			return nil
+		}
+	}
+	return errdefs.NewFrontendPolicyError(source, errdefs.FrontendPolicyInvalidSignature, errors.Errorf("no valid signature for %s", dgst))
+}
+
+// verifyPayload checks the signature of a simple signing payload and that the
+// payload is for the manifest dgst.
+func (p *Policy) verifyPayload(payload []byte, sig string, dgst digest.Digest) error {
+	rawSig, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature encoding")
+	}
+	verified := false
+	for _, k := range p.keys {
+		if verifySignature(k, payload, rawSig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return errors.New("signature doesn't match any trusted key")
+	}
+
+	var ss struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest digest.Digest `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &ss); err != nil {
+		return errors.Wrap(err, "invalid signature payload")
+	}
+	if ss.Critical.Image.DockerManifestDigest != dgst {
+		return errors.Errorf("signature is for %s, not %s", ss.Critical.Image.DockerManifestDigest, dgst)
+	}
+	return nil
+}
+
+func verifySignature(k crypto.PublicKey, payload, sig []byte) bool {
+	switch k := k.(type) {
+	case *ecdsa.PublicKey:
+		var es struct {
+			R, S *big.Int
+		}
+		if rest, err := asn1.Unmarshal(sig, &es); err != nil || len(rest) != 0 {
+			return false
+		}
+		h := sha256.Sum256(payload)
+		return ecdsa.Verify(k, h[:], es.R, es.S)
+	case *rsa.PublicKey:
+		h := sha256.Sum256(payload)
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, h[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	default:
+		return false
+	}
+}
+
+func parsePublicKey(dt []byte) (crypto.PublicKey, error) {
+	b, _ := pem.Decode(dt)
+	if b == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	k, err := x509.ParsePKIXPublicKey(b.Bytes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	switch k.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return k, nil
+	default:
+		return nil, errors.Errorf("unsupported public key type %T", k)
+	}
+}
+
+func fetchBlob(ctx context.Context, f remotes.Fetcher, desc specs.Descriptor) ([]byte, error) {
+	if desc.Size > maxSignatureBlobSize {
+		return nil, errors.Errorf("blob %s is too big: %d", desc.Digest, desc.Size)
+	}
+	rc, err := f.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	dt, err := ioutil.ReadAll(io.LimitReader(rc, maxSignatureBlobSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(dt) > maxSignatureBlobSize {
+		return nil, errors.Errorf("blob %s is too big", desc.Digest)
+	}
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if desc.Digest.Algorithm().FromBytes(dt) != desc.Digest {
+		return nil, errors.Errorf("digest mismatch for %s", desc.Digest)
+	}
+	return dt, nil
+}
@@ -0,0 +1,119 @@
+package gateway
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/moby/buildkit/solver/errdefs"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyCheckReference(t *testing.T) {
+	p, err := NewPolicy(PolicyOpt{
+		AllowedRepositories: []string{"docker/dockerfile", "registry.example.com/frontends/*"},
+		RequireDigest:       true,
+	})
+	require.NoError(t, err)
+
+	dgst := digest.FromBytes([]byte("frontend"))
+	tcs := []struct {
+		ref    string
+		reason string
+	}{
+		{ref: "docker/dockerfile@" + dgst.String()},
+		{ref: "docker.io/docker/dockerfile:1@" + dgst.String()},
+		{ref: "registry.example.com/frontends/foo@" + dgst.String()},
+		{ref: "registry.example.com/frontends/foo/bar@" + dgst.String()},
+		{ref: "docker/dockerfile:1", reason: errdefs.FrontendPolicyDigestRequired},
+		{ref: "docker/dockerfile-upstream@" + dgst.String(), reason: errdefs.FrontendPolicyNotAllowed},
+		{ref: "registry.example.com/frontends@" + dgst.String(), reason: errdefs.FrontendPolicyNotAllowed},
+		{ref: "example.com/evil:latest", reason: errdefs.FrontendPolicyNotAllowed},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.ref, func(t *testing.T) {
+			named, err := reference.ParseNormalizedNamed(tc.ref)
+			require.NoError(t, err)
+			err = p.checkReference(tc.ref, named)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+			var pe *errdefs.FrontendPolicyError
+			require.True(t, errors.As(err, &pe))
+			require.Equal(t, tc.reason, pe.Reason)
+			require.Equal(t, tc.ref, pe.Frontend)
+		})
+	}
+
+	require.Error(t, p.checkDevel())
+
+	var nilPolicy *Policy
+	named, err := reference.ParseNormalizedNamed("example.com/any:latest")
+	require.NoError(t, err)
+	require.NoError(t, nilPolicy.checkReference("example.com/any:latest", named))
+	require.NoError(t, nilPolicy.checkDevel())
+
+	_, err = NewPolicy(PolicyOpt{AllowedRepositories: []string{"docker/dockerfile:1"}})
+	require.Error(t, err)
+}
+
+func TestPolicyVerifyPayload(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p, err := NewPolicy(PolicyOpt{
+		PublicKeys: [][]byte{pemPublicKey(t, ecKey.Public()), pemPublicKey(t, edPub)},
+	})
+	require.NoError(t, err)
+
+	dgst := digest.FromBytes([]byte("manifest"))
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"docker.io/docker/dockerfile"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, dgst))
+
+	h := sha256.Sum256(payload)
+	ecSig, err := ecKey.Sign(rand.Reader, h[:], crypto.SHA256)
+	require.NoError(t, err)
+	require.NoError(t, p.verifyPayload(payload, base64.StdEncoding.EncodeToString(ecSig), dgst))
+
+	edSig := ed25519.Sign(edKey, payload)
+	require.NoError(t, p.verifyPayload(payload, base64.StdEncoding.EncodeToString(edSig), dgst))
+
+	// signature for another manifest
+	err = p.verifyPayload(payload, base64.StdEncoding.EncodeToString(ecSig), digest.FromBytes([]byte("other")))
+	require.Error(t, err)
+
+	// signature from an untrusted key
+	otherSig, err := otherKey.Sign(rand.Reader, h[:], crypto.SHA256)
+	require.NoError(t, err)
+	err = p.verifyPayload(payload, base64.StdEncoding.EncodeToString(otherSig), dgst)
+	require.Error(t, err)
+
+	// modified payload
+	err = p.verifyPayload(append(payload, ' '), base64.StdEncoding.EncodeToString(ecSig), dgst)
+	require.Error(t, err)
+
+	_, err = NewPolicy(PolicyOpt{PublicKeys: [][]byte{[]byte("not a key")}})
+	require.Error(t, err)
+}
+
+func pemPublicKey(t *testing.T, k crypto.PublicKey) []byte {
+	dt, err := x509.MarshalPKIXPublicKey(k)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: dt})
+}
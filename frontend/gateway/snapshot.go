@@ -0,0 +1,144 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/client/llb"
+	gwclient "github.com/moby/buildkit/frontend/gateway/client"
+	pb "github.com/moby/buildkit/frontend/gateway/pb"
+	opspb "github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/source"
+	"github.com/moby/buildkit/source/mountsnapshot"
+	"github.com/pkg/errors"
+	fstypes "github.com/tonistiigi/fsutil/types"
+)
+
+// SnapshotMount commits the writable mount at dest of a container created
+// with NewContainer into an immutable ref. The container must not be running
+// and can't start new processes afterwards. The returned ref is owned by the
+// caller and can be loaded in LLB with MountSnapshotDefinition by the build
+// that created the container as long as it is not released.
+func SnapshotMount(ctx context.Context, ctr gwclient.Container, dest string) (cache.ImmutableRef, error) {
+	gwCtr, ok := ctr.(*gatewayContainer)
+	if !ok {
+		return nil, errors.Errorf("invalid container type %T", ctr)
+	}
+	return gwCtr.snapshot(ctx, dest)
+}
+
+// SnapshotMount implements client.Container. The snapshot is loaded as a
+// reference of the build that created the container.
+func (gwCtr *gatewayContainer) SnapshotMount(ctx context.Context, dest string) (gwclient.Reference, error) {
+	if gwCtr.loadSnapshot == nil {
+		return nil, errors.Errorf("container %s was created without support for mount snapshots", gwCtr.id)
+	}
+	ref, err := gwCtr.snapshot(ctx, dest)
+	if err != nil {
+		return nil, err
+	}
+	return gwCtr.loadSnapshot(ctx, ref, dest)
+}
+
+func (gwCtr *gatewayContainer) snapshot(ctx context.Context, dest string) (cache.ImmutableRef, error) {
+	gwCtr.mu.Lock()
+	defer gwCtr.mu.Unlock()
+
+	if gwCtr.started {
+		select {
+		case <-gwCtr.exited:
+		default:
+			return nil, errors.Errorf("container %s is still running", gwCtr.id)
+		}
+	}
+
+	for i, o := range gwCtr.outputs {
+		if o.dest != dest {
+			continue
+		}
+		switch ref := o.ref.(type) {
+		case cache.MutableRef:
+			iref, err := ref.Commit(ctx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to commit mount %s", dest)
+			}
+			gwCtr.outputs[i].ref = iref
+			gwCtr.snapshotted = true
+			if err := mountsnapshot.SetOwner(iref, gwCtr.group); err != nil {
+				return nil, err
+			}
+			if err := cache.SetRecordType(iref, client.UsageRecordTypeMountSnapshot); err != nil {
+				return nil, err
+			}
+			return iref.Clone(), nil
+		case cache.ImmutableRef:
+			if cache.GetRecordType(ref) == client.UsageRecordTypeMountSnapshot {
+				return ref.Clone(), nil
+			}
+			return nil, errors.Errorf("mount %s of container %s is read-only", dest, gwCtr.id)
+		}
+	}
+	return nil, errors.Errorf("container %s has no writable mount at %s", gwCtr.id, dest)
+}
+
+// MountSnapshotDefinition returns the LLB definition that loads a ref
+// returned by SnapshotMount.
+func MountSnapshotDefinition(ctx context.Context, ref cache.ImmutableRef, dest string) (*opspb.Definition, error) {
+	st := llb.NewState(llb.NewSource(source.MountSnapshotScheme+"://"+ref.ID(), nil, llb.Constraints{}).Output())
+	def, err := st.Marshal(ctx, llb.WithCustomName("[internal] load snapshot of "+dest))
+	if err != nil {
+		return nil, err
+	}
+	return def.ToPB(), nil
+}
+
+// forwarderRef is a reference to a ref of the forwarder of the build.
+type forwarderRef struct {
+	lbf *llbBridgeForwarder
+	id  string
+	def *opspb.Definition
+}
+
+func (r *forwarderRef) ToState() (llb.State, error) {
+	defop, err := llb.NewDefinitionOp(r.def)
+	if err != nil {
+		return llb.State{}, err
+	}
+	return llb.NewState(defop), nil
+}
+
+func (r *forwarderRef) ReadFile(ctx context.Context, req gwclient.ReadRequest) ([]byte, error) {
+	rfr := &pb.ReadFileRequest{FilePath: req.Filename, Ref: r.id}
+	if rng := req.Range; rng != nil {
+		rfr.Range = &pb.FileRange{
+			Offset: int64(rng.Offset),
+			Length: int64(rng.Length),
+		}
+	}
+	resp, err := r.lbf.ReadFile(ctx, rfr)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (r *forwarderRef) ReadDir(ctx context.Context, req gwclient.ReadDirRequest) ([]*fstypes.Stat, error) {
+	resp, err := r.lbf.ReadDir(ctx, &pb.ReadDirRequest{
+		DirPath:        req.Path,
+		IncludePattern: req.IncludePattern,
+		Ref:            r.id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Entries, nil
+}
+
+func (r *forwarderRef) StatFile(ctx context.Context, req gwclient.StatRequest) (*fstypes.Stat, error) {
+	resp, err := r.lbf.StatFile(ctx, &pb.StatFileRequest{Path: req.Path, Ref: r.id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Stat, nil
+}
@@ -1,13 +1,15 @@
 package frontend
 
 import (
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/solver"
 )
 
 type Result struct {
-	Ref      solver.ResultProxy
-	Refs     map[string]solver.ResultProxy
-	Metadata map[string][]byte
+	Ref         solver.ResultProxy
+	Refs        map[string]solver.ResultProxy
+	Metadata    map[string][]byte
+	Attachments exptypes.Attachments
 }
 
 func (r *Result) EachRef(fn func(solver.ResultProxy) error) (err error) {
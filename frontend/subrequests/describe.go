@@ -33,7 +33,7 @@ func Describe(ctx context.Context, c client.Client) ([]Request, error) {
 
 	res, err := c.Solve(ctx, client.SolveRequest{
 		FrontendOpt: map[string]string{
-			"requestid":     RequestSubrequestsDescribe,
+			KeyRequestID:    RequestSubrequestsDescribe,
 			"frontend.caps": "moby.buildkit.frontend.subrequests",
 		},
 		Frontend: "dockerfile.v0",
@@ -0,0 +1,71 @@
+package subrequests
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/errdefs"
+)
+
+// KeyRequestID is the frontend option that selects the subrequest.
+const KeyRequestID = "requestid"
+
+// Handler handles a subrequest supported by a frontend.
+type Handler struct {
+	Request
+	Handle func(ctx context.Context, c client.Client) (*client.Result, error)
+}
+
+// Dispatch calls the handler of the subrequest selected with the frontend
+// options of the build. ok is false if the build is not a subrequest. The
+// describe subrequest is answered with the definitions of the handlers, and
+// unknown subrequests return an UnsupportedSubrequestError.
+func Dispatch(ctx context.Context, c client.Client, handlers ...Handler) (res *client.Result, ok bool, err error) {
+	req, ok := c.BuildOpts().Opts[KeyRequestID]
+	if !ok {
+		return nil, false, nil
+	}
+	if req == RequestSubrequestsDescribe {
+		res, err := describe(handlers)
+		return res, true, err
+	}
+	for _, h := range handlers {
+		if h.Name == req {
+			res, err := h.Handle(ctx, c)
+			return res, true, err
+		}
+	}
+	return nil, true, errdefs.NewUnsupportedSubrequestError(req)
+}
+
+// NewResult returns the result of a subrequest with v as result.json and txt
+// as result.txt metadata.
+func NewResult(v interface{}, txt string) (*client.Result, error) {
+	dt, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	res := client.NewResult()
+	res.Metadata = map[string][]byte{
+		"result.json": dt,
+		"result.txt":  []byte(txt),
+	}
+	return res, nil
+}
+
+func describe(handlers []Handler) (*client.Result, error) {
+	all := []Request{SubrequestsDescribeDefinition}
+	for _, h := range handlers {
+		all = append(all, h.Request)
+	}
+	dt, err := json.MarshalIndent(all, "  ", "")
+	if err != nil {
+		return nil, err
+	}
+	res := client.NewResult()
+	res.Metadata = map[string][]byte{
+		"result.json": dt,
+	}
+	return res, nil
+}
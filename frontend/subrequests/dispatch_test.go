@@ -0,0 +1,52 @@
+package subrequests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/frontend/gateway/client/clienttest"
+	"github.com/moby/buildkit/solver/errdefs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatch(t *testing.T) {
+	ctx := context.TODO()
+
+	handlers := []Handler{{
+		Request: TargetsDefinition,
+		Handle: func(ctx context.Context, c client.Client) (*client.Result, error) {
+			l := List{Targets: []Target{{Name: "release", Default: true}}}
+			return NewResult(l, l.String())
+		},
+	}}
+	dispatch := func(opts map[string]string) (*client.Result, bool, error) {
+		return Dispatch(ctx, clienttest.New(clienttest.Opt{Opts: opts}), handlers...)
+	}
+
+	_, ok, err := dispatch(nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	res, ok, err := dispatch(map[string]string{KeyRequestID: RequestTargets})
+	require.NoError(t, err)
+	require.True(t, ok)
+	var l List
+	require.NoError(t, json.Unmarshal(res.Metadata["result.json"], &l))
+	require.Equal(t, "release", l.Targets[0].Name)
+	require.Contains(t, string(res.Metadata["result.txt"]), "release")
+
+	res, ok, err = dispatch(map[string]string{KeyRequestID: RequestSubrequestsDescribe})
+	require.NoError(t, err)
+	require.True(t, ok)
+	var reqs []Request
+	require.NoError(t, json.Unmarshal(res.Metadata["result.json"], &reqs))
+	require.Equal(t, []Request{SubrequestsDescribeDefinition, TargetsDefinition}, reqs)
+
+	_, ok, err = dispatch(map[string]string{KeyRequestID: RequestLint})
+	require.True(t, ok)
+	var reqErr *errdefs.UnsupportedSubrequestError
+	require.True(t, errors.As(err, &reqErr))
+}
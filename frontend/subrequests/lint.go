@@ -0,0 +1,58 @@
+package subrequests
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moby/buildkit/solver/pb"
+)
+
+const RequestLint = "frontend.lint"
+
+var LintDefinition = Request{
+	Name:        RequestLint,
+	Version:     "1.0.0",
+	Type:        TypeRPC,
+	Description: "Check the build definition for problems without building it",
+	Opts:        []Named{},
+	Metadata: []Named{
+		{
+			Name: "result.json",
+		},
+		{
+			Name: "result.txt",
+		},
+	},
+}
+
+// LintResults is returned as result.json by the frontend.lint subrequest.
+type LintResults struct {
+	Warnings []LintWarning `json:"warnings"`
+	// Error is set if the warnings would fail the build
+	Error bool `json:"error,omitempty"`
+}
+
+type LintWarning struct {
+	RuleName    string      `json:"ruleName"`
+	Description string      `json:"description,omitempty"`
+	Detail      string      `json:"detail"`
+	Filename    string      `json:"filename,omitempty"`
+	Ranges      []*pb.Range `json:"ranges,omitempty"`
+}
+
+// String formats the results as returned in result.txt.
+func (r LintResults) String() string {
+	var b bytes.Buffer
+	for _, w := range r.Warnings {
+		if len(w.Ranges) > 0 {
+			fmt.Fprintf(&b, "%s:%d: ", w.Filename, w.Ranges[0].Start.Line)
+		} else if w.Filename != "" {
+			fmt.Fprintf(&b, "%s: ", w.Filename)
+		}
+		fmt.Fprintf(&b, "%s: %s\n", w.RuleName, w.Detail)
+	}
+	if len(r.Warnings) == 0 {
+		b.WriteString("No problems found.\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package subrequests
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/moby/buildkit/solver/pb"
+)
+
+const RequestFrontendOutline = "frontend.outline"
+
+var OutlineDefinition = Request{
+	Name:        RequestFrontendOutline,
+	Version:     "1.0.0",
+	Type:        TypeRPC,
+	Description: "List all parameters current build target supports",
+	Opts: []Named{
+		{
+			Name:        "target",
+			Description: "Target build stage",
+		},
+	},
+	Metadata: []Named{
+		{
+			Name: "result.json",
+		},
+		{
+			Name: "result.txt",
+		},
+	},
+}
+
+// Outline is returned as result.json by the frontend.outline subrequest.
+type Outline struct {
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Args        []OutlineArg    `json:"args,omitempty"`
+	Secrets     []OutlineSecret `json:"secrets,omitempty"`
+	SSH         []OutlineSSH    `json:"ssh,omitempty"`
+	Cache       []OutlineCache  `json:"cache,omitempty"`
+	Sources     [][]byte        `json:"sources,omitempty"`
+}
+
+type OutlineArg struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Value       string    `json:"value,omitempty"`
+	Location    *Location `json:"location,omitempty"`
+}
+
+type OutlineSecret struct {
+	ID       string    `json:"id"`
+	Required bool      `json:"required,omitempty"`
+	Location *Location `json:"location,omitempty"`
+}
+
+type OutlineSSH struct {
+	ID       string    `json:"id"`
+	Required bool      `json:"required,omitempty"`
+	Location *Location `json:"location,omitempty"`
+}
+
+type OutlineCache struct {
+	ID       string    `json:"id"`
+	Target   string    `json:"target,omitempty"`
+	Location *Location `json:"location,omitempty"`
+}
+
+// Location points to the instruction that defines a value in the source
+// with the given index.
+type Location struct {
+	SourceIndex int32       `json:"sourceIndex"`
+	Ranges      []*pb.Range `json:"ranges,omitempty"`
+}
+
+// String formats the outline as returned in result.txt.
+func (o Outline) String() string {
+	var b bytes.Buffer
+	if o.Name != "" || o.Description != "" {
+		tw := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
+		if o.Name != "" {
+			fmt.Fprintf(tw, "TARGET:\t%s\n", o.Name)
+		}
+		if o.Description != "" {
+			fmt.Fprintf(tw, "DESCRIPTION:\t%s\n", o.Description)
+		}
+		tw.Flush()
+		fmt.Fprintln(&b)
+	}
+
+	if len(o.Args) > 0 {
+		tw := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(tw, "BUILD ARG\tVALUE\tDESCRIPTION\n")
+		for _, a := range o.Args {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", a.Name, a.Value, a.Description)
+		}
+		tw.Flush()
+		fmt.Fprintln(&b)
+	}
+
+	if len(o.Secrets) > 0 {
+		tw := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(tw, "SECRET\tREQUIRED\n")
+		for _, s := range o.Secrets {
+			fmt.Fprintf(tw, "%s\t%s\n", s.ID, yesNo(s.Required))
+		}
+		tw.Flush()
+		fmt.Fprintln(&b)
+	}
+
+	if len(o.SSH) > 0 {
+		tw := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(tw, "SSH\tREQUIRED\n")
+		for _, s := range o.SSH {
+			fmt.Fprintf(tw, "%s\t%s\n", s.ID, yesNo(s.Required))
+		}
+		tw.Flush()
+		fmt.Fprintln(&b)
+	}
+
+	if len(o.Cache) > 0 {
+		tw := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(tw, "CACHE\tTARGET\n")
+		for _, c := range o.Cache {
+			fmt.Fprintf(tw, "%s\t%s\n", c.ID, c.Target)
+		}
+		tw.Flush()
+		fmt.Fprintln(&b)
+	}
+
+	return b.String()
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,58 @@
+package subrequests
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+)
+
+const RequestTargets = "frontend.targets"
+
+var TargetsDefinition = Request{
+	Name:        RequestTargets,
+	Version:     "1.0.0",
+	Type:        TypeRPC,
+	Description: "List all targets current build supports",
+	Opts:        []Named{},
+	Metadata: []Named{
+		{
+			Name: "result.json",
+		},
+		{
+			Name: "result.txt",
+		},
+	},
+}
+
+// List is returned as result.json by the frontend.targets subrequest.
+type List struct {
+	Targets []Target `json:"targets"`
+	Sources [][]byte `json:"sources,omitempty"`
+}
+
+type Target struct {
+	Name        string    `json:"name,omitempty"`
+	Default     bool      `json:"default,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Base        string    `json:"base,omitempty"`
+	Platform    string    `json:"platform,omitempty"`
+	Location    *Location `json:"location,omitempty"`
+}
+
+// String formats the list as returned in result.txt.
+func (l List) String() string {
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "TARGET\tDESCRIPTION\n")
+	for _, t := range l.Targets {
+		name := t.Name
+		if name == "" && t.Default {
+			name = "(default)"
+		} else if t.Default {
+			name += " (default)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", name, t.Description)
+	}
+	tw.Flush()
+	return b.String()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	io "io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/moby/buildkit/session"
@@ -24,6 +25,7 @@ const (
 	keyFollowPaths        = "followpaths"
 	keyDirName            = "dir-name"
 	keyExporterMetaPrefix = "exporter-md-"
+	keyExporterID         = "exporter-id"
 )
 
 type fsSyncProvider struct {
@@ -232,23 +234,45 @@ func FSSync(ctx context.Context, c session.Caller, opt FSSendRequestOpt) error {
 
 // NewFSSyncTargetDir allows writing into a directory
 func NewFSSyncTargetDir(outdir string) session.Attachable {
-	p := &fsSyncTarget{
-		outdir: outdir,
-	}
-	return p
+	return NewFSSyncMultiTarget(WithFSSyncDir(0, outdir))
 }
 
 // NewFSSyncTarget allows writing into an io.WriteCloser
 func NewFSSyncTarget(f func(map[string]string) (io.WriteCloser, error)) session.Attachable {
+	return NewFSSyncMultiTarget(WithFSSync(0, f))
+}
+
+// FSSyncTarget is the destination of the files sent by the exporter with ID.
+type FSSyncTarget struct {
+	ID      int
+	OutDir  string
+	Outfile func(map[string]string) (io.WriteCloser, error)
+}
+
+// WithFSSyncDir sets a directory as the destination of the exporter with id.
+func WithFSSyncDir(id int, outdir string) FSSyncTarget {
+	return FSSyncTarget{ID: id, OutDir: outdir}
+}
+
+// WithFSSync sets an io.WriteCloser as the destination of the exporter with id.
+func WithFSSync(id int, f func(map[string]string) (io.WriteCloser, error)) FSSyncTarget {
+	return FSSyncTarget{ID: id, Outfile: f}
+}
+
+// NewFSSyncMultiTarget allows writing the files sent by multiple exporters
+// into their own destinations
+func NewFSSyncMultiTarget(targets ...FSSyncTarget) session.Attachable {
 	p := &fsSyncTarget{
-		f: f,
+		targets: make(map[int]FSSyncTarget, len(targets)),
+	}
+	for _, t := range targets {
+		p.targets[t.ID] = t
 	}
 	return p
 }
 
 type fsSyncTarget struct {
-	outdir string
-	f      func(map[string]string) (io.WriteCloser, error)
+	targets map[int]FSSyncTarget
 }
 
 func (sp *fsSyncTarget) Register(server *grpc.Server) {
@@ -256,21 +280,33 @@ func (sp *fsSyncTarget) Register(server *grpc.Server) {
 }
 
 func (sp *fsSyncTarget) DiffCopy(stream FileSend_DiffCopyServer) (err error) {
-	if sp.outdir != "" {
-		return syncTargetDiffCopy(stream, sp.outdir)
+	opts, _ := metadata.FromIncomingContext(stream.Context()) // if no metadata continue with empty object
+	id := 0
+	if v := opts.Get(keyExporterID); len(v) > 0 {
+		id, err = strconv.Atoi(v[0])
+		if err != nil {
+			return errors.Wrapf(err, "invalid exporter id %q", v[0])
+		}
+	}
+	t, ok := sp.targets[id]
+	if !ok {
+		return errors.Errorf("no target for exporter %d", id)
+	}
+
+	if t.OutDir != "" {
+		return syncTargetDiffCopy(stream, t.OutDir)
 	}
 
-	if sp.f == nil {
+	if t.Outfile == nil {
 		return errors.New("empty outfile and outdir")
 	}
-	opts, _ := metadata.FromIncomingContext(stream.Context()) // if no metadata continue with empty object
 	md := map[string]string{}
 	for k, v := range opts {
 		if strings.HasPrefix(k, keyExporterMetaPrefix) {
 			md[strings.TrimPrefix(k, keyExporterMetaPrefix)] = strings.Join(v, ",")
 		}
 	}
-	wc, err := sp.f(md)
+	wc, err := t.Outfile(md)
 	if err != nil {
 		return err
 	}
@@ -286,7 +322,9 @@ func (sp *fsSyncTarget) DiffCopy(stream FileSend_DiffCopyServer) (err error) {
 	return writeTargetFile(stream, wc)
 }
 
-func CopyToCaller(ctx context.Context, fs fsutil.FS, c session.Caller, progress func(int, bool)) error {
+// CopyToCaller sends the files of fs to the destination of the exporter with
+// id in the session of the caller.
+func CopyToCaller(ctx context.Context, fs fsutil.FS, id int, c session.Caller, progress func(int, bool)) error {
 	method := session.MethodURL(_FileSend_serviceDesc.ServiceName, "diffcopy")
 	if !c.Supports(method) {
 		return errors.Errorf("method %s not supported by the client", method)
@@ -294,6 +332,8 @@ func CopyToCaller(ctx context.Context, fs fsutil.FS, c session.Caller, progress
 
 	client := NewFileSendClient(c.Conn())
 
+	ctx = metadata.AppendToOutgoingContext(ctx, keyExporterID, strconv.Itoa(id))
+
 	cc, err := client.DiffCopy(ctx)
 	if err != nil {
 		return errors.WithStack(err)
@@ -302,7 +342,9 @@ func CopyToCaller(ctx context.Context, fs fsutil.FS, c session.Caller, progress
 	return sendDiffCopy(cc, fs, progress)
 }
 
-func CopyFileWriter(ctx context.Context, md map[string]string, c session.Caller) (io.WriteCloser, error) {
+// CopyFileWriter returns a writer for the file destination of the exporter
+// with id in the session of the caller.
+func CopyFileWriter(ctx context.Context, md map[string]string, id int, c session.Caller) (io.WriteCloser, error) {
 	method := session.MethodURL(_FileSend_serviceDesc.ServiceName, "diffcopy")
 	if !c.Supports(method) {
 		return nil, errors.Errorf("method %s not supported by the client", method)
@@ -310,10 +352,11 @@ func CopyFileWriter(ctx context.Context, md map[string]string, c session.Caller)
 
 	client := NewFileSendClient(c.Conn())
 
-	opts := make(map[string][]string, len(md))
+	opts := make(map[string][]string, len(md)+1)
 	for k, v := range md {
 		opts[keyExporterMetaPrefix+k] = []string{v}
 	}
+	opts[keyExporterID] = []string{strconv.Itoa(id)}
 
 	ctx = metadata.NewOutgoingContext(ctx, opts)
 
@@ -181,6 +181,54 @@ func (m *Subrequest) GetName() string {
 	return ""
 }
 
+type FrontendPolicy struct {
+	// Frontend is the image reference of the frontend that was denied.
+	Frontend string `protobuf:"bytes,1,opt,name=frontend,proto3" json:"frontend,omitempty"`
+	// Reason is a short machine-readable reason, e.g. "not-allowed".
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FrontendPolicy) Reset()         { *m = FrontendPolicy{} }
+func (m *FrontendPolicy) String() string { return proto.CompactTextString(m) }
+func (*FrontendPolicy) ProtoMessage()    {}
+func (*FrontendPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_689dc58a5060aff5, []int{4}
+}
+func (m *FrontendPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FrontendPolicy.Unmarshal(m, b)
+}
+func (m *FrontendPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FrontendPolicy.Marshal(b, m, deterministic)
+}
+func (m *FrontendPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FrontendPolicy.Merge(m, src)
+}
+func (m *FrontendPolicy) XXX_Size() int {
+	return xxx_messageInfo_FrontendPolicy.Size(m)
+}
+func (m *FrontendPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_FrontendPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FrontendPolicy proto.InternalMessageInfo
+
+func (m *FrontendPolicy) GetFrontend() string {
+	if m != nil {
+		return m.Frontend
+	}
+	return ""
+}
+
+func (m *FrontendPolicy) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 type Solve struct {
 	InputIDs []string `protobuf:"bytes,1,rep,name=inputIDs,proto3" json:"inputIDs,omitempty"`
 	MountIDs []string `protobuf:"bytes,2,rep,name=mountIDs,proto3" json:"mountIDs,omitempty"`
@@ -198,7 +246,7 @@ func (m *Solve) Reset()         { *m = Solve{} }
 func (m *Solve) String() string { return proto.CompactTextString(m) }
 func (*Solve) ProtoMessage()    {}
 func (*Solve) Descriptor() ([]byte, []int) {
-	return fileDescriptor_689dc58a5060aff5, []int{4}
+	return fileDescriptor_689dc58a5060aff5, []int{5}
 }
 func (m *Solve) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Solve.Unmarshal(m, b)
@@ -294,7 +342,7 @@ func (m *FileAction) Reset()         { *m = FileAction{} }
 func (m *FileAction) String() string { return proto.CompactTextString(m) }
 func (*FileAction) ProtoMessage()    {}
 func (*FileAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_689dc58a5060aff5, []int{5}
+	return fileDescriptor_689dc58a5060aff5, []int{6}
 }
 func (m *FileAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileAction.Unmarshal(m, b)
@@ -333,7 +381,7 @@ func (m *ContentCache) Reset()         { *m = ContentCache{} }
 func (m *ContentCache) String() string { return proto.CompactTextString(m) }
 func (*ContentCache) ProtoMessage()    {}
 func (*ContentCache) Descriptor() ([]byte, []int) {
-	return fileDescriptor_689dc58a5060aff5, []int{6}
+	return fileDescriptor_689dc58a5060aff5, []int{7}
 }
 func (m *ContentCache) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ContentCache.Unmarshal(m, b)
@@ -365,6 +413,7 @@ func init() {
 	proto.RegisterType((*Source)(nil), "errdefs.Source")
 	proto.RegisterType((*FrontendCap)(nil), "errdefs.FrontendCap")
 	proto.RegisterType((*Subrequest)(nil), "errdefs.Subrequest")
+	proto.RegisterType((*FrontendPolicy)(nil), "errdefs.FrontendPolicy")
 	proto.RegisterType((*Solve)(nil), "errdefs.Solve")
 	proto.RegisterType((*FileAction)(nil), "errdefs.FileAction")
 	proto.RegisterType((*ContentCache)(nil), "errdefs.ContentCache")
@@ -373,27 +422,29 @@ func init() {
 func init() { proto.RegisterFile("errdefs.proto", fileDescriptor_689dc58a5060aff5) }
 
 var fileDescriptor_689dc58a5060aff5 = []byte{
-	// 348 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcd, 0x8e, 0xd3, 0x30,
-	0x14, 0x85, 0x27, 0xbf, 0x43, 0x6e, 0x81, 0x85, 0x81, 0x51, 0x34, 0xab, 0x8c, 0xc5, 0xa2, 0x48,
-	0x90, 0x48, 0xc3, 0x13, 0x40, 0xd1, 0x68, 0x66, 0x55, 0xc9, 0x95, 0xd8, 0xc7, 0xc9, 0x4d, 0x6b,
-	0x48, 0x6c, 0xe3, 0xd8, 0xa8, 0xbc, 0x1b, 0x0f, 0x87, 0xe2, 0xa4, 0x65, 0x16, 0xdd, 0xe5, 0xe4,
-	0xfb, 0x7c, 0xed, 0x63, 0xc3, 0x2b, 0x34, 0xa6, 0xc5, 0x6e, 0x2c, 0xb5, 0x51, 0x56, 0x91, 0xeb,
-	0x25, 0xde, 0x7e, 0xdc, 0x0b, 0x7b, 0x70, 0xbc, 0x6c, 0xd4, 0x50, 0x0d, 0x8a, 0xff, 0xa9, 0xb8,
-	0x13, 0x7d, 0xfb, 0x53, 0xd8, 0x6a, 0x54, 0xfd, 0x6f, 0x34, 0x95, 0xe6, 0x95, 0xd2, 0xcb, 0x32,
-	0x5a, 0x40, 0xfa, 0x1d, 0x8d, 0xc5, 0x23, 0xb9, 0x81, 0xb4, 0x15, 0x7b, 0x1c, 0x6d, 0x1e, 0x14,
-	0xc1, 0x3a, 0x63, 0x4b, 0xa2, 0x5b, 0x48, 0x77, 0xca, 0x99, 0x06, 0x09, 0x85, 0x58, 0xc8, 0x4e,
-	0x79, 0xbe, 0xba, 0x7f, 0x5d, 0x6a, 0x5e, 0xce, 0xe4, 0x49, 0x76, 0x8a, 0x79, 0x46, 0xee, 0x20,
-	0x35, 0xb5, 0xdc, 0xe3, 0x98, 0x87, 0x45, 0xb4, 0x5e, 0xdd, 0x67, 0x93, 0xc5, 0xa6, 0x3f, 0x6c,
-	0x01, 0xf4, 0x0e, 0x56, 0x0f, 0x46, 0x49, 0x8b, 0xb2, 0xdd, 0xd4, 0x9a, 0x10, 0x88, 0x65, 0x3d,
-	0xe0, 0xb2, 0xab, 0xff, 0xa6, 0x05, 0xc0, 0xce, 0x71, 0x83, 0xbf, 0x1c, 0x8e, 0xf6, 0xa2, 0xf1,
-	0x37, 0x80, 0x64, 0x37, 0xf5, 0x21, 0xb7, 0xf0, 0x42, 0x48, 0xed, 0xec, 0xd3, 0xb7, 0x31, 0x0f,
-	0x8a, 0x68, 0x9d, 0xb1, 0x73, 0x9e, 0xd8, 0xa0, 0x9c, 0xf4, 0x2c, 0x9c, 0xd9, 0x29, 0x93, 0x1b,
-	0x08, 0x95, 0xce, 0x23, 0xdf, 0x25, 0x9d, 0x4e, 0xb9, 0xd5, 0x2c, 0x54, 0x9a, 0x7c, 0x80, 0xb8,
-	0x13, 0x3d, 0xe6, 0xb1, 0x27, 0x6f, 0xca, 0xd3, 0x35, 0x3f, 0x88, 0x1e, 0xbf, 0x34, 0x56, 0x28,
-	0xf9, 0x78, 0xc5, 0xbc, 0x42, 0x3e, 0x41, 0xd2, 0xd4, 0xcd, 0x01, 0xf3, 0xc4, 0xbb, 0xef, 0xce,
-	0xee, 0xc6, 0xd7, 0xb3, 0x9b, 0x09, 0x3e, 0x5e, 0xb1, 0xd9, 0xfa, 0x9a, 0xc1, 0xf5, 0xe8, 0xf8,
-	0x0f, 0x6c, 0x2c, 0xa5, 0x00, 0xff, 0xe7, 0x91, 0xb7, 0x90, 0x08, 0xd9, 0xe2, 0xd1, 0x37, 0x8c,
-	0xd8, 0x1c, 0xe8, 0x7b, 0x78, 0xf9, 0x7c, 0xce, 0x65, 0x8b, 0xa7, 0xfe, 0x1d, 0x3f, 0xff, 0x0b,
-	0x00, 0x00, 0xff, 0xff, 0x1e, 0xfa, 0x9c, 0x6f, 0x0f, 0x02, 0x00, 0x00,
+	// 375 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xcf, 0x6e, 0xd4, 0x30,
+	0x10, 0x87, 0xbb, 0xd9, 0xdd, 0x94, 0x9d, 0x85, 0x1e, 0x0c, 0x54, 0x51, 0x4f, 0xa9, 0xc5, 0xa1,
+	0x48, 0x90, 0x48, 0xe5, 0x09, 0x60, 0xab, 0xaa, 0x3d, 0x15, 0x79, 0x25, 0xee, 0x71, 0x32, 0xd9,
+	0x1a, 0xb2, 0x1e, 0xe3, 0x3f, 0xa8, 0x7d, 0x37, 0x1e, 0x0e, 0xd9, 0xc9, 0x96, 0x1e, 0x7a, 0xcb,
+	0xcf, 0xdf, 0x37, 0x93, 0xcc, 0xc4, 0xf0, 0x06, 0xad, 0xed, 0xb0, 0x77, 0x95, 0xb1, 0xe4, 0x89,
+	0x1d, 0x4f, 0xf1, 0xec, 0xd3, 0x4e, 0xf9, 0xfb, 0x20, 0xab, 0x96, 0xf6, 0xf5, 0x9e, 0xe4, 0x63,
+	0x2d, 0x83, 0x1a, 0xba, 0x5f, 0xca, 0xd7, 0x8e, 0x86, 0x3f, 0x68, 0x6b, 0x23, 0x6b, 0x32, 0x53,
+	0x19, 0x2f, 0x21, 0xff, 0x81, 0xd6, 0xe3, 0x03, 0x3b, 0x85, 0xbc, 0x53, 0x3b, 0x74, 0xbe, 0x98,
+	0x95, 0xb3, 0x8b, 0x95, 0x98, 0x12, 0xbf, 0x83, 0x7c, 0x4b, 0xc1, 0xb6, 0xc8, 0x38, 0x2c, 0x94,
+	0xee, 0x29, 0xf1, 0xf5, 0xe5, 0x49, 0x65, 0x64, 0x35, 0x92, 0x5b, 0xdd, 0x93, 0x48, 0x8c, 0x9d,
+	0x43, 0x6e, 0x1b, 0xbd, 0x43, 0x57, 0x64, 0xe5, 0xfc, 0x62, 0x7d, 0xb9, 0x8a, 0x96, 0x88, 0x27,
+	0x62, 0x02, 0xfc, 0x1c, 0xd6, 0xd7, 0x96, 0xb4, 0x47, 0xdd, 0x6d, 0x1a, 0xc3, 0x18, 0x2c, 0x74,
+	0xb3, 0xc7, 0xe9, 0xad, 0xe9, 0x99, 0x97, 0x00, 0xdb, 0x20, 0x2d, 0xfe, 0x0e, 0xe8, 0xfc, 0x8b,
+	0xc6, 0x15, 0x9c, 0x1c, 0x9a, 0x7c, 0xa7, 0x41, 0xb5, 0x8f, 0xec, 0x0c, 0x5e, 0xf5, 0xd3, 0xc9,
+	0x64, 0x3e, 0xe5, 0x38, 0x9b, 0xc5, 0xc6, 0x91, 0x2e, 0xb2, 0x71, 0xb6, 0x31, 0xf1, 0xbf, 0x33,
+	0x58, 0x6e, 0xe3, 0x56, 0x62, 0xb5, 0xd2, 0x26, 0xf8, 0xdb, 0x2b, 0x57, 0xcc, 0xca, 0x79, 0xac,
+	0x3e, 0xe4, 0xc8, 0xf6, 0x14, 0x74, 0x62, 0xd9, 0xc8, 0x0e, 0x99, 0x9d, 0x42, 0x46, 0xa6, 0x98,
+	0xa7, 0x8d, 0xe4, 0x71, 0xd6, 0x3b, 0x23, 0x32, 0x32, 0xec, 0x23, 0x2c, 0x7a, 0x35, 0x60, 0xb1,
+	0x48, 0xe4, 0x6d, 0x75, 0xf8, 0x59, 0xd7, 0x6a, 0xc0, 0xaf, 0xad, 0x57, 0xa4, 0x6f, 0x8e, 0x44,
+	0x52, 0xd8, 0x67, 0x58, 0xb6, 0x4d, 0x7b, 0x8f, 0xc5, 0x32, 0xb9, 0xef, 0x9f, 0xdc, 0x4d, 0xfa,
+	0x7a, 0xbf, 0x89, 0xf0, 0xe6, 0x48, 0x8c, 0xd6, 0xb7, 0x15, 0x1c, 0xbb, 0x20, 0x7f, 0x62, 0xeb,
+	0x39, 0x07, 0xf8, 0xdf, 0x8f, 0xbd, 0x83, 0xa5, 0xd2, 0x1d, 0x3e, 0xa4, 0xe9, 0xe7, 0x62, 0x0c,
+	0xfc, 0x03, 0xbc, 0x7e, 0xde, 0xe7, 0x65, 0x4b, 0xe6, 0xe9, 0x36, 0x7c, 0xf9, 0x37, 0x00, 0x0b,
+	0x7b, 0x9b, 0x4a, 0x55, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,59 @@
+package errdefs
+
+import (
+	fmt "fmt"
+
+	"github.com/containerd/typeurl"
+	"github.com/moby/buildkit/util/grpcerrors"
+)
+
+const (
+	// FrontendPolicyNotAllowed is the reason for frontends whose repository
+	// is not in the allow-list of the daemon.
+	FrontendPolicyNotAllowed = "not-allowed"
+	// FrontendPolicyDigestRequired is the reason for frontends that are not
+	// pinned by digest.
+	FrontendPolicyDigestRequired = "digest-required"
+	// FrontendPolicyInvalidSignature is the reason for frontends without a
+	// signature that verifies against the trusted keys of the daemon.
+	FrontendPolicyInvalidSignature = "invalid-signature"
+	// FrontendPolicyDevel is the reason for development frontends built from
+	// LLB that the policy can't be applied to.
+	FrontendPolicyDevel = "devel"
+)
+
+func init() {
+	typeurl.Register((*FrontendPolicy)(nil), "github.com/moby/buildkit", "errdefs.FrontendPolicy+json")
+}
+
+type FrontendPolicyError struct {
+	FrontendPolicy
+	error
+}
+
+func (e *FrontendPolicyError) Error() string {
+	msg := fmt.Sprintf("frontend %s denied by policy (%s)", e.FrontendPolicy.Frontend, e.FrontendPolicy.Reason)
+	if e.error != nil {
+		msg += ": " + e.error.Error()
+	}
+	return msg
+}
+
+func (e *FrontendPolicyError) Unwrap() error {
+	return e.error
+}
+
+func (e *FrontendPolicyError) ToProto() grpcerrors.TypedErrorProto {
+	return &e.FrontendPolicy
+}
+
+// NewFrontendPolicyError returns an error for a frontend that was denied by
+// the frontend policy of the daemon. err is optional and describes the reason
+// in more detail.
+func NewFrontendPolicyError(frontend, reason string, err error) error {
+	return &FrontendPolicyError{FrontendPolicy: FrontendPolicy{Frontend: frontend, Reason: reason}, error: err}
+}
+
+func (v *FrontendPolicy) WrapError(err error) error {
+	return &FrontendPolicyError{error: err, FrontendPolicy: *v}
+}
@@ -22,7 +22,7 @@ import (
 type ResolveOpFunc func(Vertex, Builder) (Op, error)
 
 type Builder interface {
-	Build(ctx context.Context, e Edge) (CachedResult, error)
+	Build(ctx context.Context, e Edge) (CachedResultWithProvenance, error)
 	InContext(ctx context.Context, f func(ctx context.Context, g session.Group) error) error
 	EachValue(ctx context.Context, key string, fn func(interface{}) error) error
 }
@@ -198,15 +198,15 @@ type subBuilder struct {
 	exporters []ExportableCacheKey
 }
 
-func (sb *subBuilder) Build(ctx context.Context, e Edge) (CachedResult, error) {
-	res, err := sb.solver.subBuild(ctx, e, sb.vtx)
+func (sb *subBuilder) Build(ctx context.Context, e Edge) (CachedResultWithProvenance, error) {
+	res, e, err := sb.solver.subBuild(ctx, e, sb.vtx)
 	if err != nil {
 		return nil, err
 	}
 	sb.mu.Lock()
 	sb.exporters = append(sb.exporters, res.CacheKeys()[0]) // all keys already have full export chain
 	sb.mu.Unlock()
-	return res, nil
+	return &withProvenance{CachedResult: res, solver: sb.solver, e: e}, nil
 }
 
 func (sb *subBuilder) InContext(ctx context.Context, f func(context.Context, session.Group) error) error {
@@ -290,13 +290,14 @@ func (jl *Solver) getEdge(e Edge) *edge {
 	return st.getEdge(e.Index)
 }
 
-func (jl *Solver) subBuild(ctx context.Context, e Edge, parent Vertex) (CachedResult, error) {
+func (jl *Solver) subBuild(ctx context.Context, e Edge, parent Vertex) (CachedResult, Edge, error) {
 	v, err := jl.load(e.Vertex, parent, nil)
 	if err != nil {
-		return nil, err
+		return nil, e, err
 	}
 	e.Vertex = v
-	return jl.s.build(ctx, e)
+	res, err := jl.s.build(ctx, e)
+	return res, e, err
 }
 
 func (jl *Solver) Close() {
@@ -491,7 +492,7 @@ func (jl *Solver) deleteIfUnreferenced(k digest.Digest, st *state) {
 	}
 }
 
-func (j *Job) Build(ctx context.Context, e Edge) (CachedResult, error) {
+func (j *Job) Build(ctx context.Context, e Edge) (CachedResultWithProvenance, error) {
 	if span := opentracing.SpanFromContext(ctx); span != nil {
 		j.span = span
 	}
@@ -501,7 +502,11 @@ func (j *Job) Build(ctx context.Context, e Edge) (CachedResult, error) {
 		return nil, err
 	}
 	e.Vertex = v
-	return j.list.s.build(ctx, e)
+	res, err := j.list.s.build(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return &withProvenance{CachedResult: res, solver: j.list, e: e}, nil
 }
 
 func (j *Job) Discard() error {
@@ -547,6 +552,51 @@ func (j *Job) EachValue(ctx context.Context, key string, fn func(interface{}) er
 	return nil
 }
 
+// walkProvenance calls f for every loaded operation in the graph of e that
+// implements ProvenanceProvider. Operations are only available while the
+// vertexes are active so this needs to be called before the jobs referencing
+// them are discarded.
+func (jl *Solver) walkProvenance(ctx context.Context, e Edge, f func(ProvenanceProvider) error, visited map[digest.Digest]struct{}) error {
+	if _, ok := visited[e.Vertex.Digest()]; ok {
+		return nil
+	}
+	visited[e.Vertex.Digest()] = struct{}{}
+
+	jl.mu.RLock()
+	st, ok := jl.actives[e.Vertex.Digest()]
+	jl.mu.RUnlock()
+	if ok {
+		st.mu.Lock()
+		sop := st.op
+		st.mu.Unlock()
+		if sop != nil {
+			if op, err := sop.getOp(); err == nil {
+				if pp, ok := op.(ProvenanceProvider); ok {
+					if err := f(pp); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	for _, inp := range e.Vertex.Inputs() {
+		if err := jl.walkProvenance(ctx, inp, f, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type withProvenance struct {
+	CachedResult
+	solver *Solver
+	e      Edge
+}
+
+func (wp *withProvenance) WalkProvenance(ctx context.Context, f func(ProvenanceProvider) error) error {
+	return wp.solver.walkProvenance(ctx, wp.e, f, map[digest.Digest]struct{}{})
+}
+
 type cacheMapResp struct {
 	*CacheMap
 	complete bool
@@ -10,19 +10,23 @@ import (
 	"github.com/containerd/containerd/platforms"
 	"github.com/mitchellh/hashstructure"
 	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/frontend"
 	gw "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/solver/errdefs"
 	llberrdefs "github.com/moby/buildkit/solver/llbsolver/errdefs"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/flightcontrol"
+	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/worker"
 	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 )
 
 type llbBridge struct {
@@ -34,6 +38,10 @@ type llbBridge struct {
 	cms                       map[string]solver.CacheManager
 	cmsMu                     sync.Mutex
 	sm                        *session.Manager
+	allowedDevices            []string
+
+	progressMu sync.Mutex
+	progress   map[string]*client.Vertex
 }
 
 func (b *llbBridge) loadResult(ctx context.Context, def *pb.Definition, cacheImports []gw.CacheOptionsEntry) (solver.CachedResult, error) {
@@ -84,7 +92,7 @@ func (b *llbBridge) loadResult(ctx context.Context, def *pb.Definition, cacheImp
 	}
 	dpc := &detectPrunedCacheID{}
 
-	edge, err := Load(def, dpc.Load, ValidateEntitlements(ent), WithCacheSources(cms), NormalizeRuntimePlatforms(), WithValidateCaps())
+	edge, err := Load(def, dpc.Load, ValidateEntitlements(ent), ValidateDevices(b.allowedDevices), WithCacheSources(cms), NormalizeRuntimePlatforms(), WithValidateCaps())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load LLB")
 	}
@@ -137,6 +145,20 @@ func (b *llbBridge) Solve(ctx context.Context, req frontend.SolveRequest, sid st
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to solve with frontend %s", req.Frontend)
 		}
+		if req.Evaluate {
+			// evaluating here lets the caller inspect the mounts of a
+			// failed exec through the returned error. Refs are evaluated
+			// in parallel and the first error cancels the others.
+			eg, egCtx := errgroup.WithContext(ctx)
+			res.EachRef(func(ref solver.ResultProxy) error {
+				eg.Go(func() error {
+					_, err := ref.Result(egCtx)
+					return err
+				})
+				return nil
+			})
+			return res, eg.Wait()
+		}
 	} else {
 		return &frontend.Result{}, nil
 	}
@@ -280,6 +302,9 @@ func (b *llbBridge) ResolveImageConfig(ctx context.Context, ref string, opt llb.
 	} else {
 		id += platforms.Format(*platform)
 	}
+	if opt.ResolverType == llb.ResolverTypeOCILayout {
+		id = "oci-layout:" + opt.Store.SessionID + ":" + opt.Store.StoreID + ":" + id
+	}
 	err = inBuilderContext(ctx, b.builder, opt.LogName, id, func(ctx context.Context, g session.Group) error {
 		dgst, config, err = w.ResolveImageConfig(ctx, ref, opt, b.sm, g)
 		return err
@@ -287,6 +312,101 @@ func (b *llbBridge) ResolveImageConfig(ctx context.Context, ref string, opt llb.
 	return dgst, config, err
 }
 
+func (b *llbBridge) ResolveSourceMetadata(ctx context.Context, op *pb.SourceOp, opt llb.ResolveSourceMetadataOpt) (md *llb.SourceMetadata, err error) {
+	w, err := b.resolveWorker()
+	if err != nil {
+		return nil, err
+	}
+	if opt.LogName == "" {
+		opt.LogName = fmt.Sprintf("resolve source metadata for %s", op.Identifier)
+	}
+	id := op.Identifier // make a deterministic ID for avoiding duplicates
+	if platform := opt.Platform; platform == nil {
+		id += platforms.Format(platforms.DefaultSpec())
+	} else {
+		id += platforms.Format(*platform)
+	}
+	err = inBuilderContext(ctx, b.builder, opt.LogName, id, func(ctx context.Context, g session.Group) error {
+		md, err = w.ResolveSourceMetadata(ctx, op, opt, b.sm, g)
+		return err
+	})
+	return md, err
+}
+
+// Warn sends a warning to the progress stream of the build, attached to the
+// vertex with digest dgst.
+func (b *llbBridge) Warn(ctx context.Context, dgst digest.Digest, msg string, opts frontend.WarnOpts) error {
+	return b.builder.InContext(ctx, func(ctx context.Context, g session.Group) error {
+		pw, ok, _ := progress.FromContext(ctx, progress.WithMetadata("vertex", dgst))
+		if !ok {
+			return errors.Errorf("no progress writer for warning %q", msg)
+		}
+		defer pw.Close()
+		return pw.Write(identity.NewID(), client.VertexWarning{
+			Vertex:     dgst,
+			Level:      opts.Level,
+			Short:      []byte(msg),
+			Detail:     opts.Detail,
+			URL:        opts.URL,
+			SourceInfo: opts.SourceInfo,
+			Range:      opts.Range,
+		})
+	})
+}
+
+// Progress updates a custom progress step of a frontend. Steps are identified
+// by the ID of the request for the whole build, so nested frontends should
+// use distinct IDs.
+func (b *llbBridge) Progress(ctx context.Context, req frontend.ProgressRequest) error {
+	if req.ID == "" {
+		return errors.Errorf("progress step ID not set")
+	}
+
+	b.progressMu.Lock()
+	defer b.progressMu.Unlock()
+
+	v, started := b.progress[req.ID]
+	if !started {
+		if req.Name == "" {
+			return errors.Errorf("progress step %s must have a name", req.ID)
+		}
+		v = &client.Vertex{
+			Digest: digest.FromBytes([]byte("frontend-progress:" + req.ID)),
+			Name:   req.Name,
+		}
+		if b.progress == nil {
+			b.progress = map[string]*client.Vertex{}
+		}
+		b.progress[req.ID] = v
+	}
+	if req.Completed {
+		delete(b.progress, req.ID)
+	}
+
+	return b.builder.InContext(ctx, func(ctx context.Context, g session.Group) error {
+		pw, _, ctx := progress.FromContext(ctx, progress.WithMetadata("vertex", v.Digest))
+		defer pw.Close()
+		if !started {
+			notifyStarted(ctx, v, false)
+		}
+		if len(req.Data) > 0 {
+			pw.Write(identity.NewID(), client.VertexLog{
+				Vertex: v.Digest,
+				Stream: req.Stream,
+				Data:   req.Data,
+			})
+		}
+		if req.Completed {
+			var err error
+			if req.Error != "" {
+				err = errors.New(req.Error)
+			}
+			notifyCompleted(ctx, v, err, false)
+		}
+		return nil
+	})
+}
+
 type lazyCacheManager struct {
 	id   string
 	main solver.CacheManager
@@ -10,11 +10,13 @@ import (
 	"time"
 
 	"github.com/containerd/continuity/fs"
+	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/moby/buildkit/snapshot"
 	"github.com/moby/buildkit/solver/llbsolver/ops/fileoptypes"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/pkg/errors"
+	"github.com/tonistiigi/fsutil"
 	copy "github.com/tonistiigi/fsutil/copy"
 )
 
@@ -198,6 +200,13 @@ func docopy(ctx context.Context, src, dest string, action pb.FileActionCopy, u *
 		copy.WithXAttrErrorHandler(xattrErrorHandler),
 	}
 
+	doCopy := copy.Copy
+	if len(action.IncludePatterns) > 0 || len(action.ExcludePatterns) > 0 {
+		doCopy = func(ctx context.Context, srcRoot, src, dstRoot, dst string, opts ...copy.Opt) error {
+			return copyWithPatterns(ctx, srcRoot, src, dstRoot, dst, action, ch, opts...)
+		}
+	}
+
 	if !action.AllowWildcard {
 		if action.AttemptUnpackDockerCompatibility {
 			if ok, err := unpack(ctx, src, srcPath, dest, destPath, ch, timestampToTime(action.Timestamp)); err != nil {
@@ -206,7 +215,7 @@ func docopy(ctx context.Context, src, dest string, action pb.FileActionCopy, u *
 				return nil
 			}
 		}
-		return copy.Copy(ctx, src, srcPath, dest, destPath, opt...)
+		return doCopy(ctx, src, srcPath, dest, destPath, opt...)
 	}
 
 	m, err := copy.ResolveWildcards(src, srcPath, action.FollowSymlink)
@@ -229,7 +238,7 @@ func docopy(ctx context.Context, src, dest string, action pb.FileActionCopy, u *
 				continue
 			}
 		}
-		if err := copy.Copy(ctx, src, s, dest, destPath, opt...); err != nil {
+		if err := doCopy(ctx, src, s, dest, destPath, opt...); err != nil {
 			return err
 		}
 	}
@@ -237,6 +246,93 @@ func docopy(ctx context.Context, src, dest string, action pb.FileActionCopy, u *
 	return nil
 }
 
+// copyWithPatterns copies src like copy.Copy but only copies the paths inside
+// a src directory that match the include and exclude patterns of the action.
+// A src that isn't a directory is matched by its base name.
+func copyWithPatterns(ctx context.Context, srcRoot, src, destRoot, dest string, action pb.FileActionCopy, ch copy.Chowner, opt ...copy.Opt) error {
+	srcFollowed, err := fs.RootPath(srcRoot, src)
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(srcFollowed)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !fi.IsDir() {
+		ok, err := matchPatterns(filepath.Base(src), action.IncludePatterns, action.ExcludePatterns)
+		if err != nil || !ok {
+			return err
+		}
+		return copy.Copy(ctx, srcRoot, src, destRoot, dest, opt...)
+	}
+
+	destPath, err := fs.RootPath(destRoot, dest)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(destPath); err == nil && !action.DirCopyContents {
+		dest = filepath.Join(dest, filepath.Base(src))
+		if destPath, err = fs.RootPath(destRoot, dest); err != nil {
+			return err
+		}
+	}
+
+	tm := timestampToTime(action.Timestamp)
+	if err := copy.MkdirAll(destPath, fi.Mode().Perm(), ch, tm); err != nil {
+		return err
+	}
+
+	return fsutil.Walk(ctx, srcFollowed, &fsutil.WalkOpt{
+		IncludePatterns: action.IncludePatterns,
+		ExcludePatterns: action.ExcludePatterns,
+	}, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, p)
+		if fi.IsDir() {
+			targetPath, err := fs.RootPath(destRoot, target)
+			if err != nil {
+				return err
+			}
+			return copy.MkdirAll(targetPath, fi.Mode().Perm(), ch, tm)
+		}
+		return copy.Copy(ctx, srcRoot, filepath.Join(src, p), destRoot, target, opt...)
+	})
+}
+
+func matchPatterns(p string, includePatterns, excludePatterns []string) (bool, error) {
+	if len(includePatterns) > 0 {
+		included := false
+		for _, pattern := range includePatterns {
+			ok, err := filepath.Match(filepath.Clean(pattern), p)
+			if err != nil {
+				return false, errors.Wrapf(err, "invalid include pattern %s", pattern)
+			}
+			if ok {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false, nil
+		}
+	}
+	if len(excludePatterns) > 0 {
+		pm, err := fileutils.NewPatternMatcher(excludePatterns)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid exclude patterns %s", excludePatterns)
+		}
+		excluded, err := pm.Matches(p)
+		if err != nil {
+			return false, err
+		}
+		return !excluded, nil
+	}
+	return true, nil
+}
+
 func cleanPath(s string) string {
 	s2 := filepath.Join("/", s)
 	if strings.HasSuffix(s, "/.") {
@@ -17,6 +17,7 @@ import (
 	"github.com/moby/buildkit/executor"
 	"github.com/moby/buildkit/frontend/gateway"
 	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets"
 	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/solver/llbsolver"
 	"github.com/moby/buildkit/solver/llbsolver/errdefs"
@@ -36,6 +37,7 @@ const execCacheType = "buildkit.exec.v0"
 type execOp struct {
 	op        *pb.ExecOp
 	cm        cache.Manager
+	sm        *session.Manager
 	mm        *mounts.MountManager
 	exec      executor.Executor
 	w         worker.Worker
@@ -52,6 +54,7 @@ func NewExecOp(v solver.Vertex, op *pb.Op_Exec, platform *pb.Platform, cm cache.
 		op:        op.Exec,
 		mm:        mounts.NewMountManager(name, cm, sm, md),
 		cm:        cm,
+		sm:        sm,
 		exec:      exec,
 		numInputs: len(v.Inputs()),
 		w:         w,
@@ -59,6 +62,12 @@ func NewExecOp(v solver.Vertex, op *pb.Op_Exec, platform *pb.Platform, cm cache.
 	}, nil
 }
 
+func (e *execOp) IsProvenanceProvider() {}
+
+func (e *execOp) Proto() *pb.ExecOp {
+	return e.op
+}
+
 func cloneExecOp(old *pb.ExecOp) pb.ExecOp {
 	n := *old
 	meta := *n.Meta
@@ -214,6 +223,16 @@ func addDefaultEnvvar(env []string, k, v string) []string {
 	return append(env, k+"="+v)
 }
 
+func setEnvvar(env []string, k, v string) []string {
+	out := make([]string, 0, len(env)+1)
+	for _, e := range env {
+		if !strings.HasPrefix(e, k+"=") {
+			out = append(out, e)
+		}
+	}
+	return append(out, k+"="+v)
+}
+
 func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Result) (results []solver.Result, err error) {
 	refs := make([]*worker.WorkerRef, len(inputs))
 	for i, inp := range inputs {
@@ -296,6 +315,7 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 		ExtraHosts:     extraHosts,
 		NetMode:        e.op.Network,
 		SecurityMode:   e.op.Security,
+		Devices:        e.op.Devices,
 	}
 
 	if e.op.Meta.ProxyEnv != nil {
@@ -307,6 +327,11 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 	}
 	meta.Env = addDefaultEnvvar(meta.Env, "PATH", utilsystem.DefaultPathEnv(currentOS))
 
+	meta.Env, err = e.loadSecretEnv(ctx, g, meta.Env)
+	if err != nil {
+		return nil, err
+	}
+
 	stdout, stderr := logs.NewLogStreams(ctx, os.Getenv("BUILDKIT_DEBUG_EXEC_OUTPUT") == "1")
 	defer stdout.Close()
 	defer stderr.Close()
@@ -334,6 +359,40 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 	return results, errors.Wrapf(execErr, "executor failed running %v", e.op.Meta.Args)
 }
 
+// loadSecretEnv reads the secrets exposed as environment variables from the
+// session and sets them in env. Only their IDs are part of the cache key.
+func (e *execOp) loadSecretEnv(ctx context.Context, g session.Group, env []string) ([]string, error) {
+	for _, sopt := range e.op.Secretenv {
+		id := sopt.ID
+		if id == "" {
+			return nil, errors.Errorf("secret ID missing for %q environment variable", sopt.Name)
+		}
+		var dt []byte
+		var found bool
+		err := e.sm.Any(ctx, g, func(ctx context.Context, _ string, caller session.Caller) error {
+			var err error
+			dt, err = secrets.GetSecret(ctx, caller, id)
+			if err != nil {
+				if errors.Is(err, secrets.ErrNotFound) && sopt.Optional {
+					return nil
+				}
+				return err
+			}
+			// an empty secret is set as an empty variable
+			found = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		env = setEnvvar(env, sopt.Name, string(dt))
+	}
+	return env, nil
+}
+
 func proxyEnvList(p *pb.ProxyEnv) []string {
 	out := []string{}
 	if v := p.HttpProxy; v != "" {
@@ -0,0 +1,134 @@
+package ops
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/llbsolver"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/compression"
+	"github.com/moby/buildkit/worker"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+const mergeCacheType = "buildkit.merge.v0"
+
+type mergeOp struct {
+	op        *pb.MergeOp
+	w         worker.Worker
+	numInputs int
+}
+
+func NewMergeOp(v solver.Vertex, op *pb.Op_Merge, w worker.Worker) (solver.Op, error) {
+	if err := llbsolver.ValidateOp(&pb.Op{Op: op}); err != nil {
+		return nil, err
+	}
+	return &mergeOp{
+		op:        op.Merge,
+		w:         w,
+		numInputs: len(v.Inputs()),
+	}, nil
+}
+
+func (m *mergeOp) CacheMap(ctx context.Context, g session.Group, index int) (*solver.CacheMap, bool, error) {
+	dt, err := json.Marshal(struct {
+		Type  string
+		Merge *pb.MergeOp
+	}{
+		Type:  mergeCacheType,
+		Merge: m.op,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	cm := &solver.CacheMap{
+		Digest: digest.FromBytes(dt),
+		Deps: make([]struct {
+			Selector          digest.Digest
+			ComputeDigestFunc solver.ResultBasedCacheFunc
+			PreprocessFunc    solver.PreprocessFunc
+		}, m.numInputs),
+	}
+	return cm, true, nil
+}
+
+// Exec stacks the layers of every input on top of the first one. The blobs
+// of the upper inputs are reused, so their layers stay the same no matter
+// what they are applied to.
+func (m *mergeOp) Exec(ctx context.Context, g session.Group, inputs []solver.Result) (outputs []solver.Result, retErr error) {
+	refs := make([]*worker.WorkerRef, 0, len(m.op.Inputs))
+	for _, inp := range m.op.Inputs {
+		i := int(inp.Input)
+		if i < 0 || i >= len(inputs) {
+			return nil, errors.Errorf("invalid merge input index %d", i)
+		}
+		workerRef, ok := inputs[i].Sys().(*worker.WorkerRef)
+		if !ok {
+			return nil, errors.Errorf("invalid reference for merge %T", inputs[i].Sys())
+		}
+		if workerRef.ImmutableRef == nil {
+			continue
+		}
+		refs = append(refs, workerRef)
+	}
+	if len(refs) == 0 {
+		return []solver.Result{worker.NewWorkerRefResult(nil, m.w)}, nil
+	}
+
+	var current cache.ImmutableRef
+	defer func() {
+		if retErr != nil && current != nil {
+			current.Release(context.TODO())
+		}
+	}()
+
+	cm := m.w.CacheManager()
+	for i, ref := range refs {
+		// blobs are needed for every input so that the chain of the merged
+		// result can be addressed by them
+		remote, err := ref.GetRemote(ctx, true, compression.Default, g)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			current = ref.ImmutableRef.Clone()
+			continue
+		}
+		dhs := descHandlers(ctx, remote)
+		for _, desc := range remote.Descriptors {
+			next, err := cm.GetByBlob(ctx, desc, current, dhs, cache.WithDescription("merged layer "+desc.Digest.String()))
+			if err != nil {
+				return nil, err
+			}
+			current.Release(context.TODO())
+			current = next
+		}
+	}
+
+	return []solver.Result{worker.NewWorkerRefResult(current, m.w)}, nil
+}
+
+// descHandlers returns the handlers for lazy blobs of the remote that are
+// registered for the current build.
+func descHandlers(ctx context.Context, remote *solver.Remote) cache.DescHandlers {
+	dhs := cache.DescHandlers{}
+	optGetter := solver.CacheOptGetterOf(ctx)
+	if optGetter == nil {
+		return dhs
+	}
+	keys := make([]interface{}, 0, len(remote.Descriptors))
+	for _, desc := range remote.Descriptors {
+		keys = append(keys, cache.DescHandlerKey(desc.Digest))
+	}
+	for k, v := range optGetter(keys...) {
+		if v, ok := v.(*cache.DescHandler); ok {
+			dhs[digest.Digest(k.(cache.DescHandlerKey))] = v
+		}
+	}
+	return dhs
+}
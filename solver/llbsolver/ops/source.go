@@ -41,6 +41,23 @@ func NewSourceOp(vtx solver.Vertex, op *pb.Op_Source, platform *pb.Platform, sm
 	}, nil
 }
 
+func (s *sourceOp) IsProvenanceProvider() {}
+
+// Pin returns the identifier of the source and the immutable version it
+// resolved to, if known.
+func (s *sourceOp) Pin() (source.Identifier, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, err := source.FromLLB(s.op, s.platform)
+	if err != nil {
+		return nil, ""
+	}
+	if p, ok := s.src.(source.Pinner); ok {
+		return id, p.Pin()
+	}
+	return id, ""
+}
+
 func (s *sourceOp) instance(ctx context.Context) (source.SourceInstance, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
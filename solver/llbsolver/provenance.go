@@ -0,0 +1,150 @@
+package llbsolver
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/llbsolver/provenance"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/source"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// provenanceExporter is implemented by exporters that attach provenance
+// attestations to the exported image.
+type provenanceExporter interface {
+	ProvenanceMode() provenance.Mode
+}
+
+// sourceProvenance is implemented by the source op
+type sourceProvenance interface {
+	Pin() (source.Identifier, string)
+}
+
+// execProvenance is implemented by the exec op
+type execProvenance interface {
+	Proto() *pb.ExecOp
+}
+
+func captureProvenance(ctx context.Context, res solver.CachedResult) (*provenance.Capture, error) {
+	c := &provenance.Capture{}
+	wp, ok := res.(solver.CachedResultWithProvenance)
+	if !ok {
+		c.IncompleteMaterials = true
+		return c, nil
+	}
+	err := wp.WalkProvenance(ctx, func(pp solver.ProvenanceProvider) error {
+		switch op := pp.(type) {
+		case sourceProvenance:
+			id, pin := op.Pin()
+			switch s := id.(type) {
+			case *source.ImageIdentifier:
+				var dgst digest.Digest
+				if pin != "" {
+					var err error
+					dgst, err = digest.Parse(pin)
+					if err != nil {
+						return errors.Wrapf(err, "failed to parse image digest %s", pin)
+					}
+				} else {
+					c.IncompleteMaterials = true
+				}
+				c.AddImage(provenance.ImageSource{
+					Ref:      s.Reference.String(),
+					Platform: s.Platform,
+					Digest:   dgst,
+				})
+			case *source.OCIIdentifier:
+				var dgst digest.Digest
+				if pin != "" {
+					var err error
+					dgst, err = digest.Parse(pin)
+					if err != nil {
+						return errors.Wrapf(err, "failed to parse oci-layout digest %s", pin)
+					}
+				} else {
+					c.IncompleteMaterials = true
+				}
+				c.AddImage(provenance.ImageSource{
+					Ref:      s.Reference.String(),
+					Platform: s.Platform,
+					Digest:   dgst,
+					Local:    true,
+				})
+			case *source.GitIdentifier:
+				u := redactCredentials(s.Remote)
+				if s.Ref != "" {
+					u += "#" + s.Ref
+				}
+				if pin == "" {
+					c.IncompleteMaterials = true
+				}
+				c.AddGit(provenance.GitSource{
+					URL:    u,
+					Commit: pin,
+				})
+				if s.AuthTokenSecret != "" {
+					c.AddSecret(provenance.Secret{ID: s.AuthTokenSecret, Optional: true})
+				}
+				if s.AuthHeaderSecret != "" {
+					c.AddSecret(provenance.Secret{ID: s.AuthHeaderSecret, Optional: true})
+				}
+				if s.MountSSHSock != "" {
+					c.AddSSH(provenance.SSH{ID: s.MountSSHSock, Optional: true})
+				}
+			case *source.HTTPIdentifier:
+				var dgst digest.Digest
+				if pin != "" {
+					var err error
+					dgst, err = digest.Parse(pin)
+					if err != nil {
+						return errors.Wrapf(err, "failed to parse http checksum %s", pin)
+					}
+				} else {
+					c.IncompleteMaterials = true
+				}
+				c.AddHTTP(provenance.HTTPSource{
+					URL:    redactCredentials(s.URL),
+					Digest: dgst,
+				})
+			case *source.LocalIdentifier:
+				// local sources are not reproducible from the provenance alone
+				c.IncompleteMaterials = true
+				c.AddLocal(provenance.LocalSource{Name: s.Name})
+			}
+		case execProvenance:
+			pr := op.Proto()
+			for _, m := range pr.Mounts {
+				if m.MountType == pb.MountType_SECRET && m.SecretOpt != nil {
+					c.AddSecret(provenance.Secret{ID: m.SecretOpt.ID, Optional: m.SecretOpt.Optional})
+				}
+				if m.MountType == pb.MountType_SSH && m.SSHOpt != nil {
+					c.AddSSH(provenance.SSH{ID: m.SSHOpt.ID, Optional: m.SSHOpt.Optional})
+				}
+			}
+			for _, se := range pr.Secretenv {
+				c.AddSecret(provenance.Secret{ID: se.ID, Optional: se.Optional})
+			}
+			if pr.Network != pb.NetMode_NONE {
+				c.NetworkAccess = true
+				c.IncompleteMaterials = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func redactCredentials(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	u.User = nil
+	return u.String()
+}
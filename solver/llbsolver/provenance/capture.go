@@ -0,0 +1,157 @@
+package provenance
+
+import (
+	"sort"
+	"time"
+
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type ImageSource struct {
+	Ref      string
+	Platform *ocispec.Platform
+	Digest   digest.Digest
+	// Local is set for images read from an oci-layout store of the client.
+	Local bool
+}
+
+type GitSource struct {
+	URL    string
+	Commit string
+}
+
+type HTTPSource struct {
+	URL    string
+	Digest digest.Digest
+}
+
+type LocalSource struct {
+	Name string
+}
+
+type Secret struct {
+	ID       string
+	Optional bool
+}
+
+type SSH struct {
+	ID       string
+	Optional bool
+}
+
+type Sources struct {
+	Images []ImageSource
+	Git    []GitSource
+	HTTP   []HTTPSource
+	Local  []LocalSource
+}
+
+// Capture contains the information about a build result that is needed to
+// generate a provenance attestation for it.
+type Capture struct {
+	Frontend            string
+	Args                map[string]string
+	Inputs              []string
+	Sources             Sources
+	Secrets             []Secret
+	SSH                 []SSH
+	NetworkAccess       bool
+	IncompleteMaterials bool
+	BuildStartedOn      *time.Time
+	BuildFinishedOn     *time.Time
+	Definition          *pb.Definition
+}
+
+func (c *Capture) AddImage(i ImageSource) {
+	for _, v := range c.Sources.Images {
+		if v.Ref == i.Ref && v.Digest == i.Digest && v.Local == i.Local {
+			if v.Platform == nil && i.Platform == nil {
+				return
+			}
+			if v.Platform != nil && i.Platform != nil && v.Platform.OS == i.Platform.OS && v.Platform.Architecture == i.Platform.Architecture && v.Platform.Variant == i.Platform.Variant {
+				return
+			}
+		}
+	}
+	c.Sources.Images = append(c.Sources.Images, i)
+}
+
+func (c *Capture) AddGit(g GitSource) {
+	for _, v := range c.Sources.Git {
+		if v.URL == g.URL && v.Commit == g.Commit {
+			return
+		}
+	}
+	c.Sources.Git = append(c.Sources.Git, g)
+}
+
+func (c *Capture) AddHTTP(h HTTPSource) {
+	for _, v := range c.Sources.HTTP {
+		if v.URL == h.URL && v.Digest == h.Digest {
+			return
+		}
+	}
+	c.Sources.HTTP = append(c.Sources.HTTP, h)
+}
+
+func (c *Capture) AddLocal(l LocalSource) {
+	for _, v := range c.Sources.Local {
+		if v.Name == l.Name {
+			return
+		}
+	}
+	c.Sources.Local = append(c.Sources.Local, l)
+}
+
+func (c *Capture) AddSecret(s Secret) {
+	for i, v := range c.Secrets {
+		if v.ID == s.ID {
+			if !s.Optional {
+				c.Secrets[i].Optional = false
+			}
+			return
+		}
+	}
+	c.Secrets = append(c.Secrets, s)
+}
+
+func (c *Capture) AddSSH(s SSH) {
+	if s.ID == "" {
+		s.ID = "default"
+	}
+	for i, v := range c.SSH {
+		if v.ID == s.ID {
+			if !s.Optional {
+				c.SSH[i].Optional = false
+			}
+			return
+		}
+	}
+	c.SSH = append(c.SSH, s)
+}
+
+// Sort orders all collected values so that the generated provenance is
+// deterministic regardless of the order the build graph was walked in.
+func (c *Capture) Sort() {
+	sort.Slice(c.Sources.Images, func(i, j int) bool {
+		return c.Sources.Images[i].Ref < c.Sources.Images[j].Ref
+	})
+	sort.Slice(c.Sources.Git, func(i, j int) bool {
+		return c.Sources.Git[i].URL < c.Sources.Git[j].URL
+	})
+	sort.Slice(c.Sources.HTTP, func(i, j int) bool {
+		return c.Sources.HTTP[i].URL < c.Sources.HTTP[j].URL
+	})
+	sort.Slice(c.Sources.Local, func(i, j int) bool {
+		return c.Sources.Local[i].Name < c.Sources.Local[j].Name
+	})
+	sort.Slice(c.Secrets, func(i, j int) bool {
+		return c.Secrets[i].ID < c.Secrets[j].ID
+	})
+	sort.Slice(c.SSH, func(i, j int) bool {
+		return c.SSH[i].ID < c.SSH[j].ID
+	})
+	sort.Strings(c.Inputs)
+}
@@ -0,0 +1,209 @@
+package provenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PredicateType is the in-toto predicate type of the generated provenance.
+	PredicateType = "https://slsa.dev/provenance/v0.2"
+	// BuildKitBuildType identifies builds executed by BuildKit.
+	BuildKitBuildType = "https://mobyproject.org/buildkit@v1"
+)
+
+// Mode controls how much detail is included in the provenance.
+type Mode string
+
+const (
+	// ModeMin only includes the materials, the frontend and its non-sensitive
+	// options.
+	ModeMin Mode = "min"
+	// ModeMax additionally includes all frontend options, secret and SSH IDs
+	// and the complete LLB definition of the build.
+	ModeMax Mode = "max"
+)
+
+func ParseMode(v string) (Mode, error) {
+	switch Mode(v) {
+	case ModeMin, ModeMax:
+		return Mode(v), nil
+	case "":
+		return ModeMin, nil
+	default:
+		return "", errors.Errorf("invalid provenance mode %q", v)
+	}
+}
+
+type DigestSet map[string]string
+
+func digestSet(dgst digest.Digest) DigestSet {
+	return DigestSet{dgst.Algorithm().String(): dgst.Encoded()}
+}
+
+type Predicate struct {
+	Builder     Builder      `json:"builder"`
+	BuildType   string       `json:"buildType"`
+	Invocation  Invocation   `json:"invocation"`
+	BuildConfig *BuildConfig `json:"buildConfig,omitempty"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
+	Materials   []Material   `json:"materials,omitempty"`
+}
+
+type Builder struct {
+	ID string `json:"id"`
+}
+
+type Invocation struct {
+	Parameters  Parameters  `json:"parameters,omitempty"`
+	Environment Environment `json:"environment,omitempty"`
+}
+
+type Parameters struct {
+	Frontend string            `json:"frontend,omitempty"`
+	Args     map[string]string `json:"args,omitempty"`
+	Inputs   []string          `json:"inputs,omitempty"`
+	Locals   []LocalSource     `json:"locals,omitempty"`
+	Secrets  []Secret          `json:"secrets,omitempty"`
+	SSH      []SSH             `json:"ssh,omitempty"`
+}
+
+type Environment struct {
+	Platform string `json:"platform,omitempty"`
+}
+
+type BuildConfig struct {
+	Definition []BuildStep `json:"llbDefinition,omitempty"`
+}
+
+type BuildStep struct {
+	ID     string   `json:"id"`
+	Op     *pb.Op   `json:"op"`
+	Inputs []string `json:"inputs,omitempty"`
+}
+
+type Metadata struct {
+	BuildStartedOn  *time.Time   `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn *time.Time   `json:"buildFinishedOn,omitempty"`
+	Completeness    Completeness `json:"completeness"`
+	Reproducible    bool         `json:"reproducible"`
+}
+
+type Completeness struct {
+	Parameters  bool `json:"parameters"`
+	Environment bool `json:"environment"`
+	Materials   bool `json:"materials"`
+}
+
+type Material struct {
+	URI    string    `json:"uri"`
+	Digest DigestSet `json:"digest,omitempty"`
+}
+
+// NewPredicate converts the captured build information to a SLSA provenance
+// predicate with the detail level specified by mode.
+func NewPredicate(c *Capture, mode Mode) (*Predicate, error) {
+	pr := &Predicate{
+		BuildType: BuildKitBuildType,
+		Invocation: Invocation{
+			Parameters: Parameters{
+				Frontend: c.Frontend,
+				Inputs:   c.Inputs,
+				Locals:   c.Sources.Local,
+			},
+			Environment: Environment{
+				Platform: platforms.DefaultString(),
+			},
+		},
+		Metadata: &Metadata{
+			BuildStartedOn:  c.BuildStartedOn,
+			BuildFinishedOn: c.BuildFinishedOn,
+			Completeness: Completeness{
+				Parameters:  c.Frontend != "",
+				Environment: true,
+				Materials:   !c.IncompleteMaterials,
+			},
+		},
+	}
+
+	args := make(map[string]string, len(c.Args))
+	for k, v := range c.Args {
+		if mode == ModeMin && (strings.HasPrefix(k, "build-arg:") || strings.HasPrefix(k, "label:")) {
+			pr.Metadata.Completeness.Parameters = false
+			continue
+		}
+		args[k] = v
+	}
+	if len(args) > 0 {
+		pr.Invocation.Parameters.Args = args
+	}
+
+	for _, s := range c.Sources.Images {
+		uri := "docker-image://" + s.Ref
+		if s.Local {
+			uri = "oci-layout://" + s.Ref
+		}
+		if s.Platform != nil {
+			uri += "?platform=" + platforms.Format(*s.Platform)
+		}
+		m := Material{URI: uri}
+		if s.Digest != "" {
+			m.Digest = digestSet(s.Digest)
+		}
+		pr.Materials = append(pr.Materials, m)
+	}
+	for _, s := range c.Sources.Git {
+		m := Material{URI: s.URL}
+		if s.Commit != "" {
+			m.Digest = DigestSet{"sha1": s.Commit}
+		}
+		pr.Materials = append(pr.Materials, m)
+	}
+	for _, s := range c.Sources.HTTP {
+		m := Material{URI: s.URL}
+		if s.Digest != "" {
+			m.Digest = digestSet(s.Digest)
+		}
+		pr.Materials = append(pr.Materials, m)
+	}
+
+	if mode == ModeMax {
+		pr.Invocation.Parameters.Secrets = c.Secrets
+		pr.Invocation.Parameters.SSH = c.SSH
+		if c.Definition != nil {
+			steps, err := toBuildSteps(c.Definition)
+			if err != nil {
+				return nil, err
+			}
+			pr.BuildConfig = &BuildConfig{Definition: steps}
+		}
+	}
+
+	return pr, nil
+}
+
+func toBuildSteps(def *pb.Definition) ([]BuildStep, error) {
+	steps := make([]BuildStep, 0, len(def.Def))
+	for _, dt := range def.Def {
+		var op pb.Op
+		if err := op.Unmarshal(dt); err != nil {
+			return nil, errors.Wrap(err, "failed to parse llb definition")
+		}
+		inputs := make([]string, 0, len(op.Inputs))
+		for _, inp := range op.Inputs {
+			inputs = append(inputs, fmt.Sprintf("%s:%d", inp.Digest, inp.Index))
+		}
+		steps = append(steps, BuildStep{
+			ID:     digest.FromBytes(dt).String(),
+			Op:     &op,
+			Inputs: inputs,
+		})
+	}
+	return steps, nil
+}
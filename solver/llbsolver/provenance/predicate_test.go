@@ -0,0 +1,90 @@
+package provenance
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func testCapture(t *testing.T) *Capture {
+	op := &pb.Op{
+		Op: &pb.Op_Source{
+			Source: &pb.SourceOp{Identifier: "docker-image://docker.io/library/alpine:latest"},
+		},
+	}
+	dt, err := op.Marshal()
+	require.NoError(t, err)
+
+	c := &Capture{
+		Frontend: "dockerfile.v0",
+		Args: map[string]string{
+			"target":          "release",
+			"build-arg:TOKEN": "foo",
+		},
+		Secrets:    []Secret{{ID: "npmrc"}},
+		Definition: &pb.Definition{Def: [][]byte{dt}},
+	}
+	c.AddImage(ImageSource{
+		Ref:      "docker.io/library/alpine:latest",
+		Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+		Digest:   digest.FromBytes([]byte("alpine")),
+	})
+	c.AddImage(ImageSource{
+		Ref:      "docker.io/library/alpine:latest",
+		Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+		Digest:   digest.FromBytes([]byte("alpine")),
+	})
+	c.AddGit(GitSource{URL: "https://github.com/moby/buildkit.git#master", Commit: "8e2f6ec7dba7c6f4c8b56a9a1a8c7e0a9b2d4e1f"})
+	c.AddHTTP(HTTPSource{URL: "https://example.com/file.tar.gz", Digest: digest.FromBytes([]byte("file"))})
+	c.Sort()
+	return c
+}
+
+func TestPredicateMin(t *testing.T) {
+	pr, err := NewPredicate(testCapture(t), ModeMin)
+	require.NoError(t, err)
+
+	require.Equal(t, BuildKitBuildType, pr.BuildType)
+	require.Equal(t, "dockerfile.v0", pr.Invocation.Parameters.Frontend)
+	require.Equal(t, map[string]string{"target": "release"}, pr.Invocation.Parameters.Args)
+	require.Nil(t, pr.Invocation.Parameters.Secrets)
+	require.Nil(t, pr.BuildConfig)
+	require.False(t, pr.Metadata.Completeness.Parameters)
+	require.True(t, pr.Metadata.Completeness.Materials)
+
+	require.Equal(t, 3, len(pr.Materials))
+	require.Equal(t, "docker-image://docker.io/library/alpine:latest?platform=linux/amd64", pr.Materials[0].URI)
+	require.Equal(t, digest.FromBytes([]byte("alpine")).Encoded(), pr.Materials[0].Digest["sha256"])
+	require.Equal(t, "https://github.com/moby/buildkit.git#master", pr.Materials[1].URI)
+	require.Equal(t, "8e2f6ec7dba7c6f4c8b56a9a1a8c7e0a9b2d4e1f", pr.Materials[1].Digest["sha1"])
+	require.Equal(t, "https://example.com/file.tar.gz", pr.Materials[2].URI)
+}
+
+func TestPredicateMax(t *testing.T) {
+	pr, err := NewPredicate(testCapture(t), ModeMax)
+	require.NoError(t, err)
+
+	require.Equal(t, "foo", pr.Invocation.Parameters.Args["build-arg:TOKEN"])
+	require.Equal(t, []Secret{{ID: "npmrc"}}, pr.Invocation.Parameters.Secrets)
+	require.True(t, pr.Metadata.Completeness.Parameters)
+
+	require.NotNil(t, pr.BuildConfig)
+	require.Equal(t, 1, len(pr.BuildConfig.Definition))
+	require.Equal(t, "docker-image://docker.io/library/alpine:latest", pr.BuildConfig.Definition[0].Op.GetSource().Identifier)
+}
+
+func TestParseMode(t *testing.T) {
+	m, err := ParseMode("")
+	require.NoError(t, err)
+	require.Equal(t, ModeMin, m)
+
+	m, err = ParseMode("max")
+	require.NoError(t, err)
+	require.Equal(t, ModeMax, m)
+
+	_, err = ParseMode("full")
+	require.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package llbsolver
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	cacheutil "github.com/moby/buildkit/cache/util"
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/worker"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	sbomScanDestination = "/run/out"
+	sbomScanSource      = "/run/src/core"
+	sbomScanExtras      = "/run/src/extras"
+)
+
+// sbomGenerator is implemented by exporters that attach SBOM attestations
+// to the exported image.
+type sbomGenerator interface {
+	SBOMGenerator() string
+}
+
+// sbomMetadata runs the scanner image over the filesystem of the result and
+// the optional intermediate stages and returns the SPDX documents written by
+// the scanner as a JSON array.
+func sbomMetadata(ctx context.Context, b frontend.FrontendLLBBridge, scanner string, rp solver.ResultProxy, stagesDt []byte, sessionID string) ([]byte, error) {
+	var stages map[string][]byte
+	if len(stagesDt) > 0 {
+		if err := json.Unmarshal(stagesDt, &stages); err != nil {
+			return nil, errors.Wrap(err, "failed to parse sbom stages")
+		}
+	}
+
+	ref, err := reference.ParseNormalizedNamed(scanner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse sbom generator %s", scanner)
+	}
+	scanner = reference.TagNameOnly(ref).String()
+
+	_, dt, err := b.ResolveImageConfig(ctx, scanner, llb.ResolveImageConfigOpt{
+		LogName: "[internal] load metadata for " + scanner,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var img ocispec.Image
+	if err := json.Unmarshal(dt, &img); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse image config for %s", scanner)
+	}
+
+	st, err := llb.Image(scanner, llb.WithCustomName("[internal] load sbom generator "+scanner)).WithImageConfig(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := defToState(rp.Definition())
+	if err != nil {
+		return nil, err
+	}
+
+	args := append(append([]string{}, img.Config.Entrypoint...), img.Config.Cmd...)
+	if len(args) == 0 {
+		return nil, errors.Errorf("sbom generator %s has no entrypoint or command", scanner)
+	}
+
+	runOpts := []llb.RunOption{
+		llb.Args(args),
+		llb.AddEnv("BUILDKIT_SCAN_DESTINATION", sbomScanDestination),
+		llb.AddEnv("BUILDKIT_SCAN_SOURCE", sbomScanSource),
+		llb.AddMount(sbomScanSource, def, llb.Readonly),
+		llb.AddMount("/tmp", llb.Scratch(), llb.Tmpfs()),
+		llb.WithCustomName("[internal] generating sbom using " + scanner),
+	}
+	if len(stages) > 0 {
+		runOpts = append(runOpts, llb.AddEnv("BUILDKIT_SCAN_SOURCE_EXTRAS", sbomScanExtras+"/"))
+		names := make([]string, 0, len(stages))
+		for name := range stages {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var pbDef pb.Definition
+			if err := pbDef.Unmarshal(stages[name]); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse sbom stage %s", name)
+			}
+			stageDef, err := defToState(&pbDef)
+			if err != nil {
+				return nil, err
+			}
+			runOpts = append(runOpts, llb.AddMount(path.Join(sbomScanExtras, name), stageDef, llb.Readonly))
+		}
+	}
+
+	run := st.Run(runOpts...)
+	out := run.AddMount(sbomScanDestination, llb.Scratch())
+
+	outDef, err := out.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := b.Solve(ctx, frontend.SolveRequest{
+		Definition: outDef.ToPB(),
+	}, sessionID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate sbom")
+	}
+	defer res.EachRef(func(ref solver.ResultProxy) error {
+		go ref.Release(context.TODO())
+		return nil
+	})
+	if res.Ref == nil {
+		return nil, errors.Errorf("sbom generator %s returned no result", scanner)
+	}
+	r, err := res.Ref.Result(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workerRef, ok := r.Sys().(*worker.WorkerRef)
+	if !ok {
+		return nil, errors.Errorf("invalid reference: %T", r.Sys())
+	}
+	if workerRef.ImmutableRef == nil {
+		return nil, errors.Errorf("sbom generator %s produced no output", scanner)
+	}
+
+	m, err := workerRef.ImmutableRef.Mount(ctx, true, session.NewGroup(sessionID))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := cacheutil.ReadDir(ctx, m, cacheutil.ReadDirRequest{
+		Path:           "/",
+		IncludePattern: "*.spdx.json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]json.RawMessage, 0, len(entries))
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Path, ".spdx.json") {
+			continue
+		}
+		dt, err := cacheutil.ReadFile(ctx, m, cacheutil.ReadRequest{
+			Filename: e.Path,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid(dt) {
+			return nil, errors.Errorf("invalid sbom %s generated by %s", e.Path, scanner)
+		}
+		docs = append(docs, dt)
+	}
+	if len(docs) == 0 {
+		return nil, errors.Errorf("sbom generator %s did not write any *.spdx.json files", scanner)
+	}
+	return json.Marshal(docs)
+}
+
+func defToState(def *pb.Definition) (llb.State, error) {
+	if def == nil || len(def.Def) == 0 {
+		return llb.Scratch(), nil
+	}
+	op, err := llb.NewDefinitionOp(def)
+	if err != nil {
+		return llb.State{}, err
+	}
+	return llb.NewState(op), nil
+}
@@ -0,0 +1,102 @@
+package llbsolver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type sbomTestBridge struct {
+	config ocispec.Image
+	req    frontend.SolveRequest
+}
+
+func (b *sbomTestBridge) Solve(ctx context.Context, req frontend.SolveRequest, sid string) (*frontend.Result, error) {
+	b.req = req
+	return &frontend.Result{}, nil
+}
+
+func (b *sbomTestBridge) ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt) (digest.Digest, []byte, error) {
+	if ref != "docker.io/example/scanner:latest" {
+		return "", nil, errors.Errorf("unexpected image %s", ref)
+	}
+	dt, err := json.Marshal(b.config)
+	return "", dt, err
+}
+
+func (b *sbomTestBridge) ResolveSourceMetadata(ctx context.Context, op *pb.SourceOp, opt llb.ResolveSourceMetadataOpt) (*llb.SourceMetadata, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (b *sbomTestBridge) Warn(ctx context.Context, dgst digest.Digest, msg string, opts frontend.WarnOpts) error {
+	return nil
+}
+
+func (b *sbomTestBridge) Progress(ctx context.Context, req frontend.ProgressRequest) error {
+	return nil
+}
+
+type sbomTestResult struct {
+	solver.ResultProxy
+	def *pb.Definition
+}
+
+func (r *sbomTestResult) Definition() *pb.Definition {
+	return r.def
+}
+
+func TestSBOMMetadata(t *testing.T) {
+	ctx := context.TODO()
+
+	def, err := llb.Image("alpine").Marshal(ctx)
+	require.NoError(t, err)
+	stageDef, err := llb.Image("golang").Marshal(ctx)
+	require.NoError(t, err)
+	stageDt, err := stageDef.ToPB().Marshal()
+	require.NoError(t, err)
+	stages, err := json.Marshal(map[string][]byte{"build": stageDt})
+	require.NoError(t, err)
+
+	b := &sbomTestBridge{}
+	b.config.Config.Entrypoint = []string{"/scan"}
+	b.config.Config.Cmd = []string{"--all"}
+
+	_, err = sbomMetadata(ctx, b, "example/scanner", &sbomTestResult{def: def.ToPB()}, stages, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "returned no result")
+	require.NotNil(t, b.req.Definition)
+
+	var exec *pb.ExecOp
+	for _, dt := range b.req.Definition.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if e := op.GetExec(); e != nil {
+			exec = e
+		}
+	}
+	require.NotNil(t, exec)
+	require.Equal(t, []string{"/scan", "--all"}, exec.Meta.Args)
+	require.Contains(t, exec.Meta.Env, "BUILDKIT_SCAN_DESTINATION="+sbomScanDestination)
+	require.Contains(t, exec.Meta.Env, "BUILDKIT_SCAN_SOURCE="+sbomScanSource)
+	require.Contains(t, exec.Meta.Env, "BUILDKIT_SCAN_SOURCE_EXTRAS="+sbomScanExtras+"/")
+
+	var dests []string
+	for _, m := range exec.Mounts {
+		dests = append(dests, m.Dest)
+	}
+	require.ElementsMatch(t, []string{"/", sbomScanSource, "/tmp", sbomScanExtras + "/build", sbomScanDestination}, dests)
+
+	b.config.Config.Entrypoint = nil
+	b.config.Config.Cmd = nil
+	_, err = sbomMetadata(ctx, b, "example/scanner", &sbomTestResult{def: def.ToPB()}, nil, "")
+	require.EqualError(t, err, "sbom generator docker.io/example/scanner:latest has no entrypoint or command")
+}
@@ -2,6 +2,7 @@ package llbsolver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -28,7 +29,7 @@ import (
 const keyEntitlements = "llb.entitlements"
 
 type ExporterRequest struct {
-	Exporter        exporter.ExporterInstance
+	Exporters       []exporter.ExporterInstance
 	CacheExporter   remotecache.Exporter
 	CacheExportMode solver.CacheExportMode
 }
@@ -46,9 +47,10 @@ type Solver struct {
 	gatewayForwarder          *controlgateway.GatewayForwarder
 	sm                        *session.Manager
 	entitlements              []string
+	allowedDevices            []string
 }
 
-func New(wc *worker.Controller, f map[string]frontend.Frontend, cache solver.CacheManager, resolveCI map[string]remotecache.ResolveCacheImporterFunc, gatewayForwarder *controlgateway.GatewayForwarder, sm *session.Manager, ents []string) (*Solver, error) {
+func New(wc *worker.Controller, f map[string]frontend.Frontend, cache solver.CacheManager, resolveCI map[string]remotecache.ResolveCacheImporterFunc, gatewayForwarder *controlgateway.GatewayForwarder, sm *session.Manager, ents []string, allowedDevices []string) (*Solver, error) {
 	s := &Solver{
 		workerController:          wc,
 		resolveWorker:             defaultResolver(wc),
@@ -58,6 +60,7 @@ func New(wc *worker.Controller, f map[string]frontend.Frontend, cache solver.Cac
 		gatewayForwarder:          gatewayForwarder,
 		sm:                        sm,
 		entitlements:              ents,
+		allowedDevices:            allowedDevices,
 	}
 
 	s.solver = solver.NewSolver(solver.SolverOpt{
@@ -86,6 +89,7 @@ func (s *Solver) Bridge(b solver.Builder) frontend.FrontendLLBBridge {
 		resolveCacheImporterFuncs: s.resolveCacheImporterFuncs,
 		cms:                       map[string]solver.CacheManager{},
 		sm:                        s.sm,
+		allowedDevices:            s.allowedDevices,
 	}
 }
 
@@ -105,6 +109,13 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 
 	j.SessionID = sessionID
 
+	attReq, err := requestedAttestations(exp.Exporters)
+	if err != nil {
+		return nil, err
+	}
+
+	buildStarted := time.Now()
+
 	var res *frontend.Result
 	if s.gatewayForwarder != nil && req.Definition == nil && req.Frontend == "" {
 		fwd := gateway.NewBridgeForwarder(ctx, s.Bridge(j), s.workerController, req.FrontendInputs, sessionID, s.sm)
@@ -149,11 +160,14 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	buildFinished := time.Now()
 
-	var exporterResponse map[string]string
-	if e := exp.Exporter; e != nil {
+	exporterResponses := make([]map[string]string, len(exp.Exporters))
+	if len(exp.Exporters) > 0 {
 		inp := exporter.Source{
 			Metadata: res.Metadata,
+			// frontends without attachments support pass them as metadata
+			Attachments: exptypes.AttachmentsFromMetadata(res.Metadata, res.Attachments),
 		}
 		if inp.Metadata == nil {
 			inp.Metadata = make(map[string][]byte)
@@ -174,7 +188,11 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 				return nil, err
 			}
 			if dt != nil {
-				inp.Metadata[exptypes.ExporterInlineCache] = dt
+				inp.Attachments.Set("", exptypes.Attachment{Type: exptypes.AttachmentInlineCache, Data: dt})
+			}
+
+			if err := addAttestations(ctx, attReq, inp.Attachments, "", s.attestationGenerator(j, req, res, r, buildStarted, buildFinished, sessionID)); err != nil {
+				return nil, err
 			}
 		}
 		if res.Refs != nil {
@@ -198,17 +216,36 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 						return nil, err
 					}
 					if dt != nil {
-						inp.Metadata[fmt.Sprintf("%s/%s", exptypes.ExporterInlineCache, k)] = dt
+						inp.Attachments.Set(k, exptypes.Attachment{Type: exptypes.AttachmentInlineCache, Data: dt})
+					}
+
+					if err := addAttestations(ctx, attReq, inp.Attachments, k, s.attestationGenerator(j, req, res, r, buildStarted, buildFinished, sessionID)); err != nil {
+						return nil, err
 					}
 				}
 			}
 			inp.Refs = m
 		}
 
-		if err := inBuilderContext(ctx, j, e.Name(), "", func(ctx context.Context, _ session.Group) error {
-			exporterResponse, err = e.Export(ctx, inp, j.SessionID)
-			return err
-		}); err != nil {
+		eg, ctx := errgroup.WithContext(ctx)
+		for i, e := range exp.Exporters {
+			func(i int, e exporter.ExporterInstance) {
+				// exporters may add their own metadata to the source
+				src := inp
+				src.Metadata = make(map[string][]byte, len(inp.Metadata))
+				for k, v := range inp.Metadata {
+					src.Metadata[k] = v
+				}
+				eg.Go(func() error {
+					return inBuilderContext(ctx, j, e.Name(), fmt.Sprintf("%s %d", e.Name(), i), func(ctx context.Context, _ session.Group) error {
+						resp, err := e.Export(ctx, src, j.SessionID)
+						exporterResponses[i] = resp
+						return err
+					})
+				})
+			}(i, e)
+		}
+		if err := eg.Wait(); err != nil {
 			return nil, err
 		}
 	}
@@ -241,8 +278,11 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 		}
 	}
 
-	if exporterResponse == nil {
-		exporterResponse = make(map[string]string)
+	exporterResponse := make(map[string]string)
+	for _, resp := range exporterResponses {
+		for k, v := range resp {
+			exporterResponse[k] = v
+		}
 	}
 
 	for k, v := range res.Metadata {
@@ -256,9 +296,13 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 		}
 	}
 
-	return &client.SolveResponse{
+	resp := &client.SolveResponse{
 		ExporterResponse: exporterResponse,
-	}, nil
+	}
+	for _, r := range exporterResponses {
+		resp.ExporterResponses = append(resp.ExporterResponses, client.ExporterResponse{Data: r})
+	}
+	return resp, nil
 }
 
 func inlineCache(ctx context.Context, e remotecache.Exporter, res solver.CachedResult, g session.Group) ([]byte, error) {
@@ -293,6 +337,92 @@ func inlineCache(ctx context.Context, e remotecache.Exporter, res solver.CachedR
 	return nil, nil
 }
 
+// attestationGenerator computes the attestations of a ref of the result.
+type attestationGenerator struct {
+	provenance func(ctx context.Context) ([]byte, error)
+	sbom       func(ctx context.Context, scanner string, stages []byte) ([]byte, error)
+}
+
+func (s *Solver) attestationGenerator(j *solver.Job, req frontend.SolveRequest, rp solver.ResultProxy, res solver.CachedResult, started, finished time.Time, sessionID string) attestationGenerator {
+	return attestationGenerator{
+		provenance: func(ctx context.Context) ([]byte, error) {
+			return provenanceMetadata(ctx, req, rp, res, started, finished)
+		},
+		sbom: func(ctx context.Context, scanner string, stages []byte) ([]byte, error) {
+			return sbomMetadata(ctx, s.Bridge(j), scanner, rp, stages, sessionID)
+		},
+	}
+}
+
+// attestationRequest holds the attestations requested by the exporters of a
+// build.
+type attestationRequest struct {
+	provenance bool
+	scanner    string
+}
+
+// requestedAttestations returns the attestations requested by the exporters.
+// All exporters that generate an SBOM must use the same scanner, as the SBOM
+// is attached to the result that is shared by the exporters.
+func requestedAttestations(exporters []exporter.ExporterInstance) (attestationRequest, error) {
+	var r attestationRequest
+	for _, e := range exporters {
+		if pe, ok := e.(provenanceExporter); ok && pe.ProvenanceMode() != "" {
+			r.provenance = true
+		}
+		if sg, ok := e.(sbomGenerator); ok && sg.SBOMGenerator() != "" {
+			if r.scanner != "" && r.scanner != sg.SBOMGenerator() {
+				return r, errors.Errorf("conflicting sbom generators %s and %s", r.scanner, sg.SBOMGenerator())
+			}
+			r.scanner = sg.SBOMGenerator()
+		}
+	}
+	return r, nil
+}
+
+// addAttestations adds the requested attestations to the attachments of the
+// ref key. Attestations are only computed if an exporter requests them.
+func addAttestations(ctx context.Context, r attestationRequest, atts exptypes.Attachments, key string, g attestationGenerator) error {
+	if r.provenance {
+		dt, err := g.provenance(ctx)
+		if err != nil {
+			return err
+		}
+		atts.Set(key, exptypes.Attachment{Type: exptypes.AttachmentProvenance, Data: dt})
+	}
+	if r.scanner != "" {
+		stages, _ := atts.Get(key, exptypes.AttachmentSBOMStages)
+		dt, err := g.sbom(ctx, r.scanner, stages)
+		if err != nil {
+			return err
+		}
+		atts.Set(key, exptypes.Attachment{Type: exptypes.AttachmentSBOM, Data: dt})
+	}
+	return nil
+}
+
+func provenanceMetadata(ctx context.Context, req frontend.SolveRequest, rp solver.ResultProxy, res solver.CachedResult, started, finished time.Time) ([]byte, error) {
+	c, err := captureProvenance(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	c.Frontend = req.Frontend
+	c.Args = req.FrontendOpt
+	for k := range req.FrontendInputs {
+		c.Inputs = append(c.Inputs, k)
+	}
+	c.Definition = rp.Definition()
+	c.BuildStartedOn = &started
+	c.BuildFinishedOn = &finished
+	c.Sort()
+
+	dt, err := json.Marshal(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal provenance")
+	}
+	return dt, nil
+}
+
 func (s *Solver) Status(ctx context.Context, id string, statusChan chan *client.SolveStatus) error {
 	j, err := s.solver.Get(id)
 	if err != nil {
@@ -391,6 +521,9 @@ func supportedEntitlements(ents []string) []entitlements.Entitlement {
 		if e == string(entitlements.EntitlementSecurityInsecure) {
 			out = append(out, entitlements.EntitlementSecurityInsecure)
 		}
+		if e == string(entitlements.EntitlementDevice) {
+			out = append(out, entitlements.EntitlementDevice)
+		}
 	}
 	return out
 }
@@ -0,0 +1,110 @@
+package llbsolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/exporter"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/solver/llbsolver/provenance"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testExporter struct {
+	provenanceMode provenance.Mode
+	sbomGenerator  string
+}
+
+func (e *testExporter) Name() string {
+	return "test"
+}
+
+func (e *testExporter) Export(ctx context.Context, src exporter.Source, sessionID string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (e *testExporter) ProvenanceMode() provenance.Mode {
+	return e.provenanceMode
+}
+
+func (e *testExporter) SBOMGenerator() string {
+	return e.sbomGenerator
+}
+
+type plainExporter struct{}
+
+func (plainExporter) Name() string {
+	return "plain"
+}
+
+func (plainExporter) Export(ctx context.Context, src exporter.Source, sessionID string) (map[string]string, error) {
+	return nil, nil
+}
+
+func TestAddAttestations(t *testing.T) {
+	ctx := context.TODO()
+
+	failing := attestationGenerator{
+		provenance: func(ctx context.Context) ([]byte, error) {
+			return nil, errors.New("provenance not requested")
+		},
+		sbom: func(ctx context.Context, scanner string, stages []byte) ([]byte, error) {
+			return nil, errors.New("sbom not requested")
+		},
+	}
+
+	atts := exptypes.Attachments{}
+	r, err := requestedAttestations([]exporter.ExporterInstance{plainExporter{}, &testExporter{}})
+	require.NoError(t, err)
+	err = addAttestations(ctx, r, atts, "", failing)
+	require.NoError(t, err)
+	_, ok := atts.Get("", exptypes.AttachmentProvenance)
+	require.False(t, ok)
+	_, ok = atts.Get("", exptypes.AttachmentSBOM)
+	require.False(t, ok)
+
+	var scanned string
+	g := attestationGenerator{
+		provenance: func(ctx context.Context) ([]byte, error) {
+			return []byte("provenance"), nil
+		},
+		sbom: func(ctx context.Context, scanner string, stages []byte) ([]byte, error) {
+			scanned = scanner
+			return []byte("sbom"), nil
+		},
+	}
+	r, err = requestedAttestations([]exporter.ExporterInstance{
+		plainExporter{},
+		&testExporter{provenanceMode: provenance.ModeMin, sbomGenerator: "scanner"},
+	})
+	require.NoError(t, err)
+	err = addAttestations(ctx, r, atts, "linux/amd64", g)
+	require.NoError(t, err)
+	dt, ok := atts.Get("linux/amd64", exptypes.AttachmentProvenance)
+	require.True(t, ok)
+	require.Equal(t, "provenance", string(dt))
+	dt, ok = atts.Get("linux/amd64", exptypes.AttachmentSBOM)
+	require.True(t, ok)
+	require.Equal(t, "sbom", string(dt))
+	require.Equal(t, "scanner", scanned)
+
+	err = addAttestations(ctx, attestationRequest{provenance: true}, atts, "", failing)
+	require.EqualError(t, err, "provenance not requested")
+}
+
+func TestRequestedAttestationsScanner(t *testing.T) {
+	r, err := requestedAttestations([]exporter.ExporterInstance{
+		&testExporter{},
+		&testExporter{sbomGenerator: "scanner"},
+		&testExporter{sbomGenerator: "scanner"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, attestationRequest{scanner: "scanner"}, r)
+
+	_, err = requestedAttestations([]exporter.ExporterInstance{
+		&testExporter{sbomGenerator: "scanner"},
+		&testExporter{sbomGenerator: "other"},
+	})
+	require.EqualError(t, err, "conflicting sbom generators scanner and other")
+}
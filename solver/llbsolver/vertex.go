@@ -2,6 +2,7 @@ package llbsolver
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/containerd/containerd/platforms"
@@ -110,11 +111,44 @@ func ValidateEntitlements(ent entitlements.Set) LoadOpt {
 					return errors.Errorf("%s is not allowed", entitlements.EntitlementSecurityInsecure)
 				}
 			}
+
+			if len(op.Exec.Devices) > 0 {
+				if !ent.Allowed(entitlements.EntitlementDevice) {
+					return errors.Errorf("%s is not allowed", entitlements.EntitlementDevice)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateDevices checks that the devices used by exec ops match one of the
+// allowed device paths or patterns of the daemon.
+func ValidateDevices(allowed []string) LoadOpt {
+	return func(op *pb.Op, _ *pb.OpMetadata, opt *solver.VertexOptions) error {
+		exec, ok := op.Op.(*pb.Op_Exec)
+		if !ok {
+			return nil
+		}
+		for _, d := range exec.Exec.Devices {
+			if !deviceAllowed(d.Source, allowed) {
+				return errors.Errorf("device %s is not allowed by build daemon configuration", d.Source)
+			}
 		}
 		return nil
 	}
 }
 
+func deviceAllowed(p string, allowed []string) bool {
+	p = path.Clean(p)
+	for _, a := range allowed {
+		if ok, err := path.Match(a, p); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
 type detectPrunedCacheID struct {
 	ids map[string]struct{}
 }
@@ -259,6 +293,8 @@ func llbOpName(op *pb.Op) string {
 		return fileOpName(op.File.Actions)
 	case *pb.Op_Build:
 		return "build"
+	case *pb.Op_Merge:
+		return "merge"
 	default:
 		return "unknown"
 	}
@@ -298,6 +334,22 @@ func ValidateOp(op *pb.Op) error {
 		if !isRoot {
 			return errors.Errorf("invalid exec op with no rootfs")
 		}
+		for _, d := range op.Exec.Devices {
+			if !path.IsAbs(d.Source) {
+				return errors.Errorf("invalid exec op with device %q, path must be absolute", d.Source)
+			}
+			if d.Dest != "" && !path.IsAbs(d.Dest) {
+				return errors.Errorf("invalid exec op with device destination %q, path must be absolute", d.Dest)
+			}
+			if strings.Trim(d.Permissions, "rwm") != "" {
+				return errors.Errorf("invalid exec op with device permissions %q", d.Permissions)
+			}
+		}
+		for _, s := range op.Exec.Secretenv {
+			if s.Name == "" {
+				return errors.Errorf("invalid exec op with unnamed secret env")
+			}
+		}
 	case *pb.Op_File:
 		if op.File == nil {
 			return errors.Errorf("invalid nil file op")
@@ -309,6 +361,13 @@ func ValidateOp(op *pb.Op) error {
 		if op.Build == nil {
 			return errors.Errorf("invalid nil build op")
 		}
+	case *pb.Op_Merge:
+		if op.Merge == nil {
+			return errors.Errorf("invalid nil merge op")
+		}
+		if len(op.Merge.Inputs) == 0 {
+			return errors.Errorf("invalid merge op with no inputs")
+		}
 	}
 	return nil
 }
@@ -158,6 +158,7 @@ type Op struct {
 	//	*Op_Source
 	//	*Op_File
 	//	*Op_Build
+	//	*Op_Merge
 	Op          isOp_Op            `protobuf_oneof:"op"`
 	Platform    *Platform          `protobuf:"bytes,10,opt,name=platform,proto3" json:"platform,omitempty"`
 	Constraints *WorkerConstraints `protobuf:"bytes,11,opt,name=constraints,proto3" json:"constraints,omitempty"`
@@ -210,11 +211,15 @@ type Op_File struct {
 type Op_Build struct {
 	Build *BuildOp `protobuf:"bytes,5,opt,name=build,proto3,oneof" json:"build,omitempty"`
 }
+type Op_Merge struct {
+	Merge *MergeOp `protobuf:"bytes,6,opt,name=merge,proto3,oneof" json:"merge,omitempty"`
+}
 
 func (*Op_Exec) isOp_Op()   {}
 func (*Op_Source) isOp_Op() {}
 func (*Op_File) isOp_Op()   {}
 func (*Op_Build) isOp_Op()  {}
+func (*Op_Merge) isOp_Op()  {}
 
 func (m *Op) GetOp() isOp_Op {
 	if m != nil {
@@ -258,6 +263,13 @@ func (m *Op) GetBuild() *BuildOp {
 	return nil
 }
 
+func (m *Op) GetMerge() *MergeOp {
+	if x, ok := m.GetOp().(*Op_Merge); ok {
+		return x.Merge
+	}
+	return nil
+}
+
 func (m *Op) GetPlatform() *Platform {
 	if m != nil {
 		return m.Platform
@@ -279,6 +291,7 @@ func (*Op) XXX_OneofWrappers() []interface{} {
 		(*Op_Source)(nil),
 		(*Op_File)(nil),
 		(*Op_Build)(nil),
+		(*Op_Merge)(nil),
 	}
 }
 
@@ -394,10 +407,12 @@ var xxx_messageInfo_Input proto.InternalMessageInfo
 
 // ExecOp executes a command in a container.
 type ExecOp struct {
-	Meta     *Meta        `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
-	Mounts   []*Mount     `protobuf:"bytes,2,rep,name=mounts,proto3" json:"mounts,omitempty"`
-	Network  NetMode      `protobuf:"varint,3,opt,name=network,proto3,enum=pb.NetMode" json:"network,omitempty"`
-	Security SecurityMode `protobuf:"varint,4,opt,name=security,proto3,enum=pb.SecurityMode" json:"security,omitempty"`
+	Meta      *Meta        `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Mounts    []*Mount     `protobuf:"bytes,2,rep,name=mounts,proto3" json:"mounts,omitempty"`
+	Network   NetMode      `protobuf:"varint,3,opt,name=network,proto3,enum=pb.NetMode" json:"network,omitempty"`
+	Security  SecurityMode `protobuf:"varint,4,opt,name=security,proto3,enum=pb.SecurityMode" json:"security,omitempty"`
+	Secretenv []*SecretEnv `protobuf:"bytes,5,rep,name=secretenv,proto3" json:"secretenv,omitempty"`
+	Devices   []*Device    `protobuf:"bytes,6,rep,name=devices,proto3" json:"devices,omitempty"`
 }
 
 func (m *ExecOp) Reset()         { *m = ExecOp{} }
@@ -457,6 +472,20 @@ func (m *ExecOp) GetSecurity() SecurityMode {
 	return SecurityMode_SANDBOX
 }
 
+func (m *ExecOp) GetSecretenv() []*SecretEnv {
+	if m != nil {
+		return m.Secretenv
+	}
+	return nil
+}
+
+func (m *ExecOp) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
 // Meta is a set of arguments for ExecOp.
 // Meta is unrelated to LLB metadata.
 // FIXME: rename (ExecContext? ExecArgs?)
@@ -777,6 +806,129 @@ func (m *SecretOpt) GetOptional() bool {
 	return false
 }
 
+// SecretEnv defines a secret that is exposed to the process as an
+// environment variable
+type SecretEnv struct {
+	// ID of secret. Used for quering the value.
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// Name of the environment variable
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional defines if secret value is required. Error is produced
+	// if value is not found and optional is false.
+	Optional bool `protobuf:"varint,3,opt,name=optional,proto3" json:"optional,omitempty"`
+}
+
+func (m *SecretEnv) Reset()         { *m = SecretEnv{} }
+func (m *SecretEnv) String() string { return proto.CompactTextString(m) }
+func (*SecretEnv) ProtoMessage()    {}
+func (*SecretEnv) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{8}
+}
+func (m *SecretEnv) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SecretEnv) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *SecretEnv) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecretEnv.Merge(m, src)
+}
+func (m *SecretEnv) XXX_Size() int {
+	return m.Size()
+}
+func (m *SecretEnv) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecretEnv.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecretEnv proto.InternalMessageInfo
+
+func (m *SecretEnv) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *SecretEnv) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SecretEnv) GetOptional() bool {
+	if m != nil {
+		return m.Optional
+	}
+	return false
+}
+
+// Device exposes a device node of the host to the process
+type Device struct {
+	// Source is the path of the device on the host
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// Dest is the path of the device in the container. Defaults to source.
+	Dest string `protobuf:"bytes,2,opt,name=dest,proto3" json:"dest,omitempty"`
+	// Permissions are the cgroup permissions of the device, any combination
+	// of r, w and m. Defaults to rwm.
+	Permissions string `protobuf:"bytes,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+}
+
+func (m *Device) Reset()         { *m = Device{} }
+func (m *Device) String() string { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()    {}
+func (*Device) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{9}
+}
+func (m *Device) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Device) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Device) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Device.Merge(m, src)
+}
+func (m *Device) XXX_Size() int {
+	return m.Size()
+}
+func (m *Device) XXX_DiscardUnknown() {
+	xxx_messageInfo_Device.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Device proto.InternalMessageInfo
+
+func (m *Device) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Device) GetDest() string {
+	if m != nil {
+		return m.Dest
+	}
+	return ""
+}
+
+func (m *Device) GetPermissions() string {
+	if m != nil {
+		return m.Permissions
+	}
+	return ""
+}
+
 // SSHOpt defines options describing secret mounts
 type SSHOpt struct {
 	// ID of exposed ssh rule. Used for quering the value.
@@ -796,7 +948,7 @@ func (m *SSHOpt) Reset()         { *m = SSHOpt{} }
 func (m *SSHOpt) String() string { return proto.CompactTextString(m) }
 func (*SSHOpt) ProtoMessage()    {}
 func (*SSHOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{8}
+	return fileDescriptor_8de16154b2733812, []int{10}
 }
 func (m *SSHOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -869,7 +1021,7 @@ func (m *SourceOp) Reset()         { *m = SourceOp{} }
 func (m *SourceOp) String() string { return proto.CompactTextString(m) }
 func (*SourceOp) ProtoMessage()    {}
 func (*SourceOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{9}
+	return fileDescriptor_8de16154b2733812, []int{11}
 }
 func (m *SourceOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -921,7 +1073,7 @@ func (m *BuildOp) Reset()         { *m = BuildOp{} }
 func (m *BuildOp) String() string { return proto.CompactTextString(m) }
 func (*BuildOp) ProtoMessage()    {}
 func (*BuildOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{10}
+	return fileDescriptor_8de16154b2733812, []int{12}
 }
 func (m *BuildOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -976,7 +1128,7 @@ func (m *BuildInput) Reset()         { *m = BuildInput{} }
 func (m *BuildInput) String() string { return proto.CompactTextString(m) }
 func (*BuildInput) ProtoMessage()    {}
 func (*BuildInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{11}
+	return fileDescriptor_8de16154b2733812, []int{13}
 }
 func (m *BuildInput) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1001,6 +1153,83 @@ func (m *BuildInput) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_BuildInput proto.InternalMessageInfo
 
+// MergeOp stacks the layers of its inputs on top of each other. The layers of
+// every input after the first one are reused as is, so the result doesn't
+// depend on the content of the inputs below them.
+type MergeOp struct {
+	Inputs []*MergeInput `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+}
+
+func (m *MergeOp) Reset()         { *m = MergeOp{} }
+func (m *MergeOp) String() string { return proto.CompactTextString(m) }
+func (*MergeOp) ProtoMessage()    {}
+func (*MergeOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{14}
+}
+func (m *MergeOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MergeOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *MergeOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeOp.Merge(m, src)
+}
+func (m *MergeOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *MergeOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeOp proto.InternalMessageInfo
+
+func (m *MergeOp) GetInputs() []*MergeInput {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+// MergeInput is used for MergeOp.
+type MergeInput struct {
+	Input InputIndex `protobuf:"varint,1,opt,name=input,proto3,customtype=InputIndex" json:"input"`
+}
+
+func (m *MergeInput) Reset()         { *m = MergeInput{} }
+func (m *MergeInput) String() string { return proto.CompactTextString(m) }
+func (*MergeInput) ProtoMessage()    {}
+func (*MergeInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{15}
+}
+func (m *MergeInput) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MergeInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *MergeInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeInput.Merge(m, src)
+}
+func (m *MergeInput) XXX_Size() int {
+	return m.Size()
+}
+func (m *MergeInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeInput proto.InternalMessageInfo
+
 // OpMetadata is a per-vertex metadata entry, which can be defined for arbitrary Op vertex and overridable on the run time.
 type OpMetadata struct {
 	// ignore_cache specifies to ignore the cache for this Op.
@@ -1017,7 +1246,7 @@ func (m *OpMetadata) Reset()         { *m = OpMetadata{} }
 func (m *OpMetadata) String() string { return proto.CompactTextString(m) }
 func (*OpMetadata) ProtoMessage()    {}
 func (*OpMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{12}
+	return fileDescriptor_8de16154b2733812, []int{16}
 }
 func (m *OpMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1080,7 +1309,7 @@ func (m *Source) Reset()         { *m = Source{} }
 func (m *Source) String() string { return proto.CompactTextString(m) }
 func (*Source) ProtoMessage()    {}
 func (*Source) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{13}
+	return fileDescriptor_8de16154b2733812, []int{17}
 }
 func (m *Source) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1128,7 +1357,7 @@ func (m *Locations) Reset()         { *m = Locations{} }
 func (m *Locations) String() string { return proto.CompactTextString(m) }
 func (*Locations) ProtoMessage()    {}
 func (*Locations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{14}
+	return fileDescriptor_8de16154b2733812, []int{18}
 }
 func (m *Locations) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1171,7 +1400,7 @@ func (m *SourceInfo) Reset()         { *m = SourceInfo{} }
 func (m *SourceInfo) String() string { return proto.CompactTextString(m) }
 func (*SourceInfo) ProtoMessage()    {}
 func (*SourceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{15}
+	return fileDescriptor_8de16154b2733812, []int{19}
 }
 func (m *SourceInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1227,7 +1456,7 @@ func (m *Location) Reset()         { *m = Location{} }
 func (m *Location) String() string { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()    {}
 func (*Location) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{16}
+	return fileDescriptor_8de16154b2733812, []int{20}
 }
 func (m *Location) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1276,7 +1505,7 @@ func (m *Range) Reset()         { *m = Range{} }
 func (m *Range) String() string { return proto.CompactTextString(m) }
 func (*Range) ProtoMessage()    {}
 func (*Range) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{17}
+	return fileDescriptor_8de16154b2733812, []int{21}
 }
 func (m *Range) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1325,7 +1554,7 @@ func (m *Position) Reset()         { *m = Position{} }
 func (m *Position) String() string { return proto.CompactTextString(m) }
 func (*Position) ProtoMessage()    {}
 func (*Position) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{18}
+	return fileDescriptor_8de16154b2733812, []int{22}
 }
 func (m *Position) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1372,7 +1601,7 @@ func (m *ExportCache) Reset()         { *m = ExportCache{} }
 func (m *ExportCache) String() string { return proto.CompactTextString(m) }
 func (*ExportCache) ProtoMessage()    {}
 func (*ExportCache) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{19}
+	return fileDescriptor_8de16154b2733812, []int{23}
 }
 func (m *ExportCache) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1415,7 +1644,7 @@ func (m *ProxyEnv) Reset()         { *m = ProxyEnv{} }
 func (m *ProxyEnv) String() string { return proto.CompactTextString(m) }
 func (*ProxyEnv) ProtoMessage()    {}
 func (*ProxyEnv) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{20}
+	return fileDescriptor_8de16154b2733812, []int{24}
 }
 func (m *ProxyEnv) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1477,7 +1706,7 @@ func (m *WorkerConstraints) Reset()         { *m = WorkerConstraints{} }
 func (m *WorkerConstraints) String() string { return proto.CompactTextString(m) }
 func (*WorkerConstraints) ProtoMessage()    {}
 func (*WorkerConstraints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{21}
+	return fileDescriptor_8de16154b2733812, []int{25}
 }
 func (m *WorkerConstraints) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1524,7 +1753,7 @@ func (m *Definition) Reset()         { *m = Definition{} }
 func (m *Definition) String() string { return proto.CompactTextString(m) }
 func (*Definition) ProtoMessage()    {}
 func (*Definition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{22}
+	return fileDescriptor_8de16154b2733812, []int{26}
 }
 func (m *Definition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1579,7 +1808,7 @@ func (m *HostIP) Reset()         { *m = HostIP{} }
 func (m *HostIP) String() string { return proto.CompactTextString(m) }
 func (*HostIP) ProtoMessage()    {}
 func (*HostIP) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{23}
+	return fileDescriptor_8de16154b2733812, []int{27}
 }
 func (m *HostIP) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1626,7 +1855,7 @@ func (m *FileOp) Reset()         { *m = FileOp{} }
 func (m *FileOp) String() string { return proto.CompactTextString(m) }
 func (*FileOp) ProtoMessage()    {}
 func (*FileOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{24}
+	return fileDescriptor_8de16154b2733812, []int{28}
 }
 func (m *FileOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1674,7 +1903,7 @@ func (m *FileAction) Reset()         { *m = FileAction{} }
 func (m *FileAction) String() string { return proto.CompactTextString(m) }
 func (*FileAction) ProtoMessage()    {}
 func (*FileAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{25}
+	return fileDescriptor_8de16154b2733812, []int{29}
 }
 func (m *FileAction) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1791,13 +2020,19 @@ type FileActionCopy struct {
 	AllowEmptyWildcard bool `protobuf:"varint,10,opt,name=allowEmptyWildcard,proto3" json:"allowEmptyWildcard,omitempty"`
 	// optional created time override
 	Timestamp int64 `protobuf:"varint,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// includePatterns only copies the paths in a src directory that match
+	// the patterns
+	IncludePatterns []string `protobuf:"bytes,12,rep,name=includePatterns,proto3" json:"includePatterns,omitempty"`
+	// excludePatterns skips the paths in a src directory that match the
+	// patterns
+	ExcludePatterns []string `protobuf:"bytes,13,rep,name=excludePatterns,proto3" json:"excludePatterns,omitempty"`
 }
 
 func (m *FileActionCopy) Reset()         { *m = FileActionCopy{} }
 func (m *FileActionCopy) String() string { return proto.CompactTextString(m) }
 func (*FileActionCopy) ProtoMessage()    {}
 func (*FileActionCopy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{26}
+	return fileDescriptor_8de16154b2733812, []int{30}
 }
 func (m *FileActionCopy) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1899,6 +2134,20 @@ func (m *FileActionCopy) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *FileActionCopy) GetIncludePatterns() []string {
+	if m != nil {
+		return m.IncludePatterns
+	}
+	return nil
+}
+
+func (m *FileActionCopy) GetExcludePatterns() []string {
+	if m != nil {
+		return m.ExcludePatterns
+	}
+	return nil
+}
+
 type FileActionMkFile struct {
 	// path for the new file
 	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
@@ -1916,7 +2165,7 @@ func (m *FileActionMkFile) Reset()         { *m = FileActionMkFile{} }
 func (m *FileActionMkFile) String() string { return proto.CompactTextString(m) }
 func (*FileActionMkFile) ProtoMessage()    {}
 func (*FileActionMkFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{27}
+	return fileDescriptor_8de16154b2733812, []int{31}
 }
 func (m *FileActionMkFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1993,7 +2242,7 @@ func (m *FileActionMkDir) Reset()         { *m = FileActionMkDir{} }
 func (m *FileActionMkDir) String() string { return proto.CompactTextString(m) }
 func (*FileActionMkDir) ProtoMessage()    {}
 func (*FileActionMkDir) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{28}
+	return fileDescriptor_8de16154b2733812, []int{32}
 }
 func (m *FileActionMkDir) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2066,7 +2315,7 @@ func (m *FileActionRm) Reset()         { *m = FileActionRm{} }
 func (m *FileActionRm) String() string { return proto.CompactTextString(m) }
 func (*FileActionRm) ProtoMessage()    {}
 func (*FileActionRm) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{29}
+	return fileDescriptor_8de16154b2733812, []int{33}
 }
 func (m *FileActionRm) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2121,7 +2370,7 @@ func (m *ChownOpt) Reset()         { *m = ChownOpt{} }
 func (m *ChownOpt) String() string { return proto.CompactTextString(m) }
 func (*ChownOpt) ProtoMessage()    {}
 func (*ChownOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{30}
+	return fileDescriptor_8de16154b2733812, []int{34}
 }
 func (m *ChownOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2171,7 +2420,7 @@ func (m *UserOpt) Reset()         { *m = UserOpt{} }
 func (m *UserOpt) String() string { return proto.CompactTextString(m) }
 func (*UserOpt) ProtoMessage()    {}
 func (*UserOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{31}
+	return fileDescriptor_8de16154b2733812, []int{35}
 }
 func (m *UserOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2250,7 +2499,7 @@ func (m *NamedUserOpt) Reset()         { *m = NamedUserOpt{} }
 func (m *NamedUserOpt) String() string { return proto.CompactTextString(m) }
 func (*NamedUserOpt) ProtoMessage()    {}
 func (*NamedUserOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{32}
+	return fileDescriptor_8de16154b2733812, []int{36}
 }
 func (m *NamedUserOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2295,6 +2544,8 @@ func init() {
 	proto.RegisterType((*Mount)(nil), "pb.Mount")
 	proto.RegisterType((*CacheOpt)(nil), "pb.CacheOpt")
 	proto.RegisterType((*SecretOpt)(nil), "pb.SecretOpt")
+	proto.RegisterType((*SecretEnv)(nil), "pb.SecretEnv")
+	proto.RegisterType((*Device)(nil), "pb.Device")
 	proto.RegisterType((*SSHOpt)(nil), "pb.SSHOpt")
 	proto.RegisterType((*SourceOp)(nil), "pb.SourceOp")
 	proto.RegisterMapType((map[string]string)(nil), "pb.SourceOp.AttrsEntry")
@@ -2302,6 +2553,8 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "pb.BuildOp.AttrsEntry")
 	proto.RegisterMapType((map[string]*BuildInput)(nil), "pb.BuildOp.InputsEntry")
 	proto.RegisterType((*BuildInput)(nil), "pb.BuildInput")
+	proto.RegisterType((*MergeOp)(nil), "pb.MergeOp")
+	proto.RegisterType((*MergeInput)(nil), "pb.MergeInput")
 	proto.RegisterType((*OpMetadata)(nil), "pb.OpMetadata")
 	proto.RegisterMapType((map[github_com_moby_buildkit_util_apicaps.CapID]bool)(nil), "pb.OpMetadata.CapsEntry")
 	proto.RegisterMapType((map[string]string)(nil), "pb.OpMetadata.DescriptionEntry")
@@ -2332,146 +2585,154 @@ func init() {
 func init() { proto.RegisterFile("ops.proto", fileDescriptor_8de16154b2733812) }
 
 var fileDescriptor_8de16154b2733812 = []byte{
-	// 2217 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
-	0x15, 0x17, 0xbf, 0xc9, 0x47, 0x49, 0x66, 0x27, 0x4e, 0xc2, 0xa8, 0xae, 0xa4, 0x6c, 0xdc, 0x40,
-	0x96, 0x6d, 0x0a, 0x50, 0x80, 0x38, 0x08, 0x8a, 0xa2, 0xe2, 0x87, 0x21, 0xc6, 0xb6, 0x28, 0x0c,
-	0xfd, 0xd1, 0x9b, 0xb1, 0x5a, 0x0e, 0xa9, 0x85, 0xc8, 0x9d, 0xc5, 0xec, 0xd0, 0x16, 0x2f, 0x3d,
-	0xf8, 0x2f, 0x08, 0x50, 0xa0, 0xb7, 0x16, 0xe8, 0xa5, 0x7f, 0x41, 0xaf, 0x3d, 0x16, 0xc8, 0x31,
-	0x87, 0x1e, 0x82, 0x1e, 0xd2, 0xc2, 0xbe, 0xf7, 0x3f, 0x28, 0x50, 0xbc, 0x37, 0xb3, 0x1f, 0x94,
-	0x65, 0xd8, 0x46, 0x8b, 0x9e, 0x76, 0xe6, 0xbd, 0xdf, 0xbc, 0x79, 0xf3, 0xbe, 0xe6, 0xcd, 0x42,
-	0x4d, 0x86, 0x51, 0x2b, 0x54, 0x52, 0x4b, 0x96, 0x0f, 0x4f, 0x36, 0x6e, 0x4f, 0x7c, 0x7d, 0x3a,
-	0x3f, 0x69, 0x79, 0x72, 0xb6, 0x37, 0x91, 0x13, 0xb9, 0x47, 0xac, 0x93, 0xf9, 0x98, 0x66, 0x34,
-	0xa1, 0x91, 0x59, 0xe2, 0xfc, 0x31, 0x0f, 0xf9, 0x41, 0xc8, 0x3e, 0x85, 0xb2, 0x1f, 0x84, 0x73,
-	0x1d, 0x35, 0x73, 0xdb, 0x85, 0x9d, 0xfa, 0x7e, 0xad, 0x15, 0x9e, 0xb4, 0xfa, 0x48, 0xe1, 0x96,
-	0xc1, 0xb6, 0xa1, 0x28, 0xce, 0x85, 0xd7, 0xcc, 0x6f, 0xe7, 0x76, 0xea, 0xfb, 0x80, 0x80, 0xde,
-	0xb9, 0xf0, 0x06, 0xe1, 0xe1, 0x0a, 0x27, 0x0e, 0xfb, 0x1c, 0xca, 0x91, 0x9c, 0x2b, 0x4f, 0x34,
-	0x0b, 0x84, 0x59, 0x45, 0xcc, 0x90, 0x28, 0x84, 0xb2, 0x5c, 0x94, 0x34, 0xf6, 0xa7, 0xa2, 0x59,
-	0x4c, 0x25, 0xdd, 0xf5, 0xa7, 0x06, 0x43, 0x1c, 0xf6, 0x19, 0x94, 0x4e, 0xe6, 0xfe, 0x74, 0xd4,
-	0x2c, 0x11, 0xa4, 0x8e, 0x90, 0x36, 0x12, 0x08, 0x63, 0x78, 0x6c, 0x07, 0xaa, 0xe1, 0xd4, 0xd5,
-	0x63, 0xa9, 0x66, 0x4d, 0x48, 0x37, 0x3c, 0xb6, 0x34, 0x9e, 0x70, 0xd9, 0x1d, 0xa8, 0x7b, 0x32,
-	0x88, 0xb4, 0x72, 0xfd, 0x40, 0x47, 0xcd, 0x3a, 0x81, 0x3f, 0x44, 0xf0, 0x13, 0xa9, 0xce, 0x84,
-	0xea, 0xa4, 0x4c, 0x9e, 0x45, 0xb6, 0x8b, 0x90, 0x97, 0xa1, 0xf3, 0xbb, 0x1c, 0x54, 0x63, 0xa9,
-	0xcc, 0x81, 0xd5, 0x03, 0xe5, 0x9d, 0xfa, 0x5a, 0x78, 0x7a, 0xae, 0x44, 0x33, 0xb7, 0x9d, 0xdb,
-	0xa9, 0xf1, 0x25, 0x1a, 0x5b, 0x87, 0xfc, 0x60, 0x48, 0x86, 0xaa, 0xf1, 0xfc, 0x60, 0xc8, 0x9a,
-	0x50, 0x79, 0xec, 0x2a, 0xdf, 0x0d, 0x34, 0x59, 0xa6, 0xc6, 0xe3, 0x29, 0xbb, 0x06, 0xb5, 0xc1,
-	0xf0, 0xb1, 0x50, 0x91, 0x2f, 0x03, 0xb2, 0x47, 0x8d, 0xa7, 0x04, 0xb6, 0x09, 0x30, 0x18, 0xde,
-	0x15, 0x2e, 0x0a, 0x8d, 0x9a, 0xa5, 0xed, 0xc2, 0x4e, 0x8d, 0x67, 0x28, 0xce, 0x6f, 0xa0, 0x44,
-	0x3e, 0x62, 0xdf, 0x40, 0x79, 0xe4, 0x4f, 0x44, 0xa4, 0x8d, 0x3a, 0xed, 0xfd, 0xef, 0x7e, 0xdc,
-	0x5a, 0xf9, 0xfb, 0x8f, 0x5b, 0xbb, 0x99, 0x60, 0x90, 0xa1, 0x08, 0x3c, 0x19, 0x68, 0xd7, 0x0f,
-	0x84, 0x8a, 0xf6, 0x26, 0xf2, 0xb6, 0x59, 0xd2, 0xea, 0xd2, 0x87, 0x5b, 0x09, 0xec, 0x06, 0x94,
-	0xfc, 0x60, 0x24, 0xce, 0x49, 0xff, 0x42, 0xfb, 0x03, 0x2b, 0xaa, 0x3e, 0x98, 0xeb, 0x70, 0xae,
-	0xfb, 0xc8, 0xe2, 0x06, 0xe1, 0xfc, 0x21, 0x07, 0x65, 0x13, 0x03, 0xec, 0x1a, 0x14, 0x67, 0x42,
-	0xbb, 0xb4, 0x7f, 0x7d, 0xbf, 0x8a, 0xb6, 0x7d, 0x20, 0xb4, 0xcb, 0x89, 0x8a, 0xe1, 0x35, 0x93,
-	0x73, 0xb4, 0x7d, 0x3e, 0x0d, 0xaf, 0x07, 0x48, 0xe1, 0x96, 0xc1, 0x7e, 0x0e, 0x95, 0x40, 0xe8,
-	0xe7, 0x52, 0x9d, 0x91, 0x8d, 0xd6, 0x8d, 0xd3, 0x8f, 0x84, 0x7e, 0x20, 0x47, 0x82, 0xc7, 0x3c,
-	0x76, 0x0b, 0xaa, 0x91, 0xf0, 0xe6, 0xca, 0xd7, 0x0b, 0xb2, 0xd7, 0xfa, 0x7e, 0x83, 0xa2, 0xcc,
-	0xd2, 0x08, 0x9c, 0x20, 0x9c, 0xbf, 0xe6, 0xa0, 0x88, 0x6a, 0x30, 0x06, 0x45, 0x57, 0x4d, 0x4c,
-	0x74, 0xd7, 0x38, 0x8d, 0x59, 0x03, 0x0a, 0x22, 0x78, 0x46, 0x1a, 0xd5, 0x38, 0x0e, 0x91, 0xe2,
-	0x3d, 0x1f, 0x59, 0x1f, 0xe1, 0x10, 0xd7, 0xcd, 0x23, 0xa1, 0xac, 0x6b, 0x68, 0xcc, 0x6e, 0x40,
-	0x2d, 0x54, 0xf2, 0x7c, 0xf1, 0x14, 0x57, 0x97, 0x32, 0x81, 0x87, 0xc4, 0x5e, 0xf0, 0x8c, 0x57,
-	0x43, 0x3b, 0x62, 0xbb, 0x00, 0xe2, 0x5c, 0x2b, 0xf7, 0x50, 0x46, 0x3a, 0x6a, 0x96, 0xe9, 0xec,
-	0x14, 0xef, 0x48, 0xe8, 0x1f, 0xf3, 0x0c, 0x97, 0x6d, 0x40, 0xf5, 0x54, 0x46, 0x3a, 0x70, 0x67,
-	0xa2, 0x59, 0xa1, 0xed, 0x92, 0xb9, 0xf3, 0xaf, 0x3c, 0x94, 0xc8, 0x5c, 0x6c, 0x07, 0xbd, 0x13,
-	0xce, 0x8d, 0xa3, 0x0b, 0x6d, 0x66, 0xbd, 0x03, 0x14, 0x07, 0x89, 0x73, 0x30, 0x26, 0x36, 0xd0,
-	0x52, 0x53, 0xe1, 0x69, 0xa9, 0x6c, 0x28, 0x26, 0x73, 0x3c, 0xd6, 0x08, 0xa3, 0xc5, 0x9c, 0x94,
-	0xc6, 0xec, 0x26, 0x94, 0x25, 0xb9, 0x98, 0x0e, 0xfb, 0x06, 0xc7, 0x5b, 0x08, 0x0a, 0x57, 0xc2,
-	0x1d, 0xc9, 0x60, 0xba, 0x20, 0x13, 0x54, 0x79, 0x32, 0x67, 0x37, 0xa1, 0x46, 0x3e, 0x7d, 0xb8,
-	0x08, 0x45, 0xb3, 0x4c, 0x3e, 0x5a, 0x4b, 0xfc, 0x8d, 0x44, 0x9e, 0xf2, 0x31, 0x89, 0x3d, 0xd7,
-	0x3b, 0x15, 0x83, 0x50, 0x37, 0xaf, 0xa6, 0xb6, 0xec, 0x58, 0x1a, 0x4f, 0xb8, 0x28, 0x36, 0x12,
-	0x9e, 0x12, 0x1a, 0xa1, 0x1f, 0x12, 0x74, 0xcd, 0xba, 0xde, 0x10, 0x79, 0xca, 0x67, 0x0e, 0x94,
-	0x87, 0xc3, 0x43, 0x44, 0x7e, 0x94, 0x16, 0x19, 0x43, 0xe1, 0x96, 0x63, 0xce, 0x10, 0xcd, 0xa7,
-	0xba, 0xdf, 0x6d, 0x7e, 0x6c, 0x0c, 0x14, 0xcf, 0x9d, 0x3e, 0x54, 0x63, 0x15, 0x30, 0x9b, 0xfb,
-	0x5d, 0x9b, 0xe7, 0xf9, 0x7e, 0x97, 0xdd, 0x86, 0x4a, 0x74, 0xea, 0x2a, 0x3f, 0x98, 0x90, 0x5d,
-	0xd7, 0xf7, 0x3f, 0x48, 0x34, 0x1e, 0x1a, 0x3a, 0xee, 0x12, 0x63, 0x1c, 0x09, 0xb5, 0x44, 0xc5,
-	0xd7, 0x64, 0x35, 0xa0, 0x30, 0xf7, 0x47, 0x24, 0x67, 0x8d, 0xe3, 0x10, 0x29, 0x13, 0xdf, 0xc4,
-	0xe0, 0x1a, 0xc7, 0x21, 0x3a, 0x6b, 0x26, 0x47, 0xa6, 0x5c, 0xae, 0x71, 0x1a, 0xa3, 0xee, 0x32,
-	0xd4, 0xbe, 0x0c, 0xdc, 0x69, 0x6c, 0xff, 0x78, 0xee, 0x4c, 0xe3, 0xb3, 0xff, 0x5f, 0x76, 0xfb,
-	0x6d, 0x0e, 0xaa, 0x71, 0x8d, 0xc7, 0x82, 0xe5, 0x8f, 0x44, 0xa0, 0xfd, 0xb1, 0x2f, 0x94, 0xdd,
-	0x38, 0x43, 0x61, 0xb7, 0xa1, 0xe4, 0x6a, 0xad, 0xe2, 0x32, 0xf0, 0x71, 0xf6, 0x82, 0x68, 0x1d,
-	0x20, 0xa7, 0x17, 0x68, 0xb5, 0xe0, 0x06, 0xb5, 0xf1, 0x15, 0x40, 0x4a, 0x44, 0x5d, 0xcf, 0xc4,
-	0xc2, 0x4a, 0xc5, 0x21, 0xbb, 0x0a, 0xa5, 0x67, 0xee, 0x74, 0x2e, 0x6c, 0x7c, 0x9b, 0xc9, 0xd7,
-	0xf9, 0xaf, 0x72, 0xce, 0x5f, 0xf2, 0x50, 0xb1, 0x17, 0x06, 0xbb, 0x05, 0x15, 0xba, 0x30, 0xac,
-	0x46, 0x97, 0x27, 0x4d, 0x0c, 0x61, 0x7b, 0xc9, 0x4d, 0x98, 0xd1, 0xd1, 0x8a, 0x32, 0x37, 0xa2,
-	0xd5, 0x31, 0xbd, 0x17, 0x0b, 0x23, 0x31, 0xb6, 0x57, 0xde, 0x3a, 0xa2, 0xbb, 0x62, 0xec, 0x07,
-	0x3e, 0xda, 0x87, 0x23, 0x8b, 0xdd, 0x8a, 0x4f, 0x5d, 0x24, 0x89, 0x1f, 0x65, 0x25, 0xbe, 0x7e,
-	0xe8, 0x3e, 0xd4, 0x33, 0xdb, 0x5c, 0x72, 0xea, 0xeb, 0xd9, 0x53, 0xdb, 0x2d, 0x49, 0x9c, 0xb9,
-	0xaf, 0x53, 0x2b, 0xfc, 0x17, 0xf6, 0xfb, 0x12, 0x20, 0x15, 0xf9, 0xee, 0x45, 0xc7, 0x79, 0x51,
-	0x00, 0x18, 0x84, 0x58, 0x72, 0x47, 0x2e, 0xd5, 0xfd, 0x55, 0x7f, 0x12, 0x48, 0x25, 0x9e, 0x52,
-	0x1a, 0xd3, 0xfa, 0x2a, 0xaf, 0x1b, 0x1a, 0x65, 0x0c, 0x3b, 0x80, 0xfa, 0x48, 0x44, 0x9e, 0xf2,
-	0x29, 0xa0, 0xac, 0xd1, 0xb7, 0xf0, 0x4c, 0xa9, 0x9c, 0x56, 0x37, 0x45, 0x18, 0x5b, 0x65, 0xd7,
-	0xb0, 0x7d, 0x58, 0x15, 0xe7, 0xa1, 0x54, 0xda, 0xee, 0x62, 0xfa, 0x8a, 0x2b, 0xa6, 0x43, 0x41,
-	0x3a, 0xed, 0xc4, 0xeb, 0x22, 0x9d, 0x30, 0x17, 0x8a, 0x9e, 0x1b, 0x9a, 0x4b, 0xb5, 0xbe, 0xdf,
-	0xbc, 0xb0, 0x5f, 0xc7, 0x0d, 0x8d, 0xd1, 0xda, 0x5f, 0xe0, 0x59, 0x5f, 0xfc, 0x63, 0xeb, 0x66,
-	0xe6, 0x26, 0x9d, 0xc9, 0x93, 0xc5, 0x1e, 0xc5, 0xcb, 0x99, 0xaf, 0xf7, 0xe6, 0xda, 0x9f, 0xee,
-	0xb9, 0xa1, 0x8f, 0xe2, 0x70, 0x61, 0xbf, 0xcb, 0x49, 0xf4, 0xc6, 0x2f, 0xa1, 0x71, 0x51, 0xef,
-	0xf7, 0xf1, 0xc1, 0xc6, 0x1d, 0xa8, 0x25, 0x7a, 0xbc, 0x6d, 0x61, 0x35, 0xeb, 0xbc, 0x3f, 0xe7,
-	0xa0, 0x6c, 0xb2, 0x8a, 0xdd, 0x81, 0xda, 0x54, 0x7a, 0x2e, 0x2a, 0x10, 0xb7, 0x76, 0x9f, 0xa4,
-	0x49, 0xd7, 0xba, 0x1f, 0xf3, 0x8c, 0x55, 0x53, 0x2c, 0x06, 0x99, 0x1f, 0x8c, 0x65, 0x9c, 0x05,
-	0xeb, 0xe9, 0xa2, 0x7e, 0x30, 0x96, 0xdc, 0x30, 0x37, 0xee, 0xc1, 0xfa, 0xb2, 0x88, 0x4b, 0xf4,
-	0xfc, 0x6c, 0x39, 0x5c, 0xa9, 0x66, 0x27, 0x8b, 0xb2, 0x6a, 0xdf, 0x81, 0x5a, 0x42, 0x67, 0xbb,
-	0xaf, 0x2b, 0xbe, 0x9a, 0x5d, 0x99, 0xd1, 0xd5, 0x99, 0x02, 0xa4, 0xaa, 0x61, 0xb1, 0xc2, 0x1e,
-	0x92, 0xee, 0x51, 0xa3, 0x46, 0x32, 0xa7, 0x7b, 0xcf, 0xd5, 0x2e, 0xa9, 0xb2, 0xca, 0x69, 0xcc,
-	0x5a, 0x00, 0xa3, 0x24, 0x61, 0xdf, 0x90, 0xc6, 0x19, 0x84, 0x33, 0x80, 0x6a, 0xac, 0x04, 0xdb,
-	0x86, 0x7a, 0x64, 0x77, 0xc6, 0x8e, 0x09, 0xb7, 0x2b, 0xf1, 0x2c, 0x09, 0x3b, 0x1f, 0xe5, 0x06,
-	0x13, 0xb1, 0xd4, 0xf9, 0x70, 0xa4, 0x70, 0xcb, 0x70, 0x9e, 0x40, 0x89, 0x08, 0x98, 0x66, 0x91,
-	0x76, 0x95, 0xb6, 0x4d, 0x94, 0x69, 0x2a, 0x64, 0x44, 0xdb, 0xb6, 0x8b, 0x18, 0x88, 0xdc, 0x00,
-	0xd8, 0x75, 0x6c, 0x5d, 0x46, 0xd6, 0xa2, 0x97, 0xe1, 0x90, 0xed, 0xfc, 0x02, 0xaa, 0x31, 0x19,
-	0x4f, 0x7e, 0xdf, 0x0f, 0x84, 0x55, 0x91, 0xc6, 0xd8, 0x7c, 0x76, 0x4e, 0x5d, 0xe5, 0x7a, 0x5a,
-	0x98, 0x16, 0xa1, 0xc4, 0x53, 0x82, 0xf3, 0x19, 0xd4, 0x33, 0xd9, 0x83, 0xe1, 0xf6, 0x98, 0xdc,
-	0x68, 0x72, 0xd8, 0x4c, 0x9c, 0x17, 0xd8, 0x1a, 0xc7, 0xdd, 0xce, 0xcf, 0x00, 0x4e, 0xb5, 0x0e,
-	0x9f, 0x52, 0xfb, 0x63, 0x6d, 0x5f, 0x43, 0x0a, 0x21, 0xd8, 0x16, 0xd4, 0x71, 0x12, 0x59, 0xbe,
-	0x89, 0x77, 0x5a, 0x11, 0x19, 0xc0, 0x4f, 0xa1, 0x36, 0x4e, 0x96, 0x17, 0xac, 0xeb, 0xe2, 0xd5,
-	0x9f, 0x40, 0x35, 0x90, 0x96, 0x67, 0xba, 0xb1, 0x4a, 0x20, 0x89, 0xe5, 0xdc, 0x84, 0x9f, 0xbc,
-	0xd6, 0xc7, 0xb3, 0x8f, 0xa0, 0x3c, 0xf6, 0xa7, 0x9a, 0x8a, 0x3e, 0x36, 0x78, 0x76, 0xe6, 0xfc,
-	0x3b, 0x07, 0x90, 0x7a, 0x16, 0xe3, 0x15, 0xab, 0x37, 0x62, 0x56, 0x4d, 0xb5, 0x9e, 0x42, 0x75,
-	0x66, 0xeb, 0x80, 0xf5, 0xd9, 0xb5, 0xe5, 0x68, 0x68, 0xc5, 0x65, 0xc2, 0x54, 0x88, 0x7d, 0x5b,
-	0x21, 0xde, 0xa7, 0xd7, 0x4e, 0x76, 0xa0, 0x46, 0x25, 0xfb, 0x66, 0x82, 0x34, 0xd1, 0xb8, 0xe5,
-	0x6c, 0xdc, 0x83, 0xb5, 0xa5, 0x2d, 0xdf, 0xf1, 0x4e, 0x48, 0xeb, 0x59, 0x36, 0xcb, 0x6e, 0x41,
-	0xd9, 0x34, 0x9f, 0x18, 0x12, 0x38, 0xb2, 0x62, 0x68, 0x4c, 0x1d, 0xc3, 0x71, 0xfc, 0x72, 0xe9,
-	0x1f, 0x3b, 0xfb, 0x50, 0x36, 0x4f, 0x33, 0xb6, 0x03, 0x15, 0xd7, 0x33, 0xe9, 0x98, 0x29, 0x09,
-	0xc8, 0x3c, 0x20, 0x32, 0x8f, 0xd9, 0xce, 0xdf, 0xf2, 0x00, 0x29, 0xfd, 0x3d, 0x3a, 0xd6, 0xaf,
-	0x61, 0x3d, 0x12, 0x9e, 0x0c, 0x46, 0xae, 0x5a, 0x10, 0xd7, 0x3e, 0x41, 0x2e, 0x5b, 0x72, 0x01,
-	0x99, 0xe9, 0x5e, 0x0b, 0x6f, 0xef, 0x5e, 0x77, 0xa0, 0xe8, 0xc9, 0x70, 0x61, 0x2f, 0x0a, 0xb6,
-	0x7c, 0x90, 0x8e, 0x0c, 0x17, 0xf8, 0x10, 0x45, 0x04, 0x6b, 0x41, 0x79, 0x76, 0x46, 0x8f, 0x55,
-	0xd3, 0xe8, 0x5f, 0x5d, 0xc6, 0x3e, 0x38, 0xc3, 0x31, 0x3e, 0x6d, 0x0d, 0x8a, 0xdd, 0x84, 0xd2,
-	0xec, 0x6c, 0xe4, 0x2b, 0xea, 0x7b, 0xeb, 0xa6, 0x33, 0xcc, 0xc2, 0xbb, 0xbe, 0xc2, 0x07, 0x2c,
-	0x61, 0x98, 0x03, 0x79, 0x35, 0xa3, 0x5e, 0xbf, 0x6e, 0x5e, 0x31, 0x19, 0x6b, 0xce, 0x0e, 0x57,
-	0x78, 0x5e, 0xcd, 0xda, 0x55, 0x28, 0x1b, 0xbb, 0x3a, 0x7f, 0x2a, 0xc0, 0xfa, 0xb2, 0x96, 0x18,
-	0x07, 0x91, 0xf2, 0xe2, 0x38, 0x88, 0x94, 0x97, 0x34, 0xf6, 0xf9, 0x4c, 0x63, 0xef, 0x40, 0x49,
-	0x3e, 0x0f, 0x84, 0xca, 0xbe, 0xca, 0x3b, 0xa7, 0xf2, 0x79, 0x80, 0x6d, 0xaa, 0x61, 0x2d, 0x75,
-	0x7d, 0x25, 0xdb, 0xf5, 0x5d, 0x87, 0xb5, 0xb1, 0x9c, 0x4e, 0xe5, 0xf3, 0xe1, 0x62, 0x36, 0xf5,
-	0x83, 0x33, 0xdb, 0xfa, 0x2d, 0x13, 0xd9, 0x0e, 0x5c, 0x19, 0xf9, 0x0a, 0xd5, 0xe9, 0xc8, 0x40,
-	0x8b, 0x80, 0xde, 0x39, 0x88, 0xbb, 0x48, 0x66, 0xdf, 0xc0, 0xb6, 0xab, 0xb5, 0x98, 0x85, 0xfa,
-	0x51, 0x10, 0xba, 0xde, 0x59, 0x57, 0x7a, 0x94, 0xb3, 0xb3, 0xd0, 0xd5, 0xfe, 0x89, 0x3f, 0xc5,
-	0x27, 0x5d, 0x85, 0x96, 0xbe, 0x15, 0xc7, 0x3e, 0x87, 0x75, 0x4f, 0x09, 0x57, 0x8b, 0xae, 0x88,
-	0xf4, 0xb1, 0xab, 0x4f, 0x9b, 0x55, 0x5a, 0x79, 0x81, 0x8a, 0x67, 0x70, 0x51, 0xdb, 0x27, 0xfe,
-	0x74, 0xe4, 0xb9, 0x6a, 0xd4, 0xac, 0x99, 0x33, 0x2c, 0x11, 0x59, 0x0b, 0x18, 0x11, 0x7a, 0xb3,
-	0x50, 0x2f, 0x12, 0x28, 0x10, 0xf4, 0x12, 0x0e, 0x16, 0x4e, 0xed, 0xcf, 0x44, 0xa4, 0xdd, 0x59,
-	0x48, 0x7f, 0x13, 0x0a, 0x3c, 0x25, 0x38, 0xdf, 0xe6, 0xa0, 0x71, 0x31, 0x44, 0xd0, 0xc0, 0x21,
-	0xaa, 0x69, 0x93, 0x0d, 0xc7, 0x89, 0xd1, 0xf3, 0x19, 0xa3, 0xc7, 0x37, 0x54, 0x21, 0x73, 0x43,
-	0x25, 0x0e, 0x2c, 0xbe, 0xd9, 0x81, 0x4b, 0x2a, 0x95, 0x2e, 0xaa, 0xf4, 0xfb, 0x1c, 0x5c, 0xb9,
-	0x10, 0x86, 0xef, 0xac, 0xd1, 0x36, 0xd4, 0x67, 0xee, 0x99, 0x38, 0x76, 0x15, 0x39, 0xb7, 0x60,
-	0x5a, 0xb8, 0x0c, 0xe9, 0x7f, 0xa0, 0x5f, 0x00, 0xab, 0xd9, 0xd8, 0xbf, 0x54, 0xb7, 0xd8, 0x95,
-	0x47, 0x52, 0xdf, 0x95, 0x73, 0x7b, 0xfb, 0xc5, 0xae, 0x8c, 0x89, 0xaf, 0x3b, 0xbc, 0x70, 0x89,
-	0xc3, 0x9d, 0x23, 0xa8, 0xc6, 0x0a, 0xb2, 0x2d, 0xfb, 0xc4, 0xcf, 0xa5, 0xbf, 0x9a, 0x1e, 0x45,
-	0x42, 0xa1, 0xee, 0xe6, 0xbd, 0xff, 0x29, 0x94, 0x26, 0x4a, 0xce, 0x43, 0x5b, 0x5b, 0x97, 0x10,
-	0x86, 0xe3, 0x0c, 0xa1, 0x62, 0x29, 0x6c, 0x17, 0xca, 0x27, 0x8b, 0xa3, 0xb8, 0xf9, 0xb0, 0x89,
-	0x8d, 0xf3, 0x91, 0x45, 0x60, 0xb5, 0x30, 0x08, 0x76, 0x15, 0x8a, 0x27, 0x8b, 0x7e, 0xd7, 0x3c,
-	0xc8, 0xb0, 0xe6, 0xe0, 0xac, 0x5d, 0x36, 0x0a, 0x39, 0xf7, 0x61, 0x35, 0xbb, 0x0e, 0x8d, 0x92,
-	0x69, 0x6a, 0x68, 0x9c, 0x16, 0xd7, 0xfc, 0x5b, 0x8a, 0xeb, 0xee, 0x0e, 0x54, 0xec, 0xcf, 0x14,
-	0x56, 0x83, 0xd2, 0xa3, 0xa3, 0x61, 0xef, 0x61, 0x63, 0x85, 0x55, 0xa1, 0x78, 0x38, 0x18, 0x3e,
-	0x6c, 0xe4, 0x70, 0x74, 0x34, 0x38, 0xea, 0x35, 0xf2, 0xbb, 0x37, 0x60, 0x35, 0xfb, 0x3b, 0x85,
-	0xd5, 0xa1, 0x32, 0x3c, 0x38, 0xea, 0xb6, 0x07, 0xbf, 0x6e, 0xac, 0xb0, 0x55, 0xa8, 0xf6, 0x8f,
-	0x86, 0xbd, 0xce, 0x23, 0xde, 0x6b, 0xe4, 0x76, 0x7f, 0x05, 0xb5, 0xe4, 0x55, 0x8f, 0x12, 0xda,
-	0xfd, 0xa3, 0x6e, 0x63, 0x85, 0x01, 0x94, 0x87, 0xbd, 0x0e, 0xef, 0xa1, 0xdc, 0x0a, 0x14, 0x86,
-	0xc3, 0xc3, 0x46, 0x1e, 0x77, 0xed, 0x1c, 0x74, 0x0e, 0x7b, 0x8d, 0x02, 0x0e, 0x1f, 0x3e, 0x38,
-	0xbe, 0x3b, 0x6c, 0x14, 0x77, 0xbf, 0x84, 0x2b, 0x17, 0x5e, 0xce, 0xb4, 0xfa, 0xf0, 0x80, 0xf7,
-	0x50, 0x52, 0x1d, 0x2a, 0xc7, 0xbc, 0xff, 0xf8, 0xe0, 0x61, 0xaf, 0x91, 0x43, 0xc6, 0xfd, 0x41,
-	0xe7, 0x5e, 0xaf, 0xdb, 0xc8, 0xb7, 0xaf, 0x7d, 0xf7, 0x72, 0x33, 0xf7, 0xfd, 0xcb, 0xcd, 0xdc,
-	0x0f, 0x2f, 0x37, 0x73, 0xff, 0x7c, 0xb9, 0x99, 0xfb, 0xf6, 0xd5, 0xe6, 0xca, 0xf7, 0xaf, 0x36,
-	0x57, 0x7e, 0x78, 0xb5, 0xb9, 0x72, 0x52, 0xa6, 0x9f, 0x9b, 0x5f, 0xfc, 0x27, 0x00, 0x00, 0xff,
-	0xff, 0xa4, 0x50, 0x4f, 0x17, 0x1c, 0x15, 0x00, 0x00,
+	// 2348 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x6f, 0x1b, 0xb9,
+	0x15, 0xb7, 0x46, 0xdf, 0x4f, 0xb2, 0xa2, 0x32, 0xd9, 0xac, 0xd6, 0x4d, 0x6d, 0xef, 0x24, 0x5d,
+	0x38, 0x4e, 0x22, 0xa3, 0x5e, 0x60, 0xb3, 0x58, 0x14, 0x45, 0xad, 0x8f, 0xc0, 0xda, 0xc4, 0x96,
+	0x41, 0xe5, 0xa3, 0xb7, 0x60, 0x3c, 0x43, 0xcb, 0x03, 0x4b, 0xc3, 0x01, 0x87, 0x4a, 0xac, 0x4b,
+	0x0f, 0xf9, 0x0b, 0x16, 0x28, 0xd0, 0x5b, 0xff, 0x8b, 0x5e, 0x7b, 0x6c, 0xb1, 0xc7, 0x3d, 0xf4,
+	0xb0, 0xe8, 0x61, 0x5b, 0x24, 0xf7, 0x02, 0xbd, 0x16, 0x28, 0x50, 0x3c, 0x92, 0xf3, 0x21, 0xd9,
+	0x69, 0x12, 0xb4, 0xe8, 0x69, 0xc8, 0xdf, 0xfb, 0xf1, 0xf1, 0x91, 0xef, 0xf1, 0xf1, 0x0d, 0xa1,
+	0xca, 0xc3, 0xa8, 0x1d, 0x0a, 0x2e, 0x39, 0xb1, 0xc2, 0xe3, 0xb5, 0x7b, 0x63, 0x5f, 0x9e, 0xce,
+	0x8e, 0xdb, 0x2e, 0x9f, 0xee, 0x8c, 0xf9, 0x98, 0xef, 0x28, 0xd1, 0xf1, 0xec, 0x44, 0xf5, 0x54,
+	0x47, 0xb5, 0xf4, 0x10, 0xfb, 0x4f, 0x16, 0x58, 0xc3, 0x90, 0x7c, 0x0a, 0x25, 0x3f, 0x08, 0x67,
+	0x32, 0x6a, 0xe5, 0x36, 0xf3, 0x5b, 0xb5, 0xdd, 0x6a, 0x3b, 0x3c, 0x6e, 0x0f, 0x10, 0xa1, 0x46,
+	0x40, 0x36, 0xa1, 0xc0, 0xce, 0x99, 0xdb, 0xb2, 0x36, 0x73, 0x5b, 0xb5, 0x5d, 0x40, 0x42, 0xff,
+	0x9c, 0xb9, 0xc3, 0x70, 0x7f, 0x85, 0x2a, 0x09, 0xf9, 0x0c, 0x4a, 0x11, 0x9f, 0x09, 0x97, 0xb5,
+	0xf2, 0x8a, 0x53, 0x47, 0xce, 0x48, 0x21, 0x8a, 0x65, 0xa4, 0xa8, 0xe9, 0xc4, 0x9f, 0xb0, 0x56,
+	0x21, 0xd5, 0xf4, 0xc0, 0x9f, 0x68, 0x8e, 0x92, 0x90, 0x9b, 0x50, 0x3c, 0x9e, 0xf9, 0x13, 0xaf,
+	0x55, 0x54, 0x94, 0x1a, 0x52, 0x3a, 0x08, 0x28, 0x8e, 0x96, 0x21, 0x69, 0xca, 0xc4, 0x98, 0xb5,
+	0x4a, 0x29, 0xe9, 0x00, 0x01, 0x4d, 0x52, 0x32, 0xb2, 0x05, 0x95, 0x70, 0xe2, 0xc8, 0x13, 0x2e,
+	0xa6, 0x2d, 0x48, 0xad, 0x3a, 0x32, 0x18, 0x4d, 0xa4, 0xe4, 0x3e, 0xd4, 0x5c, 0x1e, 0x44, 0x52,
+	0x38, 0x7e, 0x20, 0xa3, 0x56, 0x4d, 0x91, 0x3f, 0x42, 0xf2, 0x33, 0x2e, 0xce, 0x98, 0xe8, 0xa6,
+	0x42, 0x9a, 0x65, 0x76, 0x0a, 0x60, 0xf1, 0xd0, 0xfe, 0x6d, 0x0e, 0x2a, 0xb1, 0x56, 0x62, 0x43,
+	0x7d, 0x4f, 0xb8, 0xa7, 0xbe, 0x64, 0xae, 0x9c, 0x09, 0xd6, 0xca, 0x6d, 0xe6, 0xb6, 0xaa, 0x74,
+	0x01, 0x23, 0x0d, 0xb0, 0x86, 0x23, 0xb5, 0x9b, 0x55, 0x6a, 0x0d, 0x47, 0xa4, 0x05, 0xe5, 0xa7,
+	0x8e, 0xf0, 0x9d, 0x40, 0xaa, 0xed, 0xab, 0xd2, 0xb8, 0x4b, 0x6e, 0x40, 0x75, 0x38, 0x7a, 0xca,
+	0x44, 0xe4, 0xf3, 0x40, 0x6d, 0x5a, 0x95, 0xa6, 0x00, 0x59, 0x07, 0x18, 0x8e, 0x1e, 0x30, 0x07,
+	0x95, 0x46, 0xad, 0xe2, 0x66, 0x7e, 0xab, 0x4a, 0x33, 0x88, 0xfd, 0x6b, 0x28, 0x2a, 0x47, 0x92,
+	0xaf, 0xa1, 0xe4, 0xf9, 0x63, 0x16, 0x49, 0x6d, 0x4e, 0x67, 0xf7, 0xdb, 0x1f, 0x36, 0x56, 0xfe,
+	0xf2, 0xc3, 0xc6, 0x76, 0x26, 0x62, 0x78, 0xc8, 0x02, 0x97, 0x07, 0xd2, 0xf1, 0x03, 0x26, 0xa2,
+	0x9d, 0x31, 0xbf, 0xa7, 0x87, 0xb4, 0x7b, 0xea, 0x43, 0x8d, 0x06, 0x72, 0x1b, 0x8a, 0x7e, 0xe0,
+	0xb1, 0x73, 0x65, 0x7f, 0xbe, 0x73, 0xd5, 0xa8, 0xaa, 0x0d, 0x67, 0x32, 0x9c, 0xc9, 0x01, 0x8a,
+	0xa8, 0x66, 0xd8, 0xff, 0xc8, 0x41, 0x49, 0x07, 0x0a, 0xb9, 0x01, 0x85, 0x29, 0x93, 0x8e, 0x9a,
+	0xbf, 0xb6, 0x5b, 0xd1, 0x0e, 0x93, 0x0e, 0x55, 0x28, 0xc6, 0xe0, 0x94, 0xcf, 0x70, 0xef, 0xad,
+	0x34, 0x06, 0x0f, 0x10, 0xa1, 0x46, 0x40, 0x7e, 0x0a, 0xe5, 0x80, 0xc9, 0x97, 0x5c, 0x9c, 0xa9,
+	0x3d, 0x6a, 0x68, 0xa7, 0x1f, 0x32, 0x79, 0xc0, 0x3d, 0x46, 0x63, 0x19, 0xb9, 0x0b, 0x95, 0x88,
+	0xb9, 0x33, 0xe1, 0xcb, 0xb9, 0xda, 0xaf, 0xc6, 0x6e, 0x53, 0x85, 0xa2, 0xc1, 0x14, 0x39, 0x61,
+	0x90, 0x3b, 0x50, 0x8d, 0x98, 0x2b, 0x98, 0x64, 0xc1, 0x0b, 0xb5, 0x7f, 0xb5, 0xdd, 0x55, 0x43,
+	0x17, 0x4c, 0xf6, 0x83, 0x17, 0x34, 0x95, 0x93, 0x5b, 0x50, 0xf6, 0xd8, 0x0b, 0xdf, 0x65, 0x51,
+	0xab, 0xb4, 0x99, 0x8f, 0xc3, 0xb7, 0xa7, 0x20, 0x1a, 0x8b, 0xec, 0x3f, 0xe6, 0xa0, 0x80, 0x2b,
+	0x23, 0x04, 0x0a, 0x8e, 0x18, 0xeb, 0x53, 0x55, 0xa5, 0xaa, 0x4d, 0x9a, 0x90, 0xc7, 0x99, 0x2c,
+	0x05, 0x61, 0x13, 0x11, 0xf7, 0xa5, 0x67, 0xdc, 0x8e, 0x4d, 0x1c, 0x37, 0x8b, 0x98, 0x30, 0xde,
+	0x56, 0x6d, 0x72, 0x1b, 0xaa, 0xa1, 0xe0, 0xe7, 0xf3, 0xe7, 0xda, 0xce, 0x34, 0x96, 0x11, 0x44,
+	0x33, 0x2b, 0xa1, 0x69, 0x91, 0x6d, 0x00, 0x76, 0x2e, 0x85, 0xb3, 0xcf, 0x23, 0xb9, 0x60, 0x28,
+	0x02, 0x83, 0x23, 0x9a, 0x91, 0x92, 0x35, 0xa8, 0x9c, 0xf2, 0x48, 0x06, 0xce, 0x94, 0xb5, 0xca,
+	0x6a, 0xba, 0xa4, 0x6f, 0xff, 0xdd, 0x82, 0xa2, 0xf2, 0x00, 0xd9, 0x42, 0x87, 0x87, 0x33, 0x1d,
+	0x3b, 0xf9, 0x0e, 0x31, 0x0e, 0x87, 0x41, 0x90, 0xf5, 0x37, 0x86, 0xd9, 0x1a, 0x6e, 0xfe, 0x84,
+	0xb9, 0x92, 0x0b, 0x13, 0xdd, 0x49, 0x1f, 0x97, 0xe5, 0x61, 0x00, 0xea, 0x95, 0xaa, 0x36, 0xb9,
+	0x03, 0x25, 0xae, 0xa2, 0xa6, 0x55, 0x78, 0x7b, 0x2c, 0x19, 0x0a, 0x2a, 0x17, 0xcc, 0xf1, 0x78,
+	0x30, 0x99, 0xab, 0x2d, 0xa8, 0xd0, 0xa4, 0x8f, 0x7e, 0x54, 0x61, 0xf2, 0x78, 0x1e, 0xea, 0x9c,
+	0xd0, 0xd0, 0x7e, 0x3c, 0x88, 0x41, 0x9a, 0xca, 0x31, 0x2f, 0xb8, 0x8e, 0x7b, 0xca, 0x86, 0xa1,
+	0x6c, 0x5d, 0x4b, 0xf7, 0xb2, 0x6b, 0x30, 0x9a, 0x48, 0xd3, 0xf0, 0x40, 0xea, 0x47, 0x8a, 0x9a,
+	0x09, 0x0f, 0xe4, 0xa6, 0x72, 0x62, 0x43, 0x69, 0x34, 0xda, 0x47, 0xe6, 0xf5, 0x34, 0xb9, 0x69,
+	0x84, 0x1a, 0x89, 0x5e, 0x43, 0x34, 0x9b, 0xc8, 0x41, 0xaf, 0xf5, 0xb1, 0xde, 0xa0, 0xb8, 0x6f,
+	0x0f, 0xa0, 0x12, 0x9b, 0x80, 0x09, 0x62, 0xd0, 0x33, 0xa9, 0xc3, 0x1a, 0xf4, 0xc8, 0x3d, 0x28,
+	0x47, 0xa7, 0x8e, 0xf0, 0x83, 0xb1, 0xda, 0xd7, 0xc6, 0xee, 0xd5, 0xc4, 0xe2, 0x91, 0xc6, 0x71,
+	0x96, 0x98, 0x63, 0x73, 0xa8, 0x26, 0x26, 0x5e, 0xd0, 0xd5, 0x84, 0xfc, 0xcc, 0xf7, 0x94, 0x9e,
+	0x55, 0x8a, 0x4d, 0x44, 0xc6, 0xbe, 0x8e, 0xc1, 0x55, 0x8a, 0x4d, 0x74, 0xd6, 0x94, 0x7b, 0x3a,
+	0x4d, 0xaf, 0x52, 0xd5, 0x46, 0xdb, 0x79, 0x28, 0x7d, 0x1e, 0x38, 0x93, 0x78, 0xff, 0xe3, 0xbe,
+	0xfd, 0x30, 0x9e, 0x10, 0x23, 0x70, 0x79, 0x42, 0x02, 0x05, 0x15, 0x61, 0x3a, 0x22, 0x54, 0x7b,
+	0x41, 0x59, 0x7e, 0x49, 0xd9, 0x53, 0x28, 0xe9, 0x43, 0x45, 0xae, 0x27, 0xb7, 0x8a, 0xd6, 0x66,
+	0x7a, 0x49, 0x2c, 0x59, 0x99, 0x58, 0xda, 0x84, 0x5a, 0xc8, 0xc4, 0xd4, 0x8f, 0x30, 0x33, 0x46,
+	0x26, 0xcc, 0xb2, 0x90, 0x3d, 0x89, 0x1d, 0xf4, 0x7f, 0xd9, 0x92, 0xdf, 0xe4, 0xa0, 0x12, 0x5f,
+	0x80, 0x98, 0xa8, 0x7d, 0x8f, 0x05, 0xd2, 0x3f, 0xf1, 0x99, 0x30, 0x13, 0x67, 0x10, 0x72, 0x0f,
+	0x8a, 0x8e, 0x94, 0x22, 0x4e, 0x7f, 0x1f, 0x67, 0x6f, 0xcf, 0xf6, 0x1e, 0x4a, 0xfa, 0x81, 0x14,
+	0x73, 0xaa, 0x59, 0x6b, 0x5f, 0x02, 0xa4, 0x20, 0xda, 0x7a, 0xc6, 0xe6, 0x46, 0x2b, 0x36, 0xc9,
+	0x35, 0x28, 0xbe, 0x70, 0x26, 0xb3, 0x78, 0xcb, 0x75, 0xe7, 0x2b, 0xeb, 0xcb, 0x9c, 0xfd, 0x07,
+	0x0b, 0xca, 0xe6, 0x36, 0x25, 0x77, 0xa1, 0xac, 0x6e, 0x53, 0x26, 0xfe, 0xc3, 0xc9, 0x8e, 0x29,
+	0x64, 0x27, 0x29, 0x13, 0x32, 0x36, 0x1a, 0x55, 0xba, 0x5c, 0x30, 0x36, 0xa6, 0x45, 0x43, 0xde,
+	0x63, 0x27, 0xa6, 0x1e, 0x68, 0xe8, 0x54, 0x79, 0xe2, 0x07, 0x3e, 0xee, 0x0f, 0x45, 0x11, 0xb9,
+	0x1b, 0xaf, 0xba, 0xa0, 0x34, 0x5e, 0xcf, 0x6a, 0xbc, 0xb8, 0xe8, 0x01, 0xd4, 0x32, 0xd3, 0x5c,
+	0xb2, 0xea, 0x5b, 0xd9, 0x55, 0x9b, 0x29, 0x95, 0x3a, 0x35, 0x2c, 0xb3, 0x0b, 0xff, 0xc5, 0xfe,
+	0x7d, 0x01, 0x90, 0xaa, 0x7c, 0xff, 0xcc, 0x68, 0xff, 0x0c, 0xca, 0xa6, 0x3e, 0xc1, 0x52, 0x69,
+	0xa1, 0xde, 0x6a, 0x24, 0xc5, 0xcb, 0x42, 0xd1, 0x85, 0x53, 0xa5, 0xe8, 0x07, 0x4c, 0xf5, 0x2a,
+	0x0f, 0x30, 0x0c, 0xf1, 0x0a, 0xf2, 0x1c, 0x75, 0xb5, 0xd6, 0xfd, 0x71, 0xc0, 0x05, 0x7b, 0xae,
+	0xd2, 0x9a, 0x1a, 0x5f, 0xa1, 0x35, 0x8d, 0xa9, 0x0c, 0x42, 0xf6, 0xa0, 0xe6, 0xb1, 0xc8, 0x15,
+	0xbe, 0x8a, 0x5d, 0xe3, 0xdf, 0x0d, 0x34, 0x2b, 0xd5, 0xd3, 0xee, 0xa5, 0x0c, 0xed, 0x96, 0xec,
+	0x18, 0xb2, 0x0b, 0x75, 0x76, 0x1e, 0x72, 0x21, 0xcd, 0x2c, 0xba, 0xbe, 0xbb, 0xa2, 0x2b, 0x45,
+	0xc4, 0xd5, 0x4c, 0xb4, 0xc6, 0xd2, 0x0e, 0x71, 0xa0, 0xe0, 0x3a, 0x61, 0x64, 0xee, 0xdd, 0xd6,
+	0xd2, 0x7c, 0x5d, 0x27, 0xd4, 0xfe, 0xe9, 0x7c, 0x8e, 0x6b, 0x7d, 0xf5, 0xd7, 0x8d, 0x3b, 0x99,
+	0x62, 0x65, 0xca, 0x8f, 0xe7, 0x3b, 0x2a, 0x34, 0xcf, 0x7c, 0xb9, 0x33, 0x93, 0xfe, 0x64, 0xc7,
+	0x09, 0x7d, 0x54, 0x87, 0x03, 0x07, 0x3d, 0xaa, 0x54, 0xaf, 0xfd, 0x02, 0x9a, 0xcb, 0x76, 0x7f,
+	0x88, 0xbb, 0xd7, 0xee, 0x43, 0x35, 0xb1, 0xe3, 0x5d, 0x03, 0x2b, 0xd9, 0x38, 0xf9, 0x7d, 0x0e,
+	0x4a, 0xfa, 0x00, 0x93, 0xfb, 0x50, 0x9d, 0x70, 0xd7, 0x91, 0x2a, 0x2d, 0x69, 0x97, 0x7f, 0x92,
+	0x9e, 0xef, 0xf6, 0xa3, 0x58, 0xa6, 0x77, 0x35, 0xe5, 0x62, 0x3c, 0xfb, 0xc1, 0x09, 0x8f, 0x0f,
+	0x5c, 0x23, 0x1d, 0x34, 0x08, 0x4e, 0x38, 0xd5, 0xc2, 0xb5, 0x87, 0xd0, 0x58, 0x54, 0x71, 0x89,
+	0x9d, 0x37, 0x17, 0x4f, 0x86, 0xba, 0xc3, 0x92, 0x41, 0x59, 0xb3, 0xef, 0x43, 0x35, 0xc1, 0xc9,
+	0xf6, 0x45, 0xc3, 0xeb, 0xd9, 0x91, 0x19, 0x5b, 0xed, 0x09, 0x40, 0x6a, 0x1a, 0xe6, 0x45, 0xac,
+	0xe5, 0x55, 0xd6, 0xd7, 0x66, 0x24, 0x7d, 0x95, 0xbb, 0x1d, 0xe9, 0x28, 0x53, 0xea, 0x54, 0xb5,
+	0x49, 0x1b, 0xc0, 0x4b, 0x72, 0xc3, 0x5b, 0x32, 0x46, 0x86, 0x61, 0x0f, 0xa1, 0x12, 0x1b, 0x81,
+	0x79, 0x3f, 0x32, 0x33, 0x63, 0x51, 0x8a, 0xd3, 0x15, 0x69, 0x16, 0xc2, 0xe2, 0x52, 0x38, 0xc1,
+	0x98, 0x2d, 0x14, 0x97, 0x14, 0x11, 0x6a, 0x04, 0xf6, 0x33, 0x28, 0x2a, 0x00, 0x8f, 0x59, 0x24,
+	0x1d, 0x21, 0x4d, 0x9d, 0xaa, 0x8b, 0x2c, 0x1e, 0xa9, 0x69, 0x3b, 0x05, 0x0c, 0x44, 0xaa, 0x09,
+	0xe4, 0x16, 0x96, 0x72, 0x5e, 0xcb, 0x7a, 0x2b, 0x0f, 0xc5, 0xf6, 0xcf, 0xa1, 0x12, 0xc3, 0xb8,
+	0xf2, 0x47, 0x7e, 0xc0, 0x8c, 0x89, 0xaa, 0x8d, 0xf5, 0x7d, 0xf7, 0xd4, 0x11, 0x8e, 0x2b, 0x99,
+	0x2e, 0x99, 0x8a, 0x34, 0x05, 0xec, 0x9b, 0x50, 0xcb, 0x9c, 0x1e, 0x0c, 0xb7, 0xa7, 0xca, 0x8d,
+	0xfa, 0x0c, 0xeb, 0x8e, 0xfd, 0x0a, 0xff, 0x3e, 0xe2, 0xea, 0xef, 0x27, 0x00, 0xa7, 0x52, 0x86,
+	0xcf, 0x55, 0x39, 0x68, 0xf6, 0xbe, 0x8a, 0x88, 0x62, 0x90, 0x0d, 0xa8, 0x61, 0x27, 0x32, 0x72,
+	0x1d, 0xef, 0x6a, 0x44, 0xa4, 0x09, 0x3f, 0x86, 0xea, 0x49, 0x32, 0x3c, 0x6f, 0x5c, 0x17, 0x8f,
+	0xfe, 0x04, 0x2a, 0x01, 0x37, 0x32, 0x5d, 0x9d, 0x96, 0x03, 0xae, 0x44, 0xf6, 0x1d, 0xf8, 0xd1,
+	0x85, 0x5f, 0x25, 0xbc, 0xbe, 0x4f, 0xfc, 0x89, 0x54, 0xf7, 0x0b, 0x16, 0xbc, 0xa6, 0x67, 0xff,
+	0x2b, 0x07, 0x90, 0x7a, 0x96, 0x34, 0xf5, 0x45, 0x81, 0x9c, 0xba, 0xbe, 0x18, 0x26, 0x50, 0x99,
+	0x9a, 0x3c, 0x60, 0x7c, 0x76, 0x63, 0x31, 0x1a, 0xda, 0x71, 0x9a, 0xd0, 0x19, 0x62, 0xd7, 0x64,
+	0x88, 0x0f, 0xf9, 0x9d, 0x49, 0x66, 0x50, 0x85, 0x5b, 0xf6, 0xdf, 0x15, 0xd2, 0x83, 0x46, 0x8d,
+	0x64, 0xed, 0x21, 0xac, 0x2e, 0x4c, 0xf9, 0x9e, 0xd7, 0x4f, 0x9a, 0xcf, 0xb2, 0xa7, 0xec, 0x2e,
+	0x94, 0x74, 0x31, 0x8e, 0x21, 0x81, 0x2d, 0xa3, 0x46, 0xb5, 0x55, 0x71, 0x72, 0x14, 0xff, 0x1c,
+	0x0e, 0x8e, 0xec, 0x5d, 0x28, 0xe9, 0x5f, 0x64, 0xb2, 0x05, 0x65, 0xc7, 0xd5, 0xc7, 0x31, 0x93,
+	0x12, 0x50, 0xb8, 0xa7, 0x60, 0x1a, 0x8b, 0xed, 0x3f, 0x5b, 0x00, 0x29, 0xfe, 0x01, 0x15, 0xfc,
+	0x57, 0xd0, 0x88, 0x98, 0xcb, 0x03, 0xcf, 0x11, 0x73, 0x25, 0x6d, 0x59, 0x6f, 0x1d, 0xb2, 0xc4,
+	0xcc, 0x54, 0xf3, 0xf9, 0x77, 0x57, 0xf3, 0x5b, 0x50, 0x70, 0x79, 0x38, 0x37, 0x17, 0x05, 0x59,
+	0x5c, 0x48, 0x97, 0x87, 0x73, 0x7c, 0x10, 0x40, 0x06, 0x69, 0x43, 0x69, 0x7a, 0xa6, 0x1e, 0x0d,
+	0xf4, 0x8f, 0xcf, 0xb5, 0x45, 0xee, 0xc1, 0x19, 0xb6, 0xf1, 0x89, 0x41, 0xb3, 0xc8, 0x1d, 0x28,
+	0x4e, 0xcf, 0x3c, 0x5f, 0x98, 0xb7, 0x81, 0xab, 0xcb, 0xf4, 0x9e, 0x2f, 0xd4, 0x1b, 0x01, 0x72,
+	0x88, 0x0d, 0x96, 0x98, 0xaa, 0x7f, 0x9f, 0xda, 0x6e, 0x73, 0x91, 0x49, 0xa7, 0xfb, 0x2b, 0xd4,
+	0x12, 0xd3, 0x4e, 0x05, 0x4a, 0x7a, 0x5f, 0xed, 0x7f, 0xe6, 0xa1, 0xb1, 0x68, 0x25, 0xc6, 0x41,
+	0x24, 0xdc, 0x38, 0x0e, 0x22, 0xe1, 0x5e, 0x5a, 0x9c, 0xda, 0x50, 0xe4, 0x2f, 0x03, 0x26, 0xb2,
+	0xaf, 0x23, 0xdd, 0x53, 0xfe, 0x32, 0xc0, 0xb2, 0x5d, 0x8b, 0x16, 0x0a, 0xcc, 0xa2, 0x29, 0x30,
+	0x6f, 0xc1, 0xea, 0x09, 0x9f, 0x4c, 0xf8, 0xcb, 0xd1, 0x7c, 0x3a, 0xf1, 0x83, 0x33, 0x53, 0x65,
+	0x2e, 0x82, 0x64, 0x0b, 0xae, 0x78, 0xbe, 0x40, 0x73, 0xba, 0x3c, 0x90, 0x2c, 0x50, 0xff, 0x7d,
+	0xc8, 0x5b, 0x86, 0xc9, 0xd7, 0xb0, 0xe9, 0x48, 0xc9, 0xa6, 0xa1, 0x7c, 0x12, 0x84, 0x8e, 0x7b,
+	0xd6, 0xe3, 0xae, 0x3a, 0xb3, 0xd3, 0xd0, 0x91, 0xfe, 0xb1, 0x3f, 0xc1, 0xbf, 0xe6, 0xb2, 0x1a,
+	0xfa, 0x4e, 0x1e, 0xf9, 0x0c, 0x1a, 0xae, 0x60, 0x8e, 0x64, 0x3d, 0x16, 0xc9, 0x23, 0x47, 0x9e,
+	0xb6, 0x2a, 0x6a, 0xe4, 0x12, 0x8a, 0x6b, 0x70, 0xd0, 0xda, 0x67, 0xfe, 0xc4, 0x73, 0x1d, 0xe1,
+	0xb5, 0xaa, 0x7a, 0x0d, 0x0b, 0x20, 0x69, 0x03, 0x51, 0x40, 0x7f, 0x1a, 0xca, 0x79, 0x42, 0x05,
+	0x45, 0xbd, 0x44, 0x82, 0x89, 0x53, 0xfa, 0x53, 0x16, 0x49, 0x67, 0x1a, 0xaa, 0x07, 0x9b, 0x3c,
+	0x4d, 0x01, 0xdc, 0x11, 0x3f, 0x70, 0x27, 0x33, 0x8f, 0x1d, 0xe1, 0x3a, 0x44, 0x10, 0xb5, 0xea,
+	0x2a, 0x05, 0x2d, 0xc3, 0xc8, 0x64, 0xe7, 0x8b, 0xcc, 0x55, 0xcd, 0x5c, 0x82, 0xed, 0x6f, 0x72,
+	0xd0, 0x5c, 0x0e, 0x3b, 0x74, 0x5a, 0x88, 0x4b, 0x37, 0x07, 0x18, 0xdb, 0x89, 0x23, 0xad, 0x8c,
+	0x23, 0xe3, 0x5b, 0x2f, 0x9f, 0xb9, 0xf5, 0x92, 0xa0, 0x28, 0xbc, 0x3d, 0x28, 0x16, 0x96, 0x59,
+	0x5c, 0x5a, 0xa6, 0xfd, 0xbb, 0x1c, 0x5c, 0x59, 0x0a, 0xed, 0xf7, 0xb6, 0x68, 0x13, 0x6a, 0x53,
+	0xe7, 0x8c, 0x1d, 0x39, 0x42, 0x05, 0x8c, 0xfe, 0x09, 0xcb, 0x42, 0xff, 0x03, 0xfb, 0x02, 0xa8,
+	0x67, 0xcf, 0xd3, 0xa5, 0xb6, 0xc5, 0xe1, 0x71, 0xc8, 0xe5, 0x03, 0x3e, 0x33, 0x37, 0x6a, 0x85,
+	0x2e, 0x82, 0x17, 0x83, 0x28, 0x7f, 0x49, 0x10, 0xd9, 0x87, 0x50, 0x89, 0x0d, 0x24, 0x1b, 0xe6,
+	0x19, 0x25, 0x97, 0xbe, 0x10, 0x3e, 0x89, 0x98, 0x40, 0xdb, 0x95, 0x80, 0x7c, 0x0a, 0xc5, 0xb1,
+	0xe0, 0xb3, 0xb0, 0x65, 0x5d, 0x64, 0x68, 0x89, 0x3d, 0x82, 0xb2, 0x41, 0xc8, 0x36, 0x94, 0x8e,
+	0xe7, 0x87, 0x71, 0x41, 0x63, 0x92, 0x05, 0xf6, 0x3d, 0xc3, 0xc0, 0x0c, 0xa4, 0x19, 0xe4, 0x1a,
+	0x14, 0x8e, 0xe7, 0x83, 0x9e, 0xfe, 0x9f, 0xc4, 0x3c, 0x86, 0xbd, 0x4e, 0x49, 0x1b, 0x64, 0x3f,
+	0x82, 0x7a, 0x76, 0x5c, 0xf2, 0x7b, 0x9c, 0xcb, 0xfc, 0x1e, 0x27, 0x09, 0xdb, 0x7a, 0x47, 0xc2,
+	0xde, 0xde, 0x82, 0xb2, 0x79, 0x03, 0x23, 0x55, 0x28, 0x3e, 0x39, 0x1c, 0xf5, 0x1f, 0x37, 0x57,
+	0x48, 0x05, 0x0a, 0xfb, 0xc3, 0xd1, 0xe3, 0x66, 0x0e, 0x5b, 0x87, 0xc3, 0xc3, 0x7e, 0xd3, 0xda,
+	0xbe, 0x0d, 0xf5, 0xec, 0x2b, 0x18, 0xa9, 0x41, 0x79, 0xb4, 0x77, 0xd8, 0xeb, 0x0c, 0x7f, 0xd5,
+	0x5c, 0x21, 0x75, 0xa8, 0x0c, 0x0e, 0x47, 0xfd, 0xee, 0x13, 0xda, 0x6f, 0xe6, 0xb6, 0x7f, 0x09,
+	0xd5, 0xe4, 0xe5, 0x04, 0x35, 0x74, 0x06, 0x87, 0xbd, 0xe6, 0x0a, 0x01, 0x28, 0x8d, 0xfa, 0x5d,
+	0xda, 0x47, 0xbd, 0x65, 0xc8, 0x8f, 0x46, 0xfb, 0x4d, 0x0b, 0x67, 0xed, 0xee, 0x75, 0xf7, 0xfb,
+	0xcd, 0x3c, 0x36, 0x1f, 0x1f, 0x1c, 0x3d, 0x18, 0x35, 0x0b, 0xdb, 0x5f, 0xc0, 0x95, 0xa5, 0xd7,
+	0x09, 0x35, 0x7a, 0x7f, 0x8f, 0xf6, 0x51, 0x53, 0x0d, 0xca, 0x47, 0x74, 0xf0, 0x74, 0xef, 0x71,
+	0xbf, 0x99, 0x43, 0xc1, 0xa3, 0x61, 0xf7, 0x61, 0xbf, 0xd7, 0xb4, 0x3a, 0x37, 0xbe, 0x7d, 0xbd,
+	0x9e, 0xfb, 0xee, 0xf5, 0x7a, 0xee, 0xfb, 0xd7, 0xeb, 0xb9, 0xbf, 0xbd, 0x5e, 0xcf, 0x7d, 0xf3,
+	0x66, 0x7d, 0xe5, 0xbb, 0x37, 0xeb, 0x2b, 0xdf, 0xbf, 0x59, 0x5f, 0x39, 0x2e, 0xa9, 0x87, 0xeb,
+	0xcf, 0xff, 0x3d, 0x00, 0x55, 0xd8, 0x51, 0x87, 0xf8, 0x16, 0x00, 0x00,
 }
 
 func (m *Op) Marshal() (dAtA []byte, err error) {
@@ -2628,6 +2889,27 @@ func (m *Op_Build) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Op_Merge) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Op_Merge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Merge != nil {
+		{
+			size, err := m.Merge.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
 func (m *Platform) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2743,6 +3025,34 @@ func (m *ExecOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Devices) > 0 {
+		for iNdEx := len(m.Devices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Devices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOps(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Secretenv) > 0 {
+		for iNdEx := len(m.Secretenv) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Secretenv[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOps(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if m.Security != 0 {
 		i = encodeVarintOps(dAtA, i, uint64(m.Security))
 		i--
@@ -3073,7 +3383,7 @@ func (m *SecretOpt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *SSHOpt) Marshal() (dAtA []byte, err error) {
+func (m *SecretEnv) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3083,12 +3393,12 @@ func (m *SSHOpt) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SSHOpt) MarshalTo(dAtA []byte) (int, error) {
+func (m *SecretEnv) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SSHOpt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SecretEnv) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3101,22 +3411,14 @@ func (m *SSHOpt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0
 		}
 		i--
-		dAtA[i] = 0x28
-	}
-	if m.Mode != 0 {
-		i = encodeVarintOps(dAtA, i, uint64(m.Mode))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Gid != 0 {
-		i = encodeVarintOps(dAtA, i, uint64(m.Gid))
-		i--
 		dAtA[i] = 0x18
 	}
-	if m.Uid != 0 {
-		i = encodeVarintOps(dAtA, i, uint64(m.Uid))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintOps(dAtA, i, uint64(len(m.Name)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x12
 	}
 	if len(m.ID) > 0 {
 		i -= len(m.ID)
@@ -3128,7 +3430,7 @@ func (m *SSHOpt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *SourceOp) Marshal() (dAtA []byte, err error) {
+func (m *Device) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3138,7 +3440,106 @@ func (m *SourceOp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SourceOp) MarshalTo(dAtA []byte) (int, error) {
+func (m *Device) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Device) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Permissions) > 0 {
+		i -= len(m.Permissions)
+		copy(dAtA[i:], m.Permissions)
+		i = encodeVarintOps(dAtA, i, uint64(len(m.Permissions)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Dest) > 0 {
+		i -= len(m.Dest)
+		copy(dAtA[i:], m.Dest)
+		i = encodeVarintOps(dAtA, i, uint64(len(m.Dest)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintOps(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SSHOpt) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SSHOpt) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SSHOpt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Optional {
+		i--
+		if m.Optional {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Mode != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Mode))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Gid != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Gid))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Uid != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Uid))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintOps(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SourceOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SourceOp) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
@@ -3305,6 +3706,71 @@ func (m *BuildInput) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MergeOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MergeOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MergeOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Inputs) > 0 {
+		for iNdEx := len(m.Inputs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Inputs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOps(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MergeInput) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MergeInput) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MergeInput) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Input != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Input))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *OpMetadata) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4087,6 +4553,24 @@ func (m *FileActionCopy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ExcludePatterns) > 0 {
+		for iNdEx := len(m.ExcludePatterns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ExcludePatterns[iNdEx])
+			copy(dAtA[i:], m.ExcludePatterns[iNdEx])
+			i = encodeVarintOps(dAtA, i, uint64(len(m.ExcludePatterns[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.IncludePatterns) > 0 {
+		for iNdEx := len(m.IncludePatterns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IncludePatterns[iNdEx])
+			copy(dAtA[i:], m.IncludePatterns[iNdEx])
+			i = encodeVarintOps(dAtA, i, uint64(len(m.IncludePatterns[iNdEx])))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
 	if m.Timestamp != 0 {
 		i = encodeVarintOps(dAtA, i, uint64(m.Timestamp))
 		i--
@@ -4589,6 +5073,18 @@ func (m *Op_Build) Size() (n int) {
 	}
 	return n
 }
+func (m *Op_Merge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Merge != nil {
+		l = m.Merge.Size()
+		n += 1 + l + sovOps(uint64(l))
+	}
+	return n
+}
 func (m *Platform) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4658,6 +5154,18 @@ func (m *ExecOp) Size() (n int) {
 	if m.Security != 0 {
 		n += 1 + sovOps(uint64(m.Security))
 	}
+	if len(m.Secretenv) > 0 {
+		for _, e := range m.Secretenv {
+			l = e.Size()
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.Size()
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -4790,6 +5298,47 @@ func (m *SecretOpt) Size() (n int) {
 	return n
 }
 
+func (m *SecretEnv) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovOps(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovOps(uint64(l))
+	}
+	if m.Optional {
+		n += 2
+	}
+	return n
+}
+
+func (m *Device) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovOps(uint64(l))
+	}
+	l = len(m.Dest)
+	if l > 0 {
+		n += 1 + l + sovOps(uint64(l))
+	}
+	l = len(m.Permissions)
+	if l > 0 {
+		n += 1 + l + sovOps(uint64(l))
+	}
+	return n
+}
+
 func (m *SSHOpt) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4885,6 +5434,33 @@ func (m *BuildInput) Size() (n int) {
 	return n
 }
 
+func (m *MergeOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Inputs) > 0 {
+		for _, e := range m.Inputs {
+			l = e.Size()
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MergeInput) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Input != 0 {
+		n += 1 + sovOps(uint64(m.Input))
+	}
+	return n
+}
+
 func (m *OpMetadata) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5250,6 +5826,18 @@ func (m *FileActionCopy) Size() (n int) {
 	if m.Timestamp != 0 {
 		n += 1 + sovOps(uint64(m.Timestamp))
 	}
+	if len(m.IncludePatterns) > 0 {
+		for _, s := range m.IncludePatterns {
+			l = len(s)
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
+	if len(m.ExcludePatterns) > 0 {
+		for _, s := range m.ExcludePatterns {
+			l = len(s)
+			n += 1 + l + sovOps(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -5600,9 +6188,9 @@ func (m *Op) Unmarshal(dAtA []byte) error {
 			}
 			m.Op = &Op_Build{v}
 			iNdEx = postIndex
-		case 10:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Merge", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5629,16 +6217,15 @@ func (m *Op) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Platform == nil {
-				m.Platform = &Platform{}
-			}
-			if err := m.Platform.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &MergeOp{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Op = &Op_Merge{v}
 			iNdEx = postIndex
-		case 11:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Constraints", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5665,8 +6252,44 @@ func (m *Op) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Constraints == nil {
-				m.Constraints = &WorkerConstraints{}
+			if m.Platform == nil {
+				m.Platform = &Platform{}
+			}
+			if err := m.Platform.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Constraints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Constraints == nil {
+				m.Constraints = &WorkerConstraints{}
 			}
 			if err := m.Constraints.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -6150,6 +6773,74 @@ func (m *ExecOp) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secretenv", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Secretenv = append(m.Secretenv, &SecretEnv{})
+			if err := m.Secretenv[len(m.Secretenv)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Devices = append(m.Devices, &Device{})
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOps(dAtA[iNdEx:])
@@ -6994,11 +7685,272 @@ func (m *SecretOpt) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Optional", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Optional = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SecretEnv) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SecretEnv: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SecretEnv: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Optional", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Optional = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Device) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dest", wireType)
 			}
-			m.Mode = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOps
@@ -7008,16 +7960,29 @@ func (m *SecretOpt) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Mode |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Optional", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
 			}
-			var v int
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dest = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOps
@@ -7027,12 +7992,24 @@ func (m *SecretOpt) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Optional = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Permissions = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOps(dAtA[iNdEx:])
@@ -7867,6 +8844,165 @@ func (m *BuildInput) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MergeOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MergeOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MergeOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inputs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Inputs = append(m.Inputs, &MergeInput{})
+			if err := m.Inputs[len(m.Inputs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MergeInput) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MergeInput: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MergeInput: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			}
+			m.Input = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Input |= InputIndex(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *OpMetadata) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -10322,6 +11458,70 @@ func (m *FileActionCopy) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludePatterns", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IncludePatterns = append(m.IncludePatterns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludePatterns", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExcludePatterns = append(m.ExcludePatterns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOps(dAtA[iNdEx:])
@@ -7,6 +7,7 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"sort"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -3330,6 +3331,56 @@ func TestInputRequestDeadlock(t *testing.T) {
 	j2 = nil
 }
 
+func TestWalkProvenance(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	s := NewSolver(SolverOpt{
+		ResolveOpFunc: testOpResolver,
+	})
+	defer s.Close()
+
+	j0, err := s.NewJob("job0")
+	require.NoError(t, err)
+
+	defer func() {
+		if j0 != nil {
+			j0.Discard()
+		}
+	}()
+
+	g0 := Edge{
+		Vertex: vtxSum(1, vtxOpt{
+			inputs: []Edge{
+				{Vertex: vtxProvenance(vtxConst(3, vtxOpt{name: "p0"}))},
+				{Vertex: vtxConst(4, vtxOpt{})},
+				{Vertex: vtxSum(2, vtxOpt{
+					inputs: []Edge{
+						{Vertex: vtxProvenance(vtxConst(5, vtxOpt{name: "p1"}))},
+						{Vertex: vtxProvenance(vtxConst(3, vtxOpt{name: "p0"}))},
+					},
+				})},
+			},
+		}),
+	}
+
+	res, err := j0.Build(ctx, g0)
+	require.NoError(t, err)
+	require.Equal(t, 18, unwrapInt(res))
+
+	var names []string
+	err = res.WalkProvenance(ctx, func(pp ProvenanceProvider) error {
+		names = append(names, pp.(*vertexProvenance).Name())
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Strings(names)
+	require.Equal(t, []string{"p0", "p1"}, names)
+
+	require.NoError(t, j0.Discard())
+	j0 = nil
+}
+
 func generateSubGraph(nodes int) (Edge, int) {
 	if nodes == 1 {
 		value := rand.Int() % 500
@@ -3556,6 +3607,21 @@ func (v *vertexConst) Exec(ctx context.Context, g session.Group, inputs []Result
 	return []Result{&dummyResult{id: identity.NewID(), intValue: v.value}}, nil
 }
 
+// vtxProvenance returns a vertex that reports itself as ProvenanceProvider
+func vtxProvenance(v *vertexConst) *vertexProvenance {
+	return &vertexProvenance{vertexConst: v}
+}
+
+type vertexProvenance struct {
+	*vertexConst
+}
+
+func (v *vertexProvenance) Sys() interface{} {
+	return v
+}
+
+func (v *vertexProvenance) IsProvenanceProvider() {}
+
 // vtxSum returns a vertex that ourputs sum of its inputs plus a constant
 func vtxSum(v int, opt vtxOpt) *vertexSum {
 	if opt.cacheKeySeed == "" {
@@ -69,6 +69,19 @@ type CachedResult interface {
 	CacheKeys() []ExportableCacheKey
 }
 
+// CachedResultWithProvenance is a CachedResult that can also report the
+// operations that were used to produce it.
+type CachedResultWithProvenance interface {
+	CachedResult
+	WalkProvenance(context.Context, func(ProvenanceProvider) error) error
+}
+
+// ProvenanceProvider is implemented by Op implementations that can provide
+// information about the inputs they used for build provenance.
+type ProvenanceProvider interface {
+	IsProvenanceProvider()
+}
+
 type ResultProxy interface {
 	Result(context.Context) (CachedResult, error)
 	Release(context.Context) error
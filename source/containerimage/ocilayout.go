@@ -0,0 +1,118 @@
+package containerimage
+
+import (
+	"context"
+	"io"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
+	"github.com/moby/buildkit/session"
+	sessioncontent "github.com/moby/buildkit/session/content"
+	"github.com/moby/buildkit/util/imageutil"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// OCILayoutStoreIDPrefix is prepended to the store ID of an oci-layout
+// reference to find the content store exposed by the client session.
+const OCILayoutStoreIDPrefix = "oci-layout:"
+
+// ociLayoutResolver implements remotes.Resolver on top of a content store
+// that the client exposes over the session. Manifests and blobs are read
+// directly from the store, so references must always include a digest.
+type ociLayoutResolver struct {
+	sm        *session.Manager
+	g         session.Group
+	sessionID string
+	storeID   string
+}
+
+func newOCILayoutResolver(sm *session.Manager, g session.Group, sessionID, storeID string) *ociLayoutResolver {
+	return &ociLayoutResolver{
+		sm:        sm,
+		g:         g,
+		sessionID: sessionID,
+		storeID:   storeID,
+	}
+}
+
+func (r *ociLayoutResolver) Resolve(ctx context.Context, ref string) (string, specs.Descriptor, error) {
+	spec, err := reference.Parse(ref)
+	if err != nil {
+		return "", specs.Descriptor{}, errors.WithStack(err)
+	}
+	dgst := spec.Digest()
+	if dgst == "" {
+		return "", specs.Descriptor{}, errors.Errorf("oci-layout reference %s must have a digest", ref)
+	}
+
+	var desc specs.Descriptor
+	err = r.withStore(ctx, func(store content.Store) error {
+		ra, err := store.ReaderAt(ctx, specs.Descriptor{Digest: dgst})
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s from oci-layout store %s", dgst, r.storeID)
+		}
+		defer ra.Close()
+
+		mt, err := imageutil.DetectManifestMediaType(ra)
+		if err != nil {
+			return errors.Wrapf(err, "failed to detect media type of %s", dgst)
+		}
+		desc = specs.Descriptor{
+			MediaType: mt,
+			Digest:    dgst,
+			Size:      ra.Size(),
+		}
+		return nil
+	})
+	if err != nil {
+		return "", specs.Descriptor{}, err
+	}
+	return ref, desc, nil
+}
+
+func (r *ociLayoutResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return r, nil
+}
+
+func (r *ociLayoutResolver) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	return nil, errors.New("oci-layout store is read-only")
+}
+
+func (r *ociLayoutResolver) Fetch(ctx context.Context, desc specs.Descriptor) (io.ReadCloser, error) {
+	var rc io.ReadCloser
+	err := r.withStore(ctx, func(store content.Store) error {
+		ra, err := store.ReaderAt(ctx, desc)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s from oci-layout store %s", desc.Digest, r.storeID)
+		}
+		rc = &readerAtCloser{Reader: content.NewReader(ra), Closer: ra}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
+
+// withStore calls f with the content store of the session set in the
+// reference or, if not set, with the store of every session of the build
+// until one succeeds.
+func (r *ociLayoutResolver) withStore(ctx context.Context, f func(content.Store) error) error {
+	g := r.g
+	if r.sessionID != "" {
+		g = session.NewGroup(r.sessionID)
+	}
+	if g == nil {
+		return errors.New("oci-layout source requires session")
+	}
+	return r.sm.Any(ctx, g, func(ctx context.Context, _ string, caller session.Caller) error {
+		return f(sessioncontent.NewCallerStore(caller, OCILayoutStoreIDPrefix+r.storeID))
+	})
+}
+
+type readerAtCloser struct {
+	io.Reader
+	io.Closer
+}
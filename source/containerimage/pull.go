@@ -14,10 +14,13 @@ import (
 	ctdlabels "github.com/containerd/containerd/labels"
 	"github.com/containerd/containerd/leases"
 	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/containerd/containerd/snapshots"
 	"github.com/docker/docker/errdefs"
 	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/snapshot"
@@ -47,6 +50,9 @@ type SourceOpt struct {
 	ImageStore    images.Store // optional
 	RegistryHosts docker.RegistryHosts
 	LeaseManager  leases.Manager
+	// ResolverType selects whether images are pulled from registries or read
+	// from oci-layout content stores exposed by the client session.
+	ResolverType llb.ResolverType
 }
 
 type Source struct {
@@ -65,6 +71,9 @@ func NewSource(opt SourceOpt) (*Source, error) {
 }
 
 func (is *Source) ID() string {
+	if is.ResolverType == llb.ResolverTypeOCILayout {
+		return source.OCIScheme
+	}
 	return source.DockerImageScheme
 }
 
@@ -78,13 +87,23 @@ func (is *Source) ResolveImageConfig(ctx context.Context, ref string, opt llb.Re
 		key += platforms.Format(*platform)
 	}
 
-	rm, err := source.ParseImageResolveMode(opt.ResolveMode)
-	if err != nil {
-		return "", nil, err
+	var res remotes.Resolver
+	switch is.ResolverType {
+	case llb.ResolverTypeOCILayout:
+		if opt.ResolverType != llb.ResolverTypeOCILayout {
+			return "", nil, errors.Errorf("invalid resolver type %v for oci-layout source", opt.ResolverType)
+		}
+		key = opt.Store.SessionID + ":" + opt.Store.StoreID + ":" + key
+		res = newOCILayoutResolver(sm, g, opt.Store.SessionID, opt.Store.StoreID)
+	default:
+		rm, err := source.ParseImageResolveMode(opt.ResolveMode)
+		if err != nil {
+			return "", nil, err
+		}
+		res = resolver.DefaultPool.GetResolver(is.RegistryHosts, ref, "pull", sm, g).WithImageStore(is.ImageStore, rm)
 	}
 
-	res, err := is.g.Do(ctx, key, func(ctx context.Context) (interface{}, error) {
-		res := resolver.DefaultPool.GetResolver(is.RegistryHosts, ref, "pull", sm, g).WithImageStore(is.ImageStore, rm)
+	v, err := is.g.Do(ctx, key, func(ctx context.Context) (interface{}, error) {
 		dgst, dt, err := imageutil.Config(ctx, ref, res, is.ContentStore, is.LeaseManager, opt.Platform)
 		if err != nil {
 			return nil, err
@@ -94,38 +113,63 @@ func (is *Source) ResolveImageConfig(ctx context.Context, ref string, opt llb.Re
 	if err != nil {
 		return "", nil, err
 	}
-	typed := res.(*t)
+	typed := v.(*t)
 	return typed.dgst, typed.dt, nil
 }
 
 func (is *Source) Resolve(ctx context.Context, id source.Identifier, sm *session.Manager, vtx solver.Vertex) (source.SourceInstance, error) {
-	imageIdentifier, ok := id.(*source.ImageIdentifier)
-	if !ok {
-		return nil, errors.Errorf("invalid image identifier %v", id)
-	}
-
-	platform := platforms.DefaultSpec()
-	if imageIdentifier.Platform != nil {
-		platform = *imageIdentifier.Platform
-	}
-
-	pullerUtil := &pull.Puller{
-		ContentStore: is.ContentStore,
-		Platform:     platform,
-		Src:          imageIdentifier.Reference,
-	}
 	p := &puller{
 		CacheAccessor:  is.CacheAccessor,
 		LeaseManager:   is.LeaseManager,
-		Puller:         pullerUtil,
-		id:             imageIdentifier,
 		RegistryHosts:  is.RegistryHosts,
 		ImageStore:     is.ImageStore,
-		Mode:           imageIdentifier.ResolveMode,
-		Ref:            imageIdentifier.Reference.String(),
+		ResolverType:   is.ResolverType,
 		SessionManager: sm,
 		vtx:            vtx,
 	}
+
+	var (
+		ref      reference.Spec
+		platform *specs.Platform
+	)
+	switch id := id.(type) {
+	case *source.ImageIdentifier:
+		if is.ResolverType != llb.ResolverTypeRegistry {
+			return nil, errors.Errorf("invalid oci-layout identifier %v", id)
+		}
+		ref = id.Reference
+		platform = id.Platform
+		p.Mode = id.ResolveMode
+		p.RecordType = id.RecordType
+	case *source.OCIIdentifier:
+		if is.ResolverType != llb.ResolverTypeOCILayout {
+			return nil, errors.Errorf("invalid image identifier %v", id)
+		}
+		ref = id.Reference
+		platform = id.Platform
+		p.Store = llb.ResolveImageConfigOptStore{
+			SessionID: id.SessionID,
+			StoreID:   id.StoreID(),
+		}
+	default:
+		return nil, errors.Errorf("invalid image identifier %v", id)
+	}
+
+	pullerUtil := &pull.Puller{
+		ContentStore: is.ContentStore,
+		Platform:     platforms.DefaultSpec(),
+		Src:          ref,
+	}
+	if platform != nil {
+		pullerUtil.Platform = *platform
+	}
+	if p.ResolverType == llb.ResolverTypeOCILayout {
+		// lazy blobs must be read with the sessions of the build that
+		// unpacks them, not the one that resolved the image
+		pullerUtil.NewResolver = p.resolver
+	}
+	p.Puller = pullerUtil
+	p.Ref = ref.String()
 	return p, nil
 }
 
@@ -135,9 +179,11 @@ type puller struct {
 	RegistryHosts  docker.RegistryHosts
 	ImageStore     images.Store
 	Mode           source.ResolveMode
+	RecordType     client.UsageRecordType
+	ResolverType   llb.ResolverType
+	Store          llb.ResolveImageConfigOptStore
 	Ref            string
 	SessionManager *session.Manager
-	id             *source.ImageIdentifier
 	vtx            solver.Vertex
 
 	g                flightcontrol.Group
@@ -169,8 +215,15 @@ func mainManifestKey(ctx context.Context, desc specs.Descriptor, platform specs.
 	return digest.FromBytes(dt), nil
 }
 
+func (p *puller) resolver(g session.Group) remotes.Resolver {
+	if p.ResolverType == llb.ResolverTypeOCILayout {
+		return newOCILayoutResolver(p.SessionManager, g, p.Store.SessionID, p.Store.StoreID)
+	}
+	return resolver.DefaultPool.GetResolver(p.RegistryHosts, p.Ref, "pull", p.SessionManager, g).WithImageStore(p.ImageStore, p.Mode)
+}
+
 func (p *puller) CacheKey(ctx context.Context, g session.Group, index int) (cacheKey string, cacheOpts solver.CacheOpts, cacheDone bool, err error) {
-	p.Puller.Resolver = resolver.DefaultPool.GetResolver(p.RegistryHosts, p.Ref, "pull", p.SessionManager, g).WithImageStore(p.ImageStore, p.id.ResolveMode)
+	p.Puller.Resolver = p.resolver(g)
 
 	_, err = p.g.Do(ctx, "", func(ctx context.Context) (_ interface{}, err error) {
 		if p.cacheKeyErr != nil || p.cacheKeyDone == true {
@@ -277,8 +330,15 @@ func (p *puller) CacheKey(ctx context.Context, g session.Group, index int) (cach
 	return p.configKey, cacheOpts, cacheDone, nil
 }
 
+func (p *puller) Pin() string {
+	if p.manifest == nil {
+		return ""
+	}
+	return p.manifest.MainManifestDesc.Digest.String()
+}
+
 func (p *puller) Snapshot(ctx context.Context, g session.Group) (ir cache.ImmutableRef, err error) {
-	p.Puller.Resolver = resolver.DefaultPool.GetResolver(p.RegistryHosts, p.Ref, "pull", p.SessionManager, g).WithImageStore(p.ImageStore, p.id.ResolveMode)
+	p.Puller.Resolver = p.resolver(g)
 
 	if len(p.manifest.Descriptors) == 0 {
 		return nil, nil
@@ -335,8 +395,8 @@ func (p *puller) Snapshot(ctx context.Context, g session.Group) (ir cache.Immuta
 		}
 	}
 
-	if p.id.RecordType != "" && cache.GetRecordType(current) == "" {
-		if err := cache.SetRecordType(current, p.id.RecordType); err != nil {
+	if p.RecordType != "" && cache.GetRecordType(current) == "" {
+		if err := cache.SetRecordType(current, p.RecordType); err != nil {
 			return nil, err
 		}
 	}
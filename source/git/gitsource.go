@@ -13,6 +13,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/moby/buildkit/source"
 	"github.com/moby/buildkit/util/progress/logs"
 	"github.com/moby/locker"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	bolt "go.etcd.io/bbolt"
@@ -69,9 +71,15 @@ func (gs *gitSource) ID() string {
 	return source.GitScheme
 }
 
-// needs to be called with repo lock
-func (gs *gitSource) mountRemote(ctx context.Context, remote string, auth []string, g session.Group) (target string, release func(), retErr error) {
+// mountRemote mounts the shared bare repository for remote. Partial
+// repositories only have the blobs of sparse checkouts and are kept separate
+// from the full repositories so that full checkouts never need to fetch
+// missing blobs lazily. It needs to be called with the repo lock.
+func (gs *gitSource) mountRemote(ctx context.Context, remote string, auth []string, partial bool, g session.Group) (target string, release func(), retErr error) {
 	remoteKey := "git-remote::" + remote
+	if partial {
+		remoteKey = "git-remote-partial::" + remote
+	}
 
 	sis, err := gs.md.Search(remoteKey)
 	if err != nil {
@@ -161,6 +169,7 @@ type gitSourceHandler struct {
 	*gitSource
 	src      source.GitIdentifier
 	cacheKey string
+	sha      string
 	sm       *session.Manager
 	auth     []string
 }
@@ -170,6 +179,11 @@ func (gs *gitSourceHandler) shaToCacheKey(sha string) string {
 	if gs.src.KeepGitDir {
 		key += ".git"
 	}
+	if len(gs.src.SparsePaths) > 0 {
+		paths := append([]string{}, gs.src.SparsePaths...)
+		sort.Strings(paths)
+		key += ".sparse:" + digest.FromString(strings.Join(paths, "\x00")).String()
+	}
 	return key
 }
 
@@ -311,6 +325,7 @@ func (gs *gitSourceHandler) CacheKey(ctx context.Context, g session.Group, index
 	defer gs.locker.Unlock(remote)
 
 	if isCommitSHA(ref) {
+		gs.sha = ref
 		ref = gs.shaToCacheKey(ref)
 		gs.cacheKey = ref
 		return ref, nil, true, nil
@@ -318,7 +333,7 @@ func (gs *gitSourceHandler) CacheKey(ctx context.Context, g session.Group, index
 
 	gs.getAuthToken(ctx, g)
 
-	gitDir, unmountGitDir, err := gs.mountRemote(ctx, remote, gs.auth, g)
+	gitDir, unmountGitDir, err := gs.mountRemote(ctx, remote, gs.auth, false, g)
 	if err != nil {
 		return "", nil, false, err
 	}
@@ -346,7 +361,8 @@ func (gs *gitSourceHandler) CacheKey(ctx context.Context, g session.Group, index
 
 	// TODO: should we assume that remote tag is immutable? add a timer?
 
-	buf, err := gitWithinDir(ctx, gitDir, "", sock, knownHosts, gs.auth, "ls-remote", "origin", ref)
+	// ref^{} also lists the commit an annotated tag points to
+	buf, err := gitWithinDir(ctx, gitDir, "", sock, knownHosts, gs.auth, "ls-remote", "origin", ref, ref+"^{}")
 	if err != nil {
 		return "", nil, false, errors.Wrapf(err, "failed to fetch remote %s", remote)
 	}
@@ -360,11 +376,35 @@ func (gs *gitSourceHandler) CacheKey(ctx context.Context, g session.Group, index
 	if !isCommitSHA(sha) {
 		return "", nil, false, errors.Errorf("invalid commit sha %q", sha)
 	}
+	gs.sha = peeledSHA(out, sha)
 	sha = gs.shaToCacheKey(sha)
 	gs.cacheKey = sha
 	return sha, nil, true, nil
 }
 
+// Pin returns the SHA of the resolved commit. For annotated tags, this is the
+// commit the tag points to, not the tag object.
+func (gs *gitSourceHandler) Pin() string {
+	return gs.sha
+}
+
+// peeledSHA returns the SHA of the commit the first ref of the ls-remote
+// output out points to if that ref is an annotated tag, and sha otherwise.
+func peeledSHA(out, sha string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	fields := strings.Fields(lines[0])
+	if len(fields) != 2 {
+		return sha
+	}
+	for _, l := range lines[1:] {
+		f := strings.Fields(l)
+		if len(f) == 2 && f[1] == fields[1]+"^{}" && isCommitSHA(f[0]) {
+			return f[0]
+		}
+	}
+	return sha
+}
+
 func (gs *gitSourceHandler) Snapshot(ctx context.Context, g session.Group) (out cache.ImmutableRef, retErr error) {
 	ref := gs.src.Ref
 	if ref == "" {
@@ -396,7 +436,12 @@ func (gs *gitSourceHandler) Snapshot(ctx context.Context, g session.Group) (out
 
 	gs.locker.Lock(gs.src.Remote)
 	defer gs.locker.Unlock(gs.src.Remote)
-	gitDir, unmountGitDir, err := gs.mountRemote(ctx, gs.src.Remote, gs.auth, g)
+	sparse := len(gs.src.SparsePaths) > 0
+	if sparse && gs.src.KeepGitDir {
+		return nil, errors.Errorf("sparse paths can't be used with keep git dir for %s", gs.src.Remote)
+	}
+
+	gitDir, unmountGitDir, err := gs.mountRemote(ctx, gs.src.Remote, gs.auth, sparse, g)
 	if err != nil {
 		return nil, err
 	}
@@ -435,6 +480,9 @@ func (gs *gitSourceHandler) Snapshot(ctx context.Context, g session.Group) (out
 		os.RemoveAll(filepath.Join(gitDir, "shallow.lock"))
 
 		args := []string{"fetch"}
+		if sparse {
+			args = append(args, "--filter=blob:none")
+		}
 		if !isCommitSHA(ref) { // TODO: find a branch from ls-remote?
 			args = append(args, "--depth=1", "--no-tags")
 		} else {
@@ -512,6 +560,10 @@ func (gs *gitSourceHandler) Snapshot(ctx context.Context, g session.Group) (out
 			return nil, errors.Wrapf(err, "failed to checkout remote %s", gs.src.Remote)
 		}
 		gitDir = checkoutDirGit
+	} else if sparse {
+		if err := gs.checkoutSparse(ctx, gitDir, checkoutDir, sock, knownHosts, ref); err != nil {
+			return nil, err
+		}
 	} else {
 		_, err = gitWithinDir(ctx, gitDir, checkoutDir, sock, knownHosts, nil, "checkout", ref, "--", ".")
 		if err != nil {
@@ -519,9 +571,13 @@ func (gs *gitSourceHandler) Snapshot(ctx context.Context, g session.Group) (out
 		}
 	}
 
-	_, err = gitWithinDir(ctx, gitDir, checkoutDir, sock, knownHosts, gs.auth, "submodule", "update", "--init", "--recursive", "--depth=1")
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to update submodules for %s", gs.src.Remote)
+	// submodules would need a full fetch of every submodule, so sparse
+	// checkouts leave them empty
+	if !sparse {
+		_, err = gitWithinDir(ctx, gitDir, checkoutDir, sock, knownHosts, gs.auth, "submodule", "update", "--init", "--recursive", "--depth=1")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to update submodules for %s", gs.src.Remote)
+		}
 	}
 
 	if idmap := mount.IdentityMapping(); idmap != nil {
@@ -564,6 +620,40 @@ func (gs *gitSourceHandler) Snapshot(ctx context.Context, g session.Group) (out
 	return snap, nil
 }
 
+// checkoutSparse checks out the sparse paths of ref that exist in the commit.
+// The blobs of the paths are fetched lazily from the remote by git, so the
+// credentials are passed to the checkout.
+func (gs *gitSourceHandler) checkoutSparse(ctx context.Context, gitDir, checkoutDir, sock, knownHosts, ref string) error {
+	paths := make([]string, 0, len(gs.src.SparsePaths))
+	for _, p := range gs.src.SparsePaths {
+		p = strings.TrimPrefix(filepath.Clean("/"+p), "/")
+		if p == "" {
+			p = "."
+		}
+		paths = append(paths, p)
+	}
+
+	buf, err := gitWithinDir(ctx, gitDir, "", sock, knownHosts, nil, append([]string{"ls-tree", "-z", "--name-only", ref, "--"}, paths...)...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list sparse paths of %s", gs.src.Remote)
+	}
+	var existing []string
+	for _, l := range strings.Split(buf.String(), "\x00") {
+		if l != "" {
+			existing = append(existing, l)
+		}
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	_, err = gitWithinDir(ctx, gitDir, checkoutDir, sock, knownHosts, gs.auth, append([]string{"checkout", ref, "--"}, existing...)...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to checkout remote %s", gs.src.Remote)
+	}
+	return nil
+}
+
 func isCommitSHA(str string) bool {
 	return validHex.MatchString(str)
 }
@@ -221,6 +221,49 @@ func testFetchBySHA(t *testing.T, keepGitDir bool) {
 	require.Equal(t, "subcontents\n", string(dt))
 }
 
+func TestPinAnnotatedTag(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Depends on unimplemented containerd bind-mount support on Windows")
+	}
+
+	t.Parallel()
+	ctx := namespaces.WithNamespace(context.Background(), "buildkit-test")
+
+	tmpdir, err := ioutil.TempDir("", "buildkit-state")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	gs := setupGitSource(t, tmpdir)
+
+	repodir, err := ioutil.TempDir("", "buildkit-gitsource")
+	require.NoError(t, err)
+	defer os.RemoveAll(repodir)
+
+	repodir, err = setupGitRepo(repodir)
+	require.NoError(t, err)
+
+	cmd := exec.Command("git", "rev-parse", "v1.0^{commit}")
+	cmd.Dir = repodir
+
+	out, err := cmd.Output()
+	require.NoError(t, err)
+
+	sha := strings.TrimSpace(string(out))
+	require.Equal(t, 40, len(sha))
+
+	id := &source.GitIdentifier{Remote: repodir, Ref: "v1.0"}
+
+	g, err := gs.Resolve(ctx, id, nil, nil)
+	require.NoError(t, err)
+
+	_, _, _, err = g.CacheKey(ctx, nil, 0)
+	require.NoError(t, err)
+
+	p, ok := g.(source.Pinner)
+	require.True(t, ok)
+	require.Equal(t, sha, p.Pin())
+}
+
 func TestMultipleRepos(t *testing.T) {
 	testMultipleRepos(t, false)
 }
@@ -323,6 +366,110 @@ func testMultipleRepos(t *testing.T, keepGitDir bool) {
 	require.Equal(t, "xyz\n", string(dt))
 }
 
+func TestSparseFetch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Depends on unimplemented containerd bind-mount support on Windows")
+	}
+
+	t.Parallel()
+	ctx := context.TODO()
+
+	tmpdir, err := ioutil.TempDir("", "buildkit-state")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	gs := setupGitSource(t, tmpdir)
+
+	repodir, err := ioutil.TempDir("", "buildkit-gitsource")
+	require.NoError(t, err)
+	defer os.RemoveAll(repodir)
+
+	repodir, err = setupGitRepo(repodir)
+	require.NoError(t, err)
+
+	// local repositories ignore --filter unless they allow it
+	err = runShell(repodir, "git config --local uploadpack.allowFilter true")
+	require.NoError(t, err)
+
+	out, err := exec.Command("git", "-C", repodir, "rev-parse", "feature:abc").Output()
+	require.NoError(t, err)
+	abcBlob := strings.TrimSpace(string(out))
+
+	id := &source.GitIdentifier{Remote: repodir, Ref: "feature", SparsePaths: []string{"/ghi", "missing"}}
+
+	g, err := gs.Resolve(ctx, id, nil, nil)
+	require.NoError(t, err)
+
+	key1, _, _, err := g.CacheKey(ctx, nil, 0)
+	require.NoError(t, err)
+
+	ref1, err := g.Snapshot(ctx, nil)
+	require.NoError(t, err)
+	defer ref1.Release(context.TODO())
+
+	mount, err := ref1.Mount(ctx, false, nil)
+	require.NoError(t, err)
+
+	lm := snapshot.LocalMounter(mount)
+	dir, err := lm.Mount()
+	require.NoError(t, err)
+	defer lm.Unmount()
+
+	dt, err := ioutil.ReadFile(filepath.Join(dir, "ghi"))
+	require.NoError(t, err)
+	require.Equal(t, "baz\n", string(dt))
+
+	for _, p := range []string{"abc", "def", "sub/subfile"} {
+		_, err = os.Lstat(filepath.Join(dir, p))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, os.ErrNotExist))
+	}
+
+	// the blobs outside of the sparse paths were never fetched
+	gitDir, release, err := gs.(*gitSource).mountRemote(ctx, repodir, nil, true, nil)
+	require.NoError(t, err)
+	out, err = exec.Command("git", "--git-dir", gitDir, "rev-list", "--objects", "--missing=print", "tags/feature").Output()
+	release()
+	require.NoError(t, err)
+	require.Contains(t, strings.Split(string(out), "\n"), "?"+abcBlob)
+
+	// full checkout of the same commit has a different cache key
+	id = &source.GitIdentifier{Remote: repodir, Ref: "feature"}
+
+	g, err = gs.Resolve(ctx, id, nil, nil)
+	require.NoError(t, err)
+
+	key2, _, _, err := g.CacheKey(ctx, nil, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, key1, key2)
+	require.True(t, strings.HasPrefix(key1, key2))
+
+	ref2, err := g.Snapshot(ctx, nil)
+	require.NoError(t, err)
+	defer ref2.Release(context.TODO())
+
+	mount, err = ref2.Mount(ctx, false, nil)
+	require.NoError(t, err)
+
+	lm = snapshot.LocalMounter(mount)
+	dir, err = lm.Mount()
+	require.NoError(t, err)
+	defer lm.Unmount()
+
+	dt, err = ioutil.ReadFile(filepath.Join(dir, "abc"))
+	require.NoError(t, err)
+	require.Equal(t, "foo\n", string(dt))
+
+	// sparse paths can't be combined with keeping the git directory
+	id = &source.GitIdentifier{Remote: repodir, Ref: "feature", KeepGitDir: true, SparsePaths: []string{"ghi"}}
+
+	g, err = gs.Resolve(ctx, id, nil, nil)
+	require.NoError(t, err)
+
+	_, err = g.Snapshot(ctx, nil)
+	require.Error(t, err)
+}
+
 func setupGitSource(t *testing.T, tmpdir string) source.Source {
 	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
 	assert.NoError(t, err)
@@ -397,6 +544,7 @@ func setupGitRepo(dir string) (string, error) {
 		"git submodule add "+subPath+" sub",
 		"git add -A",
 		"git commit -m withsub",
+		"git tag -a v1.0 -m v1.0",
 	); err != nil {
 		return "", err
 	}
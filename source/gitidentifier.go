@@ -16,6 +16,9 @@ type GitIdentifier struct {
 	AuthHeaderSecret string
 	MountSSHSock     string
 	KnownSSHHosts    string
+	// SparsePaths limits the checkout to these paths. Only the blobs of the
+	// paths are fetched from the remote.
+	SparsePaths []string
 }
 
 func NewGitIdentifier(remoteURL string) (*GitIdentifier, error) {
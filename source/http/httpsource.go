@@ -65,6 +65,7 @@ type httpSourceHandler struct {
 	src      source.HTTPIdentifier
 	refID    string
 	cacheKey digest.Digest
+	etag     string
 	sm       *session.Manager
 }
 
@@ -197,6 +198,8 @@ func (hs *httpSourceHandler) CacheKey(ctx context.Context, g session.Group, inde
 					hs.refID = si.ID()
 					dgst := getChecksum(si)
 					if dgst != "" {
+						hs.cacheKey = dgst
+						hs.etag = respETag
 						modTime := getModTime(si)
 						resp.Body.Close()
 						return hs.formatCacheKey(getFileName(hs.src.URL, hs.src.Filename, resp), dgst, modTime).String(), nil, true, nil
@@ -233,6 +236,8 @@ func (hs *httpSourceHandler) CacheKey(ctx context.Context, g session.Group, inde
 		if dgst == "" {
 			return "", nil, false, errors.Errorf("invalid metadata change")
 		}
+		hs.cacheKey = dgst
+		hs.etag = respETag
 		modTime := getModTime(si)
 		resp.Body.Close()
 		return hs.formatCacheKey(getFileName(hs.src.URL, hs.src.Filename, resp), dgst, modTime).String(), nil, true, nil
@@ -245,10 +250,21 @@ func (hs *httpSourceHandler) CacheKey(ctx context.Context, g session.Group, inde
 	ref.Release(context.TODO())
 
 	hs.cacheKey = dgst
+	hs.etag = resp.Header.Get("ETag")
 
 	return hs.formatCacheKey(getFileName(hs.src.URL, hs.src.Filename, resp), dgst, resp.Header.Get("Last-Modified")).String(), nil, true, nil
 }
 
+func (hs *httpSourceHandler) Pin() string {
+	return hs.cacheKey.String()
+}
+
+// ETag returns the ETag of the downloaded file, if the server sent one. It is
+// only valid after CacheKey has been called.
+func (hs *httpSourceHandler) ETag() string {
+	return hs.etag
+}
+
 func (hs *httpSourceHandler) save(ctx context.Context, resp *http.Response, s session.Group) (ref cache.ImmutableRef, dgst digest.Digest, retErr error) {
 	newRef, err := hs.cache.New(ctx, nil, s, cache.CachePolicyRetain, cache.WithDescription(fmt.Sprintf("http url %s", hs.src.URL)))
 	if err != nil {
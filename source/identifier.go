@@ -32,6 +32,9 @@ const (
 	LocalScheme       = "local"
 	HTTPScheme        = "http"
 	HTTPSScheme       = "https"
+	OCIScheme         = "oci-layout"
+
+	MountSnapshotScheme = "mount-snapshot"
 )
 
 type Identifier interface {
@@ -56,6 +59,10 @@ func FromString(s string) (Identifier, error) {
 		return NewHTTPIdentifier(parts[1], true)
 	case HTTPScheme:
 		return NewHTTPIdentifier(parts[1], false)
+	case OCIScheme:
+		return NewOCIIdentifier(parts[1])
+	case MountSnapshotScheme:
+		return NewMountSnapshotIdentifier(parts[1])
 	default:
 		return nil, errors.Wrapf(errNotFound, "unknown schema %s", parts[0])
 	}
@@ -94,6 +101,23 @@ func FromLLB(op *pb.Op_Source, platform *pb.Platform) (Identifier, error) {
 			}
 		}
 	}
+	if id, ok := id.(*OCIIdentifier); ok {
+		if platform != nil {
+			id.Platform = &specs.Platform{
+				OS:           platform.OS,
+				Architecture: platform.Architecture,
+				Variant:      platform.Variant,
+				OSVersion:    platform.OSVersion,
+				OSFeatures:   platform.OSFeatures,
+			}
+		}
+		for k, v := range op.Source.Attrs {
+			switch k {
+			case pb.AttrOCILayoutSessionID:
+				id.SessionID = v
+			}
+		}
+	}
 	if id, ok := id.(*GitIdentifier); ok {
 		for k, v := range op.Source.Attrs {
 			switch k {
@@ -111,6 +135,12 @@ func FromLLB(op *pb.Op_Source, platform *pb.Platform) (Identifier, error) {
 				id.KnownSSHHosts = v
 			case pb.AttrMountSSHSock:
 				id.MountSSHSock = v
+			case pb.AttrGitSparsePaths:
+				var paths []string
+				if err := json.Unmarshal([]byte(v), &paths); err != nil {
+					return nil, errors.Wrapf(err, "invalid %s", pb.AttrGitSparsePaths)
+				}
+				id.SparsePaths = paths
 			}
 		}
 	}
@@ -204,6 +234,35 @@ func (*ImageIdentifier) ID() string {
 	return DockerImageScheme
 }
 
+// OCIIdentifier refers to an image in an OCI layout directory that the client
+// exposes as a content store over the session. The locator of the reference
+// is the ID of the content store and the reference must have a digest.
+type OCIIdentifier struct {
+	Reference reference.Spec
+	Platform  *specs.Platform
+	SessionID string
+}
+
+func NewOCIIdentifier(str string) (*OCIIdentifier, error) {
+	ref, err := reference.Parse(str)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if ref.Digest() == "" {
+		return nil, errors.Wrapf(errInvalid, "oci-layout reference %s must have a digest", str)
+	}
+	return &OCIIdentifier{Reference: ref}, nil
+}
+
+// StoreID returns the ID of the content store the image is read from.
+func (id *OCIIdentifier) StoreID() string {
+	return id.Reference.Hostname()
+}
+
+func (*OCIIdentifier) ID() string {
+	return OCIScheme
+}
+
 type LocalIdentifier struct {
 	Name            string
 	SessionID       string
@@ -281,3 +340,20 @@ func parseImageRecordType(v string) (client.UsageRecordType, error) {
 		return "", errors.Errorf("invalid record type %s", v)
 	}
 }
+
+// MountSnapshotIdentifier refers to a mount of a gateway container that was
+// snapshotted into an immutable cache record.
+type MountSnapshotIdentifier struct {
+	RefID string
+}
+
+func NewMountSnapshotIdentifier(str string) (*MountSnapshotIdentifier, error) {
+	if str == "" {
+		return nil, errors.Wrapf(errInvalid, "empty mount snapshot ID")
+	}
+	return &MountSnapshotIdentifier{RefID: str}, nil
+}
+
+func (*MountSnapshotIdentifier) ID() string {
+	return MountSnapshotScheme
+}
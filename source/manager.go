@@ -20,6 +20,13 @@ type SourceInstance interface {
 	Snapshot(ctx context.Context, g session.Group) (cache.ImmutableRef, error)
 }
 
+// Pinner is implemented by source instances that can report the immutable
+// version of the content they resolved, e.g. an image digest or a commit SHA.
+// The returned value is only valid after CacheKey has been called.
+type Pinner interface {
+	Pin() string
+}
+
 type Manager struct {
 	mu      sync.Mutex
 	sources map[string]Source
@@ -0,0 +1,118 @@
+package mountsnapshot
+
+import (
+	"context"
+
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/cache/metadata"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/source"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// keyOwnerSessions holds the sessions of the build that created a snapshot.
+const keyOwnerSessions = "mountsnapshot.sessions"
+
+type Opt struct {
+	CacheAccessor cache.Accessor
+}
+
+// NewSource returns the source for mount-snapshot:// identifiers. The
+// snapshots are created through the gateway from the writable mounts of a
+// container and are only valid while they are kept alive by the build that
+// created them. Only the build that created a snapshot can load it.
+func NewSource(opt Opt) (source.Source, error) {
+	return &mountSnapshotSource{cm: opt.CacheAccessor}, nil
+}
+
+type mountSnapshotSource struct {
+	cm cache.Accessor
+}
+
+func (ms *mountSnapshotSource) ID() string {
+	return source.MountSnapshotScheme
+}
+
+func (ms *mountSnapshotSource) Resolve(ctx context.Context, id source.Identifier, _ *session.Manager, _ solver.Vertex) (source.SourceInstance, error) {
+	msi, ok := id.(*source.MountSnapshotIdentifier)
+	if !ok {
+		return nil, errors.Errorf("invalid mount snapshot identifier %v", id)
+	}
+	return &mountSnapshotSourceHandler{src: *msi, cm: ms.cm}, nil
+}
+
+type mountSnapshotSourceHandler struct {
+	src source.MountSnapshotIdentifier
+	cm  cache.Accessor
+}
+
+func (h *mountSnapshotSourceHandler) CacheKey(ctx context.Context, g session.Group, index int) (string, solver.CacheOpts, bool, error) {
+	// the ownership is checked before returning the key as a matching key
+	// would allow loading the cached result without calling Snapshot
+	ref, err := h.load(ctx, g)
+	if err != nil {
+		return "", nil, false, err
+	}
+	ref.Release(context.TODO())
+	// snapshot records are immutable, so the ID identifies the content
+	return source.MountSnapshotScheme + ":" + h.src.RefID, nil, true, nil
+}
+
+func (h *mountSnapshotSourceHandler) Snapshot(ctx context.Context, g session.Group) (cache.ImmutableRef, error) {
+	return h.load(ctx, g)
+}
+
+func (h *mountSnapshotSourceHandler) load(ctx context.Context, g session.Group) (cache.ImmutableRef, error) {
+	ref, err := h.cm.Get(ctx, h.src.RefID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load mount snapshot %s", h.src.RefID)
+	}
+	// only records created by snapshotting a container mount of the same
+	// build can be loaded, the ID of any other record must not give access
+	// to its content
+	if cache.GetRecordType(ref) != client.UsageRecordTypeMountSnapshot || !isOwner(ref.Metadata(), g) {
+		ref.Release(context.TODO())
+		return nil, errors.Errorf("%s is not a mount snapshot of this build", h.src.RefID)
+	}
+	return ref, nil
+}
+
+// SetOwner records the sessions of g as the owner of the snapshot ref. The
+// snapshot can only be loaded by builds with one of these sessions.
+func SetOwner(ref cache.ImmutableRef, g session.Group) error {
+	ids := session.AllSessionIDs(g)
+	if len(ids) == 0 {
+		return errors.New("mount snapshot requires a session")
+	}
+	v, err := metadata.NewValue(ids)
+	if err != nil {
+		return errors.Wrap(err, "failed to create mount snapshot owner value")
+	}
+	si := ref.Metadata()
+	si.Queue(func(b *bolt.Bucket) error {
+		return si.SetValue(b, keyOwnerSessions, v)
+	})
+	return si.Commit()
+}
+
+func isOwner(si *metadata.StorageItem, g session.Group) bool {
+	v := si.Get(keyOwnerSessions)
+	if v == nil {
+		return false
+	}
+	var owners []string
+	if err := v.Unmarshal(&owners); err != nil {
+		return false
+	}
+	for _, id := range session.AllSessionIDs(g) {
+		for _, o := range owners {
+			if id == o {
+				return true
+			}
+		}
+	}
+	return false
+}
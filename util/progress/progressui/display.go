@@ -68,6 +68,7 @@ func DisplaySolveStatus(ctx context.Context, phase string, c console.Console, w
 			width, height = disp.getSize()
 			if done {
 				disp.print(t.displayInfo(), width, height, true)
+				t.printWarnings(c)
 				t.printErrorLogs(c)
 				return nil
 			} else if displayLimiter.Allow() {
@@ -79,6 +80,7 @@ func DisplaySolveStatus(ctx context.Context, phase string, c console.Console, w
 			if done || displayLimiter.Allow() {
 				printer.print(t)
 				if done {
+					t.printWarnings(w)
 					t.printErrorLogs(w)
 					return nil
 				}
@@ -118,6 +120,7 @@ type trace struct {
 	nextIndex     int
 	updates       map[digest.Digest]struct{}
 	modeConsole   bool
+	warnings      []*client.VertexWarning
 }
 
 type vertex struct {
@@ -288,6 +291,21 @@ func (t *trace) update(s *client.SolveStatus, termWidth int) {
 		t.updates[v.Digest] = struct{}{}
 		v.update(1)
 	}
+	t.warnings = append(t.warnings, s.Warnings...)
+}
+
+func (t *trace) printWarnings(f io.Writer) {
+	if len(t.warnings) == 0 {
+		return
+	}
+	fmt.Fprintf(f, "\n%d warning(s) found:\n", len(t.warnings))
+	for _, w := range t.warnings {
+		fmt.Fprintf(f, " - %s", w.Short)
+		if w.SourceInfo != nil && len(w.Range) > 0 {
+			fmt.Fprintf(f, " (%s:%d)", w.SourceInfo.Filename, w.Range[0].Start.Line)
+		}
+		fmt.Fprintln(f)
+	}
 }
 
 func (t *trace) printErrorLogs(f io.Writer) {
@@ -26,9 +26,17 @@ import (
 
 type Puller struct {
 	ContentStore content.Store
-	Resolver     *resolver.Resolver
-	Src          reference.Spec
-	Platform     ocispec.Platform
+	// Resolver is usually a registry resolver from the resolver package.
+	// Other resolvers are used as they are for fetching lazy blobs and don't
+	// add distribution source labels to the pulled content.
+	Resolver remotes.Resolver
+	// NewResolver returns the resolver for fetching lazy blobs with the
+	// sessions of a group, e.g. when a later build unpacks the layers. If
+	// not set, registry resolvers are bound to the group and other resolvers
+	// are used as they are.
+	NewResolver func(g session.Group) remotes.Resolver
+	Src         reference.Spec
+	Platform    ocispec.Platform
 
 	g           flightcontrol.Group
 	resolveErr  error
@@ -142,16 +150,18 @@ func (p *Puller) PullManifests(ctx context.Context) (*PulledManifests, error) {
 		// Limit manifests pulled to the best match in an index
 		childrenHandler = images.LimitManifests(childrenHandler, platform, 1)
 
-		dslHandler, err := docker.AppendDistributionSourceLabel(p.ContentStore, p.ref)
-		if err != nil {
-			return nil, err
-		}
 		handlers = append(handlers,
 			filterLayerBlobs(metadata, &mu),
 			retryhandler.New(remotes.FetchHandler(p.ContentStore, fetcher), logs.LoggerFromContext(ctx)),
 			childrenHandler,
-			dslHandler,
 		)
+		if _, ok := p.Resolver.(*resolver.Resolver); ok {
+			dslHandler, err := docker.AppendDistributionSourceLabel(p.ContentStore, p.ref)
+			if err != nil {
+				return nil, err
+			}
+			handlers = append(handlers, dslHandler)
+		}
 	}
 
 	if err := images.Dispatch(ctx, images.Handlers(handlers...), nil, p.desc); err != nil {
@@ -195,7 +205,13 @@ func (p *Puller) PullManifests(ctx context.Context) (*PulledManifests, error) {
 		Nonlayers:        p.nonlayers,
 		Descriptors:      p.layers,
 		Provider: func(g session.Group) content.Provider {
-			return &provider{puller: p, resolver: p.Resolver.WithSession(g)}
+			r := p.Resolver
+			if p.NewResolver != nil {
+				r = p.NewResolver(g)
+			} else if rr, ok := r.(*resolver.Resolver); ok {
+				r = rr.WithSession(g)
+			}
+			return &provider{puller: p, resolver: r}
 		},
 	}, nil
 }
@@ -28,7 +28,89 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const distributionSourceLabelPrefix = "containerd.io/distribution.source."
+
+// Result is the outcome of pushing an image to one of the names passed to
+// PushAll.
+type Result struct {
+	Name   string        `json:"name"`
+	Digest digest.Digest `json:"digest,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// PushAll pushes the image to every ref and returns a result for each of
+// them instead of stopping at the first error. Pushes to different registries
+// run in parallel. Refs on the same registry are pushed one after another so
+// that blobs already uploaded to an earlier repository are mounted instead of
+// being uploaded again.
+func PushAll(ctx context.Context, sm *session.Manager, sid string, provider content.Provider, manager content.Manager, dgst digest.Digest, refs []string, insecure bool, hosts docker.RegistryHosts, byDigest bool, annotations map[digest.Digest]map[string]string) []Result {
+	results := make([]Result, len(refs))
+	var domains []string
+	byDomain := map[string][]int{}
+	for i, ref := range refs {
+		results[i].Name = ref
+		parsed, err := reference.ParseNormalizedNamed(ref)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		domain := reference.Domain(parsed)
+		if _, ok := byDomain[domain]; !ok {
+			domains = append(domains, domain)
+		}
+		byDomain[domain] = append(byDomain[domain], i)
+	}
+
+	var wg sync.WaitGroup
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain string, idxs []int) {
+			defer wg.Done()
+			var mounts []string
+			for _, i := range idxs {
+				var mountFrom map[string]string
+				if len(mounts) > 0 {
+					mountFrom = map[string]string{
+						distributionSourceLabelPrefix + domain: strings.Join(mounts, ","),
+					}
+				}
+				if err := push(ctx, sm, sid, provider, manager, dgst, refs[i], insecure, hosts, byDigest, annotations, mountFrom); err != nil {
+					results[i].Error = err.Error()
+					continue
+				}
+				results[i].Digest = dgst
+				parsed, _ := reference.ParseNormalizedNamed(refs[i])
+				mounts = append(mounts, reference.Path(parsed))
+			}
+		}(domain, byDomain[domain])
+	}
+	wg.Wait()
+	return results
+}
+
+// ResultsError returns an error listing every name that failed to push, or
+// nil if all the pushes succeeded.
+func ResultsError(results []Result) error {
+	var errs []string
+	for _, r := range results {
+		if r.Error != "" {
+			errs = append(errs, fmt.Sprintf("%s: %s", r.Name, r.Error))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.Errorf("failed to push image to %d of %d names: %s", len(errs), len(results), strings.Join(errs, "; "))
+}
+
 func Push(ctx context.Context, sm *session.Manager, sid string, provider content.Provider, manager content.Manager, dgst digest.Digest, ref string, insecure bool, hosts docker.RegistryHosts, byDigest bool, annotations map[digest.Digest]map[string]string) error {
+	return push(ctx, sm, sid, provider, manager, dgst, ref, insecure, hosts, byDigest, annotations, nil)
+}
+
+// push pushes the image to ref. mountFrom contains distribution source
+// annotations that are added to every blob so that the registry can mount
+// them from other repositories.
+func push(ctx context.Context, sm *session.Manager, sid string, provider content.Provider, manager content.Manager, dgst digest.Digest, ref string, insecure bool, hosts docker.RegistryHosts, byDigest bool, annotations map[digest.Digest]map[string]string, mountFrom map[string]string) error {
 	desc := ocispec.Descriptor{
 		Digest: dgst,
 	}
@@ -89,7 +171,7 @@ func Push(ctx context.Context, sm *session.Manager, sid string, provider content
 	}
 
 	handlers := append([]images.Handler{},
-		images.HandlerFunc(annotateDistributionSourceHandler(manager, annotations, childrenHandler(provider))),
+		images.HandlerFunc(annotateDistributionSourceHandler(manager, annotations, mountFrom, childrenHandler(provider))),
 		filterHandler,
 		dedupeHandler(pushUpdateSourceHandler),
 	)
@@ -104,7 +186,7 @@ func Push(ctx context.Context, sm *session.Manager, sid string, provider content
 		return err
 	}
 
-	layersDone := oneOffProgress(ctx, "pushing layers")
+	layersDone := oneOffProgress(ctx, fmt.Sprintf("pushing layers for %s", ref))
 	err = images.Dispatch(ctx, images.Handlers(handlers...), nil, ocispec.Descriptor{
 		Digest:    dgst,
 		Size:      ra.Size(),
@@ -123,7 +205,7 @@ func Push(ctx context.Context, sm *session.Manager, sid string, provider content
 	return mfstDone(nil)
 }
 
-func annotateDistributionSourceHandler(manager content.Manager, annotations map[digest.Digest]map[string]string, f images.HandlerFunc) func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+func annotateDistributionSourceHandler(manager content.Manager, annotations map[digest.Digest]map[string]string, mountFrom map[string]string, f images.HandlerFunc) func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 	return func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 		children, err := f(ctx, desc)
 		if err != nil {
@@ -143,7 +225,7 @@ func annotateDistributionSourceHandler(manager content.Manager, annotations map[
 
 			if m, ok := annotations[child.Digest]; ok {
 				for k, v := range m {
-					if !strings.HasPrefix(k, "containerd.io/distribution.source.") {
+					if !strings.HasPrefix(k, distributionSourceLabelPrefix) {
 						continue
 					}
 					if child.Annotations == nil {
@@ -152,6 +234,17 @@ func annotateDistributionSourceHandler(manager content.Manager, annotations map[
 					child.Annotations[k] = v
 				}
 			}
+			switch child.MediaType {
+			case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest,
+				images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+			default:
+				for k, v := range mountFrom {
+					if child.Annotations == nil {
+						child.Annotations = map[string]string{}
+					}
+					child.Annotations[k] = appendSources(child.Annotations[k], v)
+				}
+			}
 			children[i] = child
 
 			info, err := manager.Info(ctx, child.Digest)
@@ -162,14 +255,14 @@ func annotateDistributionSourceHandler(manager content.Manager, annotations map[
 			}
 
 			for k, v := range info.Labels {
-				if !strings.HasPrefix(k, "containerd.io/distribution.source.") {
+				if !strings.HasPrefix(k, distributionSourceLabelPrefix) {
 					continue
 				}
 
 				if child.Annotations == nil {
 					child.Annotations = map[string]string{}
 				}
-				child.Annotations[k] = v
+				child.Annotations[k] = appendSources(child.Annotations[k], v)
 			}
 
 			children[i] = child
@@ -178,6 +271,27 @@ func annotateDistributionSourceHandler(manager content.Manager, annotations map[
 	}
 }
 
+// appendSources merges two comma separated lists of repositories.
+func appendSources(a, b string) string {
+	if a == "" {
+		return b
+	}
+	repos := strings.Split(a, ",")
+	for _, r := range strings.Split(b, ",") {
+		found := false
+		for _, v := range repos {
+			if v == r {
+				found = true
+				break
+			}
+		}
+		if !found && r != "" {
+			repos = append(repos, r)
+		}
+	}
+	return strings.Join(repos, ",")
+}
+
 func oneOffProgress(ctx context.Context, id string) func(err error) error {
 	pw, _, _ := progress.FromContext(ctx)
 	now := time.Now()
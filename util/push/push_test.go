@@ -0,0 +1,57 @@
+package push
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/images"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendSources(t *testing.T) {
+	require.Equal(t, "foo/bar", appendSources("", "foo/bar"))
+	require.Equal(t, "foo/bar,foo/baz", appendSources("foo/bar", "foo/baz"))
+	require.Equal(t, "foo/bar,foo/baz", appendSources("foo/bar,foo/baz", "foo/baz,"))
+}
+
+func TestAnnotateMountFrom(t *testing.T) {
+	cs, err := local.NewStore(t.TempDir())
+	require.NoError(t, err)
+
+	layer := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageLayerGzip, Digest: digest.FromBytes([]byte("layer"))}
+	config := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromBytes([]byte("config"))}
+	mfst := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromBytes([]byte("manifest"))}
+
+	annotations := map[digest.Digest]map[string]string{
+		layer.Digest: {
+			distributionSourceLabelPrefix + "docker.io": "library/alpine",
+		},
+	}
+	mountFrom := map[string]string{
+		distributionSourceLabelPrefix + "docker.io": "user/app",
+	}
+	children := func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return []ocispec.Descriptor{config, layer}, nil
+	}
+
+	h := annotateDistributionSourceHandler(cs, annotations, mountFrom, images.HandlerFunc(children))
+	descs, err := h(context.TODO(), mfst)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(descs))
+	require.Equal(t, "user/app", descs[0].Annotations[distributionSourceLabelPrefix+"docker.io"])
+	require.Equal(t, "library/alpine,user/app", descs[1].Annotations[distributionSourceLabelPrefix+"docker.io"])
+}
+
+func TestResultsError(t *testing.T) {
+	require.NoError(t, ResultsError([]Result{{Name: "docker.io/user/app:latest"}}))
+
+	err := ResultsError([]Result{
+		{Name: "docker.io/user/app:latest", Error: "unauthorized"},
+		{Name: "ghcr.io/user/app:v1"},
+		{Name: "quay.io/user/app:v1", Error: "timeout"},
+	})
+	require.EqualError(t, err, "failed to push image to 2 of 3 names: docker.io/user/app:latest: unauthorized; quay.io/user/app:v1: timeout")
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/moby/buildkit/source/git"
 	"github.com/moby/buildkit/source/http"
 	"github.com/moby/buildkit/source/local"
+	"github.com/moby/buildkit/source/mountsnapshot"
 	"github.com/moby/buildkit/util/archutil"
 	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/util/progress/controller"
@@ -80,10 +81,11 @@ type WorkerOpt struct {
 // TODO: s/Worker/OpWorker/g ?
 type Worker struct {
 	WorkerOpt
-	CacheMgr      cache.Manager
-	SourceManager *source.Manager
-	imageWriter   *imageexporter.ImageWriter
-	ImageSource   *containerimage.Source
+	CacheMgr        cache.Manager
+	SourceManager   *source.Manager
+	imageWriter     *imageexporter.ImageWriter
+	ImageSource     *containerimage.Source
+	OCILayoutSource *containerimage.Source
 }
 
 // NewWorker instantiates a local worker
@@ -127,6 +129,20 @@ func NewWorker(opt WorkerOpt) (*Worker, error) {
 
 	sm.Register(is)
 
+	oss, err := containerimage.NewSource(containerimage.SourceOpt{
+		Snapshotter:   opt.Snapshotter,
+		ContentStore:  opt.ContentStore,
+		Applier:       opt.Applier,
+		CacheAccessor: cm,
+		LeaseManager:  opt.LeaseManager,
+		ResolverType:  llb.ResolverTypeOCILayout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sm.Register(oss)
+
 	if err := git.Supported(); err == nil {
 		gs, err := git.NewSource(git.Opt{
 			CacheAccessor: cm,
@@ -159,6 +175,14 @@ func NewWorker(opt WorkerOpt) (*Worker, error) {
 	}
 	sm.Register(ss)
 
+	mss, err := mountsnapshot.NewSource(mountsnapshot.Opt{
+		CacheAccessor: cm,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sm.Register(mss)
+
 	iw, err := imageexporter.NewImageWriter(imageexporter.WriterOpt{
 		Snapshotter:  opt.Snapshotter,
 		ContentStore: opt.ContentStore,
@@ -178,11 +202,12 @@ func NewWorker(opt WorkerOpt) (*Worker, error) {
 	}
 
 	return &Worker{
-		WorkerOpt:     opt,
-		CacheMgr:      cm,
-		SourceManager: sm,
-		imageWriter:   iw,
-		ImageSource:   is,
+		WorkerOpt:       opt,
+		CacheMgr:        cm,
+		SourceManager:   sm,
+		imageWriter:     iw,
+		ImageSource:     is,
+		OCILayoutSource: oss,
 	}, nil
 }
 
@@ -268,6 +293,8 @@ func (w *Worker) ResolveOp(v solver.Vertex, s frontend.FrontendLLBBridge, sm *se
 			return ops.NewFileOp(v, op, w.CacheMgr, w.WorkerOpt.MetadataStore, w)
 		case *pb.Op_Build:
 			return ops.NewBuildOp(v, op, s, w)
+		case *pb.Op_Merge:
+			return ops.NewMergeOp(v, op, w)
 		default:
 			return nil, errors.Errorf("no support for %T", op)
 		}
@@ -322,7 +349,106 @@ func (w *Worker) PruneCacheMounts(ctx context.Context, ids []string) error {
 }
 
 func (w *Worker) ResolveImageConfig(ctx context.Context, ref string, opt llb.ResolveImageConfigOpt, sm *session.Manager, g session.Group) (digest.Digest, []byte, error) {
-	return w.ImageSource.ResolveImageConfig(ctx, ref, opt, sm, g)
+	switch opt.ResolverType {
+	case llb.ResolverTypeOCILayout:
+		return w.OCILayoutSource.ResolveImageConfig(ctx, ref, opt, sm, g)
+	default:
+		return w.ImageSource.ResolveImageConfig(ctx, ref, opt, sm, g)
+	}
+}
+
+func (w *Worker) ResolveSourceMetadata(ctx context.Context, op *pb.SourceOp, opt llb.ResolveSourceMetadataOpt, sm *session.Manager, g session.Group) (*llb.SourceMetadata, error) {
+	var platform *pb.Platform
+	if p := opt.Platform; p != nil {
+		platform = &pb.Platform{
+			OS:           p.OS,
+			Architecture: p.Architecture,
+			Variant:      p.Variant,
+			OSVersion:    p.OSVersion,
+			OSFeatures:   p.OSFeatures,
+		}
+	}
+	id, err := source.FromLLB(&pb.Op_Source{Source: op}, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	switch id := id.(type) {
+	case *source.ImageIdentifier:
+		dgst, config, err := w.ResolveImageConfig(ctx, id.Reference.String(), llb.ResolveImageConfigOpt{
+			Platform:    opt.Platform,
+			ResolveMode: id.ResolveMode.String(),
+			LogName:     opt.LogName,
+		}, sm, g)
+		if err != nil {
+			return nil, err
+		}
+		return &llb.SourceMetadata{
+			Image: &llb.ImageSourceMetadata{Digest: dgst, Config: config},
+		}, nil
+	case *source.OCIIdentifier:
+		dgst, config, err := w.ResolveImageConfig(ctx, id.Reference.String(), llb.ResolveImageConfigOpt{
+			ResolverType: llb.ResolverTypeOCILayout,
+			Platform:     opt.Platform,
+			LogName:      opt.LogName,
+			Store: llb.ResolveImageConfigOptStore{
+				SessionID: id.SessionID,
+				StoreID:   id.StoreID(),
+			},
+		}, sm, g)
+		if err != nil {
+			return nil, err
+		}
+		return &llb.SourceMetadata{
+			Image: &llb.ImageSourceMetadata{Digest: dgst, Config: config},
+		}, nil
+	case *source.GitIdentifier:
+		src, err := w.resolveSource(ctx, id, sm, g)
+		if err != nil {
+			return nil, err
+		}
+		ref := id.Ref
+		if ref == "" {
+			ref = "master"
+		}
+		return &llb.SourceMetadata{
+			Git: &llb.GitSourceMetadata{Ref: ref, Commit: src.Pin()},
+		}, nil
+	case *source.HTTPIdentifier:
+		src, err := w.resolveSource(ctx, id, sm, g)
+		if err != nil {
+			return nil, err
+		}
+		dgst, err := digest.Parse(src.Pin())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid checksum for %s", id.URL)
+		}
+		md := &llb.HTTPSourceMetadata{Checksum: dgst}
+		if e, ok := src.(interface{ ETag() string }); ok {
+			md.ETag = e.ETag()
+		}
+		return &llb.SourceMetadata{HTTP: md}, nil
+	default:
+		return nil, errors.Errorf("resolving metadata of %s sources is not supported", id.ID())
+	}
+}
+
+// resolveSource computes the cache key of a source, which resolves the
+// version of the source, and returns the source so the pinned version can be
+// read.
+func (w *Worker) resolveSource(ctx context.Context, id source.Identifier, sm *session.Manager, g session.Group) (source.Pinner, error) {
+	src, err := w.SourceManager.Resolve(ctx, id, sm, nil)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := src.(source.Pinner)
+	if !ok {
+		return nil, errors.Errorf("%s source can't be pinned", id.ID())
+	}
+	if _, _, _, err := src.CacheKey(ctx, g, 0); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 func (w *Worker) DiskUsage(ctx context.Context, opt client.DiskUsageInfo) ([]*client.UsageInfo, error) {